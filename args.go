@@ -194,6 +194,24 @@ const (
 	ArgSSHCommand = "ssh-command"
 	// ArgSSHRetryMax is a ssh argument.
 	ArgSSHRetryMax = "ssh-retry-max"
+	// ArgSSHClient is the ssh argument that selects the SSH implementation.
+	ArgSSHClient = "ssh-client"
+	// ArgSSHHostKeyChecking is the ssh argument that sets the host key checking policy.
+	ArgSSHHostKeyChecking = "ssh-host-key-checking"
+	// ArgSSHParallel is the ssh argument that limits how many Droplets run a command at once.
+	ArgSSHParallel = "parallel"
+	// ArgSSHJump is the ssh argument for a Droplet to connect through.
+	ArgSSHJump = "jump"
+	// ArgSSHLocalForward is the ssh tunnel argument for a port to forward.
+	ArgSSHLocalForward = "local-forward"
+	// ArgSCPRecursive is the scp argument that copies directories and their contents.
+	ArgSCPRecursive = "recursive"
+	// ArgSCPProgress is the scp argument that displays the progress of each file.
+	ArgSCPProgress = "progress"
+	// ArgInventoryWriteSSHConfig is the droplet inventory argument that writes the hosts into an SSH config file.
+	ArgInventoryWriteSSHConfig = "write-ssh-config"
+	// ArgInventorySSHConfigPath is the droplet inventory argument for the SSH config file to write.
+	ArgInventorySSHConfigPath = "ssh-config-path"
 	// ArgUserData is a user data argument.
 	ArgUserData = "user-data"
 	// ArgUserDataFile is a user data file location argument.
@@ -214,6 +232,12 @@ const (
 	ArgKeyPublicKey = "public-key"
 	// ArgKeyPublicKeyFile is a public key file argument.
 	ArgKeyPublicKeyFile = "public-key-file"
+	// ArgKeyPrivateKeyFile is the file a generated private key is written to.
+	ArgKeyPrivateKeyFile = "private-key-file"
+	// ArgKeyType is the type of key to generate.
+	ArgKeyType = "type"
+	// ArgKeySetDefault sets a generated key as the default SSH key for the current context.
+	ArgKeySetDefault = "set-default"
 	// ArgSSHUser is a SSH user argument.
 	ArgSSHUser = "ssh-user"
 	// ArgFormat is columns to include in output argument.
@@ -314,6 +338,10 @@ const (
 	ArgCertificateChainPath = "certificate-chain-path"
 	// ArgCertificateType is a certificate type.
 	ArgCertificateType = "type"
+	// ArgCertificateWithin is how soon a certificate must expire to be listed.
+	ArgCertificateWithin = "within"
+	// ArgCertificateKeepOld keeps the old certificate after a rotation.
+	ArgCertificateKeepOld = "keep-old"
 
 	// ArgLoadBalancerName is a name of the load balancer.
 	ArgLoadBalancerName = "name"
@@ -349,11 +377,29 @@ const (
 	ArgGlobalLoadBalancerCDNSettings = "glb-cdn-settings"
 	// ArgTargetLoadBalancerIDs is a list of target load balancer IDs.
 	ArgTargetLoadBalancerIDs = "target-lb-ids"
+	// ArgLoadBalancerSpec is a path to a load balancer spec, or for get, whether to print one.
+	ArgLoadBalancerSpec = "spec"
+	// ArgSwapToTag is the tag of the Droplets a load balancer swap moves traffic to.
+	ArgSwapToTag = "to-tag"
+	// ArgSwapFromTag is the tag of the Droplets a load balancer swap moves traffic from.
+	ArgSwapFromTag = "from-tag"
 	// ArgLoadBalancerNetwork is the type of network the load balancer is accessible from.
 	ArgLoadBalancerNetwork = "network"
 
 	// ArgFirewallName is a name of the firewall.
 	ArgFirewallName = "name"
+	// ArgFirewallCheckDroplet is the Droplet that firewall check sends traffic to.
+	ArgFirewallCheckDroplet = "droplet"
+	// ArgFirewallCheckFrom is the address, tag or Droplet that firewall check sends traffic from.
+	ArgFirewallCheckFrom = "from"
+	// ArgFirewallCheckPort is the port that firewall check sends traffic to.
+	ArgFirewallCheckPort = "port"
+	// ArgFirewallCheckProtocol is the protocol of the traffic that firewall check evaluates.
+	ArgFirewallCheckProtocol = "protocol"
+	// ArgFirewallAuditPorts are ports that firewall audit treats as sensitive, in addition to its defaults.
+	ArgFirewallAuditPorts = "sensitive-ports"
+	// ArgFirewallAuditFailOn is the lowest severity of finding that makes firewall audit fail.
+	ArgFirewallAuditFailOn = "fail-on"
 	// ArgInboundRules is a list of inbound rules for the firewall.
 	ArgInboundRules = "inbound-rules"
 	// ArgOutboundRules is a list of outbound rules for the firewall.
@@ -404,6 +450,10 @@ const (
 	ArgDatabaseUserMySQLAuthPlugin = "mysql-auth-plugin"
 	// ArgDatabasePrivateConnectionBool determine if the private connection details should be shown
 	ArgDatabasePrivateConnectionBool = "private"
+	// ArgDatabaseTunnelDroplet is the Droplet that a database tunnel connects through
+	ArgDatabaseTunnelDroplet = "droplet"
+	// ArgDatabaseTunnelLocalPort is the local port that a database tunnel listens on
+	ArgDatabaseTunnelLocalPort = "local-port"
 	// ArgDatabaseUserKafkaACLs will specify permissions on topics in kafka clsuter
 	ArgDatabaseUserKafkaACLs = "acl"
 
@@ -459,6 +509,44 @@ const (
 
 	// ArgForce forces confirmation on actions
 	ArgForce = "force"
+	// ArgConcurrency is the maximum number of resources a multi-target command acts on at once.
+	ArgConcurrency = "concurrency"
+	// ArgContinueOnError keeps a multi-target command going after one of its resources fails.
+	ArgContinueOnError = "continue-on-error"
+	// ArgFromFile is the path to a file of resource IDs or names to act on.
+	ArgFromFile = "from-file"
+	// ArgSearchType restricts a search to the given resource types.
+	ArgSearchType = "type"
+	// ArgManifestFile is the path to a manifest of resources to apply.
+	ArgManifestFile = "file"
+	// ArgPrune allows apply to delete resources that are missing from a manifest.
+	ArgPrune = "prune"
+	// ArgProject is the ID or name of a project to limit a command to.
+	ArgProject = "project"
+	// ArgPlan shows the changes a command would make without making them.
+	ArgPlan = "plan"
+	// ArgRulesFile is the path to a file of firewall rules.
+	ArgRulesFile = "file"
+	// ArgRecordCheckName limits a DNS propagation check to records with the given name.
+	ArgRecordCheckName = "name"
+	// ArgNameServers is a list of authoritative name servers to query.
+	ArgNameServers = "name-servers"
+	// ArgResolvers is a list of recursive DNS resolvers to query.
+	ArgResolvers = "resolvers"
+	// ArgDDNSInterval is how often the dynamic DNS updater checks the public address.
+	ArgDDNSInterval = "interval"
+	// ArgDDNSIPSource is where the dynamic DNS updater finds the public address.
+	ArgDDNSIPSource = "ip-source"
+	// ArgDDNSIPv4URL is the URL that returns the public IPv4 address.
+	ArgDDNSIPv4URL = "ipv4-url"
+	// ArgDDNSIPv6URL is the URL that returns the public IPv6 address.
+	ArgDDNSIPv6URL = "ipv6-url"
+	// ArgDDNSInterface is the network interface that holds the public address.
+	ArgDDNSInterface = "interface"
+	// ArgDDNSIPv4 updates the A record with the public IPv4 address.
+	ArgDDNSIPv4 = "ipv4"
+	// ArgDDNSIPv6 updates the AAAA record with the public IPv6 address.
+	ArgDDNSIPv6 = "ipv6"
 
 	// ArgObjectName is the Kubernetes object name
 	ArgObjectName = "name"
@@ -48,7 +48,7 @@ You can retrieve information for a specific action by adding the action's ID as
 - The region in which the action took place (nyc3, sfo2, etc)`
 
 	cmdActionGet := CmdBuilder(cmd, RunCmdActionGet, "get <action-id>", "Retrieve details about a specific action", `Retrieve the following details about a specific action taken on one of your resources:`+actionDetails, Writer,
-		aliasOpt("g"), displayerType(&displayers.Action{}))
+		aliasOpt("g"), displayerType(&displayers.Action{}), fromFileOpt())
 	cmdActionGet.Example = `The following example retrieves the action's ID, status, and resource type of the action with ID 123456: doctl compute action get 123456 --format ID,Status,ResourceType`
 
 	cmdActionList := CmdBuilder(cmd, RunCmdActionList, "list", "Retrieve a  list of all recent actions taken on your resources", `Retrieve a list of all actions taken on your resources. The following details are provided:`+actionDetails, Writer,
@@ -64,7 +64,7 @@ You can retrieve information for a specific action by adding the action's ID as
 	cmdActionWait := CmdBuilder(cmd, RunCmdActionWait, "wait <action-id>", "Block thread until an action completes", `Block the current thread, returning when an action completes.
 
 For example, if you find an action when calling `+"`"+`doctl compute action list`+"`"+` that has a status of `+"`"+`in-progress`+"`"+`, you can note the action ID and call `+"`"+`doctl compute action wait <action-id>`+"`"+`, and doctl will appear to "hang" until the action has completed. This can be useful for scripting purposes.`, Writer,
-		aliasOpt("w"), displayerType(&displayers.Action{}))
+		aliasOpt("w"), displayerType(&displayers.Action{}), fromFileOpt())
 	cmdActionWait.Example = `The following example waits for the action ` + "`" + `123456` + "`" + ` to complete before allowing further commands to execute: doctl compute action wait 123456`
 	AddIntFlag(cmdActionWait, doctl.ArgPollTime, "", 5, "Re-poll time in seconds")
 
@@ -54,7 +54,7 @@ activation records of functions deployed to your functions namespace.`,
 		`Retrieve the activation record for a previously invoked function. You can limit output to the result
 or the logs.  The `+"`"+`doctl serverless activation logs`+"`"+` command has additional advanced capabilities for retrieving
 logs.`,
-		Writer)
+		Writer, fromFileOpt())
 	AddBoolFlag(get, "last", "l", false, "Retrieve the most recent activation (default). Does not return activations for web-invoked functions.")
 	AddIntFlag(get, "skip", "s", 0, "Exclude a specified number of activations from the returned list, starting with the most recent.")
 	AddBoolFlag(get, "logs", "g", false, "Retrieve only the logs, stripped of time stamps and stream identifier.")
@@ -69,6 +69,7 @@ invoked functions.`,
 		Writer,
 		aliasOpt("ls"),
 		displayerType(&displayers.Activation{}),
+		fromFileOpt(),
 	)
 	AddIntFlag(list, "limit", "l", 30, "Limit the number of activations returned to the specified amount. Default: 30, Maximum: 200")
 	AddIntFlag(list, "skip", "s", 0, "Exclude a specified number of activations from the returned list, starting with the most recent.")
@@ -82,7 +83,7 @@ invoked functions.`,
 		`Use `+"`"+`doctl serverless activations logs`+"`"+` to retrieve the logs portion of one or more activation records
 with various options, such as selecting by package or function, and optionally watching continuously
 for new arrivals.`,
-		Writer)
+		Writer, fromFileOpt())
 	AddStringFlag(logs, "function", "f", "", "Retrieve the logs for a specific function.")
 	AddStringFlag(logs, "package", "p", "", "Retrieve the logs for a specific package.")
 	AddBoolFlag(logs, "last", "l", false, "Retrieve logs for the most recent activation (default).")
@@ -98,7 +99,7 @@ for new arrivals.`,
 	result := CmdBuilder(cmd, RunActivationsResult, "result [<activationId>]", "Retrieve the output for an activation.",
 		`Retrieve just the results portion
 of one or more activation records.`,
-		Writer)
+		Writer, fromFileOpt())
 	AddBoolFlag(result, "last", "l", false, "Retrieve the most recent activation result (default).")
 	AddIntFlag(result, "limit", "n", 1, "Limit the number of results return to the specified number. (default 30, max 200)")
 	AddIntFlag(result, "skip", "s", 0, "Exclude a specified number of activations from the returned list, starting with the most recent.")
@@ -0,0 +1,1175 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/fatih/color"
+)
+
+const (
+	planCreate = "create"
+	planUpdate = "update"
+	planDelete = "delete"
+)
+
+var planPastTense = map[string]string{
+	planCreate: "Created",
+	planUpdate: "Updated",
+	planDelete: "Deleted",
+}
+
+const manifestDetail = `
+
+The manifest is a YAML file that describes tags, Droplets, volumes, reserved IPs, load balancers, cloud firewalls, domains and their records, and alert policies, each keyed by name. Alert policies are keyed by their description. ` + "`" + `doctl export` + "`" + ` writes your existing resources in this format:
+
+    tags:
+      - web
+    droplets:
+      - name: web-01
+        region: nyc1
+        size: s-1vcpu-1gb
+        image: ubuntu-22-04-x64
+        tags: [web]
+    reserved_ips:
+      - droplet: web-01
+    firewalls:
+      - name: web
+        tags: [web]
+        inbound_rules:
+          - protocol: tcp
+            ports: "443"
+            sources:
+              addresses: ["0.0.0.0/0", "::/0"]
+    domains:
+      - name: example.com
+        records:
+          - type: CNAME
+            name: www
+            data: "@"
+
+Load balancers and alert policies are only compared on the fields that the manifest sets. Resources on your account that are missing from the manifest are left alone unless you pass the ` + "`" + `--prune` + "`" + ` flag, in which case they are deleted. Only the resource types that appear in the manifest are pruned.`
+
+// Apply creates the apply command.
+func Apply() *Command {
+	cmd := CmdBuilder(nil, RunApply, "apply", "Create, update, and delete resources to match a manifest",
+		`Compares a manifest with the resources on your account, shows the changes needed to make them match, and then makes those changes. Resources are created and updated in dependency order, and deleted in the reverse order.`+manifestDetail, Writer)
+	cmd.GroupID = manageResourcesGroup
+	AddStringFlag(cmd, doctl.ArgManifestFile, "f", "", "The path to the manifest, or `-` to read it from standard input", requiredOpt())
+	AddBoolFlag(cmd, doctl.ArgPrune, "", false, "Delete resources that are missing from the manifest")
+	AddBoolFlag(cmd, doctl.ArgForce, "", false, "Apply the changes without a confirmation prompt")
+	cmd.Example = `The following example applies the manifest in ` + "`" + `resources.yaml` + "`" + `: doctl apply -f resources.yaml`
+
+	return cmd
+}
+
+// Plan creates the plan command.
+func Plan() *Command {
+	cmd := CmdBuilder(nil, RunPlan, "plan", "Show the changes needed to match a manifest",
+		`Compares a manifest with the resources on your account and shows the changes that `+"`"+`doctl apply`+"`"+` would make, without making them.`+manifestDetail, Writer)
+	cmd.GroupID = manageResourcesGroup
+	AddStringFlag(cmd, doctl.ArgManifestFile, "f", "", "The path to the manifest, or `-` to read it from standard input", requiredOpt())
+	AddBoolFlag(cmd, doctl.ArgPrune, "", false, "Include deletes for resources that are missing from the manifest")
+	cmd.Example = `The following example shows the changes needed to match the manifest in ` + "`" + `resources.yaml` + "`" + `: doctl plan -f resources.yaml`
+
+	return cmd
+}
+
+// RunPlan shows the changes needed to match a manifest.
+func RunPlan(c *CmdConfig) error {
+	p, _, err := planFromArgs(c)
+	if err != nil {
+		return err
+	}
+
+	p.write(c.Out)
+	return nil
+}
+
+// RunApply makes the changes needed to match a manifest.
+func RunApply(c *CmdConfig) error {
+	p, state, err := planFromArgs(c)
+	if err != nil {
+		return err
+	}
+
+	p.write(c.Out)
+	return applyPlan(c, p, state)
+}
+
+// applyPlan makes the changes in p, in order, after asking for confirmation
+// unless --force is set.
+func applyPlan(c *CmdConfig, p *plan, state *applyState) error {
+	if len(p.Changes) == 0 {
+		return nil
+	}
+
+	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
+	if err != nil {
+		return err
+	}
+	if !force && AskForConfirm(fmt.Sprintf("make these %d changes", len(p.Changes))) != nil {
+		return errOperationAborted
+	}
+
+	for _, ch := range p.Changes {
+		if err := ch.apply(state); err != nil {
+			return fmt.Errorf("failed to %s %s %q: %w", ch.Action, ch.Kind, ch.Name, err)
+		}
+		fmt.Fprintf(c.Out, "%s %s %s\n", planPastTense[ch.Action], ch.Kind, ch.Name)
+	}
+	return nil
+}
+
+func planFromArgs(c *CmdConfig) (*plan, *applyState, error) {
+	path, err := c.Doit.GetString(c.NS, doctl.ArgManifestFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	prune, err := c.Doit.GetBool(c.NS, doctl.ArgPrune)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := readManifest(os.Stdin, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buildPlan(c, m, prune)
+}
+
+// plan is the list of changes needed to bring live resources in line with a
+// manifest.
+type plan struct {
+	Changes []planChange
+	// Warnings describe differences that apply cannot fix by itself.
+	Warnings []string
+}
+
+// planChange is a single create, update, or delete.
+type planChange struct {
+	Action string
+	Kind   string
+	Name   string
+	Diffs  []planDiff
+	apply  func(s *applyState) error
+}
+
+// planDiff is a field that an update changes.
+type planDiff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// applyState records the IDs of Droplets by name, including those created
+// earlier in the same apply, so that later changes can refer to them.
+type applyState struct {
+	dropletIDs map[string]int
+}
+
+// dropletID returns the ID of the Droplet with the given name. Names that
+// are not known but are numeric are taken to be IDs, as exports list Droplets
+// outside of their scope by ID.
+func (s *applyState) dropletID(name string) (int, error) {
+	if id, ok := s.dropletIDs[name]; ok {
+		return id, nil
+	}
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, nil
+	}
+	return 0, fmt.Errorf("no Droplet is named %q", name)
+}
+
+func (s *applyState) dropletIDsFor(names []string) ([]int, error) {
+	ids := make([]int, 0, len(names))
+	for _, n := range names {
+		id, err := s.dropletID(n)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (p *plan) add(ch planChange) {
+	p.Changes = append(p.Changes, ch)
+}
+
+func (p *plan) warn(format string, args ...any) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, args...))
+}
+
+// write prints the plan as a colored diff.
+func (p *plan) write(w io.Writer) {
+	counts := map[string]int{}
+	for _, ch := range p.Changes {
+		counts[ch.Action]++
+		switch ch.Action {
+		case planCreate:
+			fmt.Fprintln(w, color.GreenString("+ %s %s", ch.Kind, ch.Name))
+		case planUpdate:
+			fmt.Fprintln(w, color.YellowString("~ %s %s", ch.Kind, ch.Name))
+		case planDelete:
+			fmt.Fprintln(w, color.RedString("- %s %s", ch.Kind, ch.Name))
+		}
+		for _, d := range ch.Diffs {
+			fmt.Fprintf(w, "    %s:\n", d.Field)
+			fmt.Fprintln(w, color.RedString("      - %s", d.Old))
+			fmt.Fprintln(w, color.GreenString("      + %s", d.New))
+		}
+	}
+	for _, msg := range p.Warnings {
+		fmt.Fprintf(w, "%s: %s\n", colorWarn, msg)
+	}
+
+	if len(p.Changes) == 0 {
+		fmt.Fprintln(w, "No changes. Your resources match the manifest.")
+		return
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n",
+		counts[planCreate], counts[planUpdate], counts[planDelete])
+}
+
+// planInput holds what each step of a plan is computed from.
+type planInput struct {
+	c     *CmdConfig
+	m     *manifest
+	prune bool
+	// droplets are the live Droplets, and dropletNames maps their IDs to
+	// their names.
+	droplets     do.Droplets
+	dropletNames map[int]string
+}
+
+// planSteps compute the changes for each kind of resource, in the order in
+// which they are created and updated: each resource exists before anything
+// refers to it. Each step returns its deletes separately, as deletes are made
+// last and in the reverse order.
+var planSteps = []func(in *planInput, p *plan) ([]planChange, error){
+	planTags,
+	planDroplets,
+	planVolumes,
+	planReservedIPs,
+	planLoadBalancers,
+	planFirewalls,
+	planDomains,
+	planAlertPolicies,
+}
+
+// buildPlan compares m with the live resources on the account.
+func buildPlan(c *CmdConfig, m *manifest, prune bool) (*plan, *applyState, error) {
+	p := &plan{}
+	state := &applyState{dropletIDs: map[string]int{}}
+	in := &planInput{c: c, m: m, prune: prune, dropletNames: map[int]string{}}
+
+	if len(m.Droplets) > 0 || len(m.Volumes) > 0 || len(m.ReservedIPs) > 0 || len(m.LoadBalancers) > 0 || len(m.Firewalls) > 0 {
+		var err error
+		in.droplets, err = c.Droplets().List()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, d := range in.droplets {
+		in.dropletNames[d.ID] = d.Name
+		if _, ok := state.dropletIDs[d.Name]; ok {
+			p.warn("more than one Droplet is named %q; the manifest cannot manage it", d.Name)
+		}
+		state.dropletIDs[d.Name] = d.ID
+	}
+
+	var deletes [][]planChange
+	for _, step := range planSteps {
+		d, err := step(in, p)
+		if err != nil {
+			return nil, nil, err
+		}
+		deletes = append(deletes, d)
+	}
+
+	for i := len(deletes) - 1; i >= 0; i-- {
+		for _, ch := range deletes[i] {
+			p.add(ch)
+		}
+	}
+	return p, state, nil
+}
+
+// dropletName returns the name of a live Droplet, or its ID if it has none.
+func (in *planInput) dropletName(id int) string {
+	if name, ok := in.dropletNames[id]; ok {
+		return name
+	}
+	return strconv.Itoa(id)
+}
+
+func planTags(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.Tags) == 0 {
+		return nil, nil
+	}
+
+	ts := in.c.Tags()
+	tags, err := ts.List()
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]bool{}
+	for _, t := range tags {
+		live[t.Name] = true
+	}
+
+	desired := map[string]bool{}
+	for _, name := range in.m.Tags {
+		desired[name] = true
+		if live[name] {
+			continue
+		}
+		p.add(planChange{Action: planCreate, Kind: "tag", Name: name, apply: func(*applyState) error {
+			_, err := ts.Create(&godo.TagCreateRequest{Name: name})
+			return err
+		}})
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, t := range tags {
+			if desired[t.Name] {
+				continue
+			}
+			name := t.Name
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "tag", Name: name, apply: func(*applyState) error {
+				return ts.Delete(name)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+func planDroplets(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.Droplets) == 0 {
+		return nil, nil
+	}
+
+	ds := in.c.Droplets()
+	ts := in.c.Tags()
+	live := map[string]do.Droplet{}
+	for _, d := range in.droplets {
+		live[d.Name] = d
+	}
+
+	desired := map[string]bool{}
+	for _, want := range in.m.Droplets {
+		want := want
+		desired[want.Name] = true
+
+		have, ok := live[want.Name]
+		if !ok {
+			p.add(planChange{Action: planCreate, Kind: "droplet", Name: want.Name, apply: func(s *applyState) error {
+				d, err := ds.Create(dropletCreateRequest(want), true)
+				if err != nil {
+					return err
+				}
+				s.dropletIDs[want.Name] = d.ID
+				return nil
+			}})
+			continue
+		}
+
+		if have.Region != nil && have.Region.Slug != want.Region {
+			p.warn("droplet %s is in region %s, not %s; Droplets cannot be moved between regions", want.Name, have.Region.Slug, want.Region)
+		}
+		if have.SizeSlug != want.Size {
+			p.warn("droplet %s has size %s, not %s; use `doctl compute droplet-action resize` to resize it", want.Name, have.SizeSlug, want.Size)
+		}
+
+		added, removed := diffStrings(have.Tags, want.Tags)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		id := have.ID
+		p.add(planChange{
+			Action: planUpdate,
+			Kind:   "droplet",
+			Name:   want.Name,
+			Diffs:  []planDiff{{Field: "tags", Old: strings.Join(have.Tags, ","), New: strings.Join(want.Tags, ",")}},
+			apply: func(*applyState) error {
+				resources := []godo.Resource{{ID: strconv.Itoa(id), Type: godo.DropletResourceType}}
+				for _, t := range added {
+					if _, err := ts.Create(&godo.TagCreateRequest{Name: t}); err != nil {
+						return err
+					}
+					if err := ts.TagResources(t, &godo.TagResourcesRequest{Resources: resources}); err != nil {
+						return err
+					}
+				}
+				for _, t := range removed {
+					if err := ts.UntagResources(t, &godo.UntagResourcesRequest{Resources: resources}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		})
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, d := range in.droplets {
+			if desired[d.Name] {
+				continue
+			}
+			id := d.ID
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "droplet", Name: d.Name, apply: func(*applyState) error {
+				return ds.Delete(id)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+func dropletCreateRequest(d manifestDroplet) *godo.DropletCreateRequest {
+	image := godo.DropletCreateImage{Slug: d.Image}
+	if id, err := strconv.Atoi(d.Image); err == nil {
+		image = godo.DropletCreateImage{ID: id}
+	}
+
+	return &godo.DropletCreateRequest{
+		Name:       d.Name,
+		Region:     d.Region,
+		Size:       d.Size,
+		Image:      image,
+		SSHKeys:    extractSSHKeys(d.SSHKeys),
+		Tags:       d.Tags,
+		VPCUUID:    d.VPCUUID,
+		Backups:    d.Backups,
+		IPv6:       d.IPv6,
+		Monitoring: d.Monitoring,
+		UserData:   d.UserData,
+	}
+}
+
+func planReservedIPs(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.ReservedIPs) == 0 {
+		return nil, nil
+	}
+
+	rs := in.c.ReservedIPs()
+	ras := in.c.ReservedIPActions()
+	ips, err := rs.List()
+	if err != nil {
+		return nil, err
+	}
+
+	assignedTo := func(ip do.ReservedIP) string {
+		if ip.Droplet == nil {
+			return ""
+		}
+		return ip.Droplet.Name
+	}
+
+	matched := map[string]bool{}
+	for _, want := range in.m.ReservedIPs {
+		want := want
+
+		var have *do.ReservedIP
+		for i, ip := range ips {
+			if (want.IP != "" && ip.IP == want.IP) || (want.IP == "" && assignedTo(ip) == want.Droplet) {
+				have = &ips[i]
+				break
+			}
+		}
+
+		if have == nil {
+			if want.IP != "" {
+				p.warn("reserved IP %s does not exist; remove its ip from the manifest to create a new one", want.IP)
+				continue
+			}
+			p.add(planChange{Action: planCreate, Kind: "reserved-ip", Name: want.key(), apply: func(s *applyState) error {
+				req := &godo.ReservedIPCreateRequest{Region: want.Region}
+				if want.Droplet != "" {
+					id, err := s.dropletID(want.Droplet)
+					if err != nil {
+						return err
+					}
+					req = &godo.ReservedIPCreateRequest{DropletID: id}
+				}
+				_, err := rs.Create(req)
+				return err
+			}})
+			continue
+		}
+
+		matched[have.IP] = true
+		if assignedTo(*have) == want.Droplet {
+			continue
+		}
+
+		ip := have.IP
+		ch := planChange{
+			Action: planUpdate,
+			Kind:   "reserved-ip",
+			Name:   ip,
+			Diffs:  []planDiff{{Field: "droplet", Old: assignedTo(*have), New: want.Droplet}},
+		}
+		if want.Droplet == "" {
+			ch.apply = func(*applyState) error {
+				_, err := ras.Unassign(ip)
+				return err
+			}
+		} else {
+			ch.apply = func(s *applyState) error {
+				id, err := s.dropletID(want.Droplet)
+				if err != nil {
+					return err
+				}
+				_, err = ras.Assign(ip, id)
+				return err
+			}
+		}
+		p.add(ch)
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, ip := range ips {
+			if matched[ip.IP] {
+				continue
+			}
+			addr := ip.IP
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "reserved-ip", Name: addr, apply: func(*applyState) error {
+				return rs.Delete(addr)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+func planVolumes(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.Volumes) == 0 {
+		return nil, nil
+	}
+
+	vs := in.c.Volumes()
+	vas := in.c.VolumeActions()
+	volumes, err := vs.List()
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]do.Volume{}
+	for _, v := range volumes {
+		live[v.Name] = v
+	}
+
+	attach := func(volumeID, droplet string, s *applyState) error {
+		if droplet == "" {
+			return nil
+		}
+		id, err := s.dropletID(droplet)
+		if err != nil {
+			return err
+		}
+		_, err = vas.Attach(volumeID, id)
+		return err
+	}
+	detach := func(v do.Volume) error {
+		if len(v.DropletIDs) == 0 {
+			return nil
+		}
+		a, err := vas.Detach(v.ID, v.DropletIDs[0])
+		if err != nil {
+			return err
+		}
+		_, err = actionWait(in.c, a.ID, 5)
+		return err
+	}
+
+	desired := map[string]bool{}
+	for _, want := range in.m.Volumes {
+		want := want
+		desired[want.Name] = true
+
+		have, ok := live[want.Name]
+		if !ok {
+			p.add(planChange{Action: planCreate, Kind: "volume", Name: want.Name, apply: func(s *applyState) error {
+				v, err := vs.CreateVolume(&godo.VolumeCreateRequest{
+					Name:            want.Name,
+					Region:          want.Region,
+					SizeGigaBytes:   want.SizeGigaBytes,
+					Description:     want.Description,
+					FilesystemType:  want.FilesystemType,
+					FilesystemLabel: want.FilesystemLabel,
+					Tags:            want.Tags,
+				})
+				if err != nil {
+					return err
+				}
+				return attach(v.ID, want.Droplet, s)
+			}})
+			continue
+		}
+
+		if have.Region != nil && have.Region.Slug != want.Region {
+			p.warn("volume %s is in region %s, not %s; volumes cannot be moved between regions", want.Name, have.Region.Slug, want.Region)
+		}
+		if have.SizeGigaBytes != want.SizeGigaBytes {
+			p.warn("volume %s is %d GiB, not %d GiB; use `doctl compute volume-action resize` to resize it", want.Name, have.SizeGigaBytes, want.SizeGigaBytes)
+		}
+
+		var haveDroplet string
+		if len(have.DropletIDs) > 0 {
+			haveDroplet = in.dropletName(have.DropletIDs[0])
+		}
+		if haveDroplet == want.Droplet {
+			continue
+		}
+		p.add(planChange{
+			Action: planUpdate,
+			Kind:   "volume",
+			Name:   want.Name,
+			Diffs:  []planDiff{{Field: "droplet", Old: haveDroplet, New: want.Droplet}},
+			apply: func(s *applyState) error {
+				if err := detach(have); err != nil {
+					return err
+				}
+				return attach(have.ID, want.Droplet, s)
+			},
+		})
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, v := range volumes {
+			if desired[v.Name] {
+				continue
+			}
+			v := v
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "volume", Name: v.Name, apply: func(*applyState) error {
+				if err := detach(v); err != nil {
+					return err
+				}
+				return vs.DeleteVolume(v.ID)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+func planLoadBalancers(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.LoadBalancers) == 0 {
+		return nil, nil
+	}
+
+	lbs := in.c.LoadBalancers()
+	balancers, err := lbs.List()
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]do.LoadBalancer{}
+	for _, lb := range balancers {
+		live[lb.Name] = lb
+	}
+
+	request := func(want manifestLoadBalancer, s *applyState) (*godo.LoadBalancerRequest, error) {
+		ids, err := s.dropletIDsFor(want.Droplets)
+		if err != nil {
+			return nil, err
+		}
+		return &godo.LoadBalancerRequest{
+			Name:                         want.Name,
+			Region:                       want.Region,
+			SizeSlug:                     want.Size,
+			SizeUnit:                     want.SizeUnit,
+			Type:                         want.Type,
+			Network:                      want.Network,
+			VPCUUID:                      want.VPCUUID,
+			ForwardingRules:              want.ForwardingRules,
+			HealthCheck:                  want.HealthCheck,
+			StickySessions:               want.StickySessions,
+			DropletIDs:                   ids,
+			Tag:                          want.Tag,
+			RedirectHttpToHttps:          want.RedirectHttpToHttps,
+			EnableProxyProtocol:          want.EnableProxyProtocol,
+			EnableBackendKeepalive:       want.EnableBackendKeepalive,
+			HTTPIdleTimeoutSeconds:       want.HTTPIdleTimeoutSeconds,
+			DisableLetsEncryptDNSRecords: want.DisableLetsEncryptDNSRecords,
+			Firewall:                     want.Firewall,
+		}, nil
+	}
+
+	names := &exportedDroplets{names: in.dropletNames}
+	desired := map[string]bool{}
+	for _, want := range in.m.LoadBalancers {
+		want := want
+		desired[want.Name] = true
+
+		have, ok := live[want.Name]
+		if !ok {
+			p.add(planChange{Action: planCreate, Kind: "load-balancer", Name: want.Name, apply: func(s *applyState) error {
+				req, err := request(want, s)
+				if err != nil {
+					return err
+				}
+				_, err = lbs.Create(req)
+				return err
+			}})
+			continue
+		}
+
+		compared := want
+		compared.Droplets = append([]string(nil), want.Droplets...)
+		sort.Strings(compared.Droplets)
+		diffs, err := fieldDiffs(exportLoadBalancer(have, names), compared)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		id := have.ID
+		p.add(planChange{Action: planUpdate, Kind: "load-balancer", Name: want.Name, Diffs: diffs, apply: func(s *applyState) error {
+			req, err := request(want, s)
+			if err != nil {
+				return err
+			}
+			_, err = lbs.Update(id, req)
+			return err
+		}})
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, lb := range balancers {
+			if desired[lb.Name] {
+				continue
+			}
+			id := lb.ID
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "load-balancer", Name: lb.Name, apply: func(*applyState) error {
+				return lbs.Delete(id)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+func planFirewalls(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.Firewalls) == 0 {
+		return nil, nil
+	}
+
+	fs := in.c.Firewalls()
+	firewalls, err := fs.List()
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]do.Firewall{}
+	for _, f := range firewalls {
+		live[f.Name] = f
+	}
+
+	request := func(want manifestFirewall, s *applyState) (*godo.FirewallRequest, error) {
+		ids, err := s.dropletIDsFor(want.Droplets)
+		if err != nil {
+			return nil, err
+		}
+		return &godo.FirewallRequest{
+			Name:          want.Name,
+			InboundRules:  want.InboundRules,
+			OutboundRules: want.OutboundRules,
+			DropletIDs:    ids,
+			Tags:          want.Tags,
+		}, nil
+	}
+
+	desired := map[string]bool{}
+	for _, want := range in.m.Firewalls {
+		want := want
+		desired[want.Name] = true
+
+		have, ok := live[want.Name]
+		if !ok {
+			p.add(planChange{Action: planCreate, Kind: "firewall", Name: want.Name, apply: func(s *applyState) error {
+				req, err := request(want, s)
+				if err != nil {
+					return err
+				}
+				_, err = fs.Create(req)
+				return err
+			}})
+			continue
+		}
+
+		haveDroplets := make([]string, 0, len(have.DropletIDs))
+		for _, id := range have.DropletIDs {
+			haveDroplets = append(haveDroplets, in.dropletName(id))
+		}
+
+		var diffs []planDiff
+		diffs = appendDiff(diffs, "inbound_rules", canonicalJSON(have.InboundRules), canonicalJSON(want.InboundRules))
+		diffs = appendDiff(diffs, "outbound_rules", canonicalJSON(have.OutboundRules), canonicalJSON(want.OutboundRules))
+		diffs = appendDiff(diffs, "droplets", sortedJoin(haveDroplets), sortedJoin(want.Droplets))
+		diffs = appendDiff(diffs, "tags", sortedJoin(have.Tags), sortedJoin(want.Tags))
+		if len(diffs) == 0 {
+			continue
+		}
+
+		id := have.ID
+		p.add(planChange{Action: planUpdate, Kind: "firewall", Name: want.Name, Diffs: diffs, apply: func(s *applyState) error {
+			req, err := request(want, s)
+			if err != nil {
+				return err
+			}
+			_, err = fs.Update(id, req)
+			return err
+		}})
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, f := range firewalls {
+			if desired[f.Name] {
+				continue
+			}
+			id := f.ID
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "firewall", Name: f.Name, apply: func(*applyState) error {
+				return fs.Delete(id)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+func planDomains(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.Domains) == 0 {
+		return nil, nil
+	}
+
+	ds := in.c.Domains()
+	domains, err := ds.List()
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]bool{}
+	for _, d := range domains {
+		live[d.Name] = true
+	}
+
+	var deletes []planChange
+	desired := map[string]bool{}
+	for _, want := range in.m.Domains {
+		want := want
+		desired[want.Name] = true
+		if !live[want.Name] {
+			p.add(planChange{Action: planCreate, Kind: "domain", Name: want.Name, apply: func(*applyState) error {
+				_, err := ds.Create(&godo.DomainCreateRequest{Name: want.Name, IPAddress: want.IPAddress})
+				return err
+			}})
+		}
+
+		if len(want.Records) > 0 {
+			d, err := planRecords(in, p, want, live[want.Name])
+			if err != nil {
+				return nil, err
+			}
+			deletes = append(deletes, d...)
+		}
+	}
+
+	if in.prune {
+		for _, d := range domains {
+			if desired[d.Name] {
+				continue
+			}
+			name := d.Name
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "domain", Name: name, apply: func(*applyState) error {
+				return ds.Delete(name)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+// planRecords computes the changes to the records of a domain. Records are
+// matched on everything but their TTL, so a record whose TTL differs is
+// updated in place and any other difference replaces the record.
+func planRecords(in *planInput, p *plan, want manifestDomain, exists bool) ([]planChange, error) {
+	ds := in.c.Domains()
+
+	var records do.DomainRecords
+	if exists {
+		var err error
+		records, err = ds.Records(want.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	live := map[string][]do.DomainRecord{}
+	matched := map[int]bool{}
+	for _, r := range records {
+		if !isManagedRecord(r.Type, r.Data) {
+			continue
+		}
+		key := exportRecord(r).key(want.Name)
+		live[key] = append(live[key], r)
+	}
+
+	for _, r := range want.Records {
+		r := r
+		name := recordLabel(want.Name, r)
+		req := &do.DomainRecordEditRequest{
+			Type:     r.Type,
+			Name:     r.Name,
+			Data:     r.Data,
+			Priority: r.Priority,
+			Port:     &r.Port,
+			TTL:      r.TTL,
+			Weight:   r.Weight,
+			Flags:    r.Flags,
+			Tag:      r.Tag,
+		}
+
+		matches := live[r.key(want.Name)]
+		if len(matches) == 0 {
+			p.add(planChange{Action: planCreate, Kind: "record", Name: name, apply: func(*applyState) error {
+				_, err := ds.CreateRecord(want.Name, req)
+				return err
+			}})
+			continue
+		}
+
+		have := matches[0]
+		live[r.key(want.Name)] = matches[1:]
+		matched[have.ID] = true
+		if r.TTL == 0 || r.TTL == have.TTL {
+			continue
+		}
+		id := have.ID
+		p.add(planChange{
+			Action: planUpdate,
+			Kind:   "record",
+			Name:   name,
+			Diffs:  []planDiff{{Field: "ttl", Old: strconv.Itoa(have.TTL), New: strconv.Itoa(r.TTL)}},
+			apply: func(*applyState) error {
+				_, err := ds.EditRecord(want.Name, id, req)
+				return err
+			},
+		})
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, r := range records {
+			if !isManagedRecord(r.Type, r.Data) || matched[r.ID] {
+				continue
+			}
+			id := r.ID
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "record", Name: recordLabel(want.Name, exportRecord(r)), apply: func(*applyState) error {
+				return ds.DeleteRecord(want.Name, id)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+// recordLabel describes a record in a plan, such as "www.example.com CNAME @".
+func recordLabel(domain string, r manifestRecord) string {
+	host := domain
+	if r.Name != "@" {
+		host = r.Name + "." + domain
+	}
+	return fmt.Sprintf("%s %s %s", host, r.Type, r.Data)
+}
+
+func planAlertPolicies(in *planInput, p *plan) ([]planChange, error) {
+	if len(in.m.AlertPolicies) == 0 {
+		return nil, nil
+	}
+
+	ms := in.c.Monitoring()
+	policies, err := ms.ListAlertPolicies()
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]do.AlertPolicy{}
+	for _, a := range policies {
+		if _, ok := live[a.Description]; ok {
+			p.warn("more than one alert policy is described as %q; the manifest cannot manage it", a.Description)
+		}
+		live[a.Description] = a
+	}
+
+	enabled := func(want manifestAlertPolicy) *bool {
+		if want.Enabled != nil {
+			return want.Enabled
+		}
+		return godo.PtrTo(true)
+	}
+
+	desired := map[string]bool{}
+	for _, want := range in.m.AlertPolicies {
+		want := want
+		desired[want.Description] = true
+
+		have, ok := live[want.Description]
+		if !ok {
+			p.add(planChange{Action: planCreate, Kind: "alert-policy", Name: want.Description, apply: func(*applyState) error {
+				_, err := ms.CreateAlertPolicy(&godo.AlertPolicyCreateRequest{
+					Type:        want.Type,
+					Description: want.Description,
+					Compare:     want.Compare,
+					Value:       want.Value,
+					Window:      want.Window,
+					Entities:    want.Entities,
+					Tags:        want.Tags,
+					Alerts:      want.Alerts,
+					Enabled:     enabled(want),
+				})
+				return err
+			}})
+			continue
+		}
+
+		diffs, err := fieldDiffs(exportAlertPolicy(have), want)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		uuid := have.UUID
+		p.add(planChange{Action: planUpdate, Kind: "alert-policy", Name: want.Description, Diffs: diffs, apply: func(*applyState) error {
+			_, err := ms.UpdateAlertPolicy(uuid, &godo.AlertPolicyUpdateRequest{
+				Type:        want.Type,
+				Description: want.Description,
+				Compare:     want.Compare,
+				Value:       want.Value,
+				Window:      want.Window,
+				Entities:    want.Entities,
+				Tags:        want.Tags,
+				Alerts:      want.Alerts,
+				Enabled:     enabled(want),
+			})
+			return err
+		}})
+	}
+
+	var deletes []planChange
+	if in.prune {
+		for _, a := range policies {
+			if desired[a.Description] {
+				continue
+			}
+			uuid := a.UUID
+			deletes = append(deletes, planChange{Action: planDelete, Kind: "alert-policy", Name: a.Description, apply: func(*applyState) error {
+				return ms.DeleteAlertPolicy(uuid)
+			}})
+		}
+	}
+	return deletes, nil
+}
+
+// diffStrings returns the strings in want but not in have, and those in have
+// but not in want.
+func diffStrings(have, want []string) (added, removed []string) {
+	haveSet := map[string]bool{}
+	for _, s := range have {
+		haveSet[s] = true
+	}
+	wantSet := map[string]bool{}
+	for _, s := range want {
+		wantSet[s] = true
+		if !haveSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range have {
+		if !wantSet[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+func appendDiff(diffs []planDiff, field, old, new string) []planDiff {
+	if old == new {
+		return diffs
+	}
+	return append(diffs, planDiff{Field: field, Old: old, New: new})
+}
+
+// fieldDiffs compares the fields that are set in want with the same fields of
+// have. Both are encoded as JSON objects, so fields that want leaves empty are
+// not compared.
+func fieldDiffs(have, want any) ([]planDiff, error) {
+	var h, w map[string]json.RawMessage
+	for _, v := range []struct {
+		in  any
+		out *map[string]json.RawMessage
+	}{{have, &h}, {want, &w}} {
+		b, err := json.Marshal(v.in)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, v.out); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]string, 0, len(w))
+	for f := range w {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	var diffs []planDiff
+	for _, f := range fields {
+		diffs = appendDiff(diffs, f, string(h[f]), string(w[f]))
+	}
+	return diffs, nil
+}
+
+func sortedJoin(s []string) string {
+	sorted := append([]string(nil), s...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// canonicalJSON encodes a list of rules so that lists holding the same rules
+// in a different order encode identically.
+func canonicalJSON[T any](rules []T) string {
+	encoded := make([]string, 0, len(rules))
+	for _, r := range rules {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Sprint(rules)
+		}
+		encoded = append(encoded, string(b))
+	}
+	sort.Strings(encoded)
+	return "[" + strings.Join(encoded, ",") + "]"
+}
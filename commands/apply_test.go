@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `
+tags:
+  - web
+droplets:
+  - name: web-01
+    region: nyc1
+    size: s-1vcpu-1gb
+    image: ubuntu-22-04-x64
+    tags: [web]
+  - name: web-02
+    region: nyc1
+    size: s-1vcpu-1gb
+    image: ubuntu-22-04-x64
+    tags: [web]
+reserved_ips:
+  - droplet: web-02
+firewalls:
+  - name: web
+    tags: [web]
+    inbound_rules:
+      - protocol: tcp
+        ports: "443"
+        sources:
+          addresses: ["0.0.0.0/0"]
+`
+
+func TestApplyCommand(t *testing.T) {
+	assert.Equal(t, "apply", Apply().Name())
+	assert.Equal(t, "plan", Plan().Name())
+}
+
+func writeTestManifest(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "resources.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func expectTestManifestState(tm *tcMocks) {
+	tm.droplets.EXPECT().List().Return(do.Droplets{
+		{Droplet: &godo.Droplet{ID: 1, Name: "web-01", SizeSlug: "s-1vcpu-1gb", Region: &godo.Region{Slug: "nyc1"}}},
+		{Droplet: &godo.Droplet{ID: 3, Name: "old-01", SizeSlug: "s-1vcpu-1gb", Region: &godo.Region{Slug: "nyc1"}}},
+	}, nil)
+	tm.tags.EXPECT().List().Return(do.Tags{}, nil)
+	tm.reservedIPs.EXPECT().List().Return(do.ReservedIPs{}, nil)
+	tm.firewalls.EXPECT().List().Return(do.Firewalls{
+		{Firewall: &godo.Firewall{ID: "fw-1", Name: "web", Tags: []string{"web"}}},
+	}, nil)
+}
+
+func TestRunPlan(t *testing.T) {
+	color.NoColor = true
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		expectTestManifestState(tm)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgManifestFile, writeTestManifest(t, testManifest))
+		config.Doit.Set(config.NS, doctl.ArgPrune, true)
+
+		err := RunPlan(config)
+		assert.NoError(t, err)
+
+		expected := "+ tag web\n" +
+			"~ droplet web-01\n" +
+			"    tags:\n" +
+			"      - \n" +
+			"      + web\n" +
+			"+ droplet web-02\n" +
+			"+ reserved-ip assigned to web-02\n" +
+			"~ firewall web\n" +
+			"    inbound_rules:\n" +
+			"      - []\n" +
+			"      + [{\"protocol\":\"tcp\",\"ports\":\"443\",\"sources\":{\"addresses\":[\"0.0.0.0/0\"]}}]\n" +
+			"- droplet old-01\n" +
+			"\n" +
+			"Plan: 3 to create, 2 to update, 1 to delete.\n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestRunPlanNoChanges(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.EXPECT().List().Return(do.Domains{
+			{Domain: &godo.Domain{Name: "example.com"}},
+			{Domain: &godo.Domain{Name: "example.org"}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgManifestFile, writeTestManifest(t, "domains:\n  - name: example.com\n"))
+
+		err := RunPlan(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "No changes. Your resources match the manifest.\n", buf.String())
+	})
+}
+
+func TestRunApply(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		expectTestManifestState(tm)
+
+		tm.tags.EXPECT().Create(&godo.TagCreateRequest{Name: "web"}).Return(&do.Tag{}, nil).Times(2)
+		tm.tags.EXPECT().TagResources("web", &godo.TagResourcesRequest{
+			Resources: []godo.Resource{{ID: "1", Type: godo.DropletResourceType}},
+		}).Return(nil)
+		tm.droplets.EXPECT().Create(&godo.DropletCreateRequest{
+			Name:    "web-02",
+			Region:  "nyc1",
+			Size:    "s-1vcpu-1gb",
+			Image:   godo.DropletCreateImage{Slug: "ubuntu-22-04-x64"},
+			SSHKeys: []godo.DropletCreateSSHKey{},
+			Tags:    []string{"web"},
+		}, true).Return(&do.Droplet{Droplet: &godo.Droplet{ID: 2, Name: "web-02"}}, nil)
+		tm.reservedIPs.EXPECT().Create(&godo.ReservedIPCreateRequest{DropletID: 2}).Return(&do.ReservedIP{}, nil)
+		tm.firewalls.EXPECT().Update("fw-1", &godo.FirewallRequest{
+			Name: "web",
+			InboundRules: []godo.InboundRule{{
+				Protocol:  "tcp",
+				PortRange: "443",
+				Sources:   &godo.Sources{Addresses: []string{"0.0.0.0/0"}},
+			}},
+			DropletIDs: []int{},
+			Tags:       []string{"web"},
+		}).Return(&do.Firewall{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgManifestFile, writeTestManifest(t, testManifest))
+		config.Doit.Set(config.NS, doctl.ArgForce, true)
+
+		err := RunApply(config)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(buf.String(), `Created tag web
+Updated droplet web-01
+Created droplet web-02
+Created reserved-ip assigned to web-02
+Updated firewall web
+`), buf.String())
+	})
+}
+
+func TestRunApplyStopsOnError(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.EXPECT().List().Return(do.Domains{}, nil)
+		tm.domains.EXPECT().Create(&godo.DomainCreateRequest{Name: "example.com"}).Return(nil, errors.New("forbidden"))
+
+		config.Doit.Set(config.NS, doctl.ArgManifestFile, writeTestManifest(t, "domains:\n  - name: example.com\n  - name: example.org\n"))
+		config.Doit.Set(config.NS, doctl.ArgForce, true)
+
+		err := RunApply(config)
+		assert.EqualError(t, err, `failed to create domain "example.com": forbidden`)
+	})
+}
+
+func TestManifestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{
+			name:     "valid",
+			manifest: testManifest,
+		},
+		{
+			name:     "unknown field",
+			manifest: "droplet:\n  - name: web-01\n",
+			err:      `parsing manifest: error unmarshaling JSON: while decoding JSON: json: unknown field "droplet"`,
+		},
+		{
+			name:     "duplicate name",
+			manifest: "domains:\n  - name: example.com\n  - name: example.com\n",
+			err:      `manifest: domain "example.com" is defined more than once`,
+		},
+		{
+			name:     "droplet missing size",
+			manifest: "droplets:\n  - name: web-01\n    region: nyc1\n    image: ubuntu-22-04-x64\n",
+			err:      `manifest: droplet "web-01" needs a region, size, and image`,
+		},
+		{
+			name:     "reserved IP for unknown droplet needs region",
+			manifest: "reserved_ips:\n  - droplet: web-01\n",
+			err:      `manifest: reserved IP for droplet "web-01" needs a region`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readManifest(strings.NewReader(tt.manifest), "-")
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRunPlanRecordsAndVolumes(t *testing.T) {
+	color.NoColor = true
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(do.Droplets{
+			{Droplet: &godo.Droplet{ID: 1, Name: "db-01"}},
+			{Droplet: &godo.Droplet{ID: 2, Name: "db-02"}},
+		}, nil)
+		tm.volumes.EXPECT().List().Return([]do.Volume{
+			{Volume: &godo.Volume{ID: "vol-1", Name: "data", Region: &godo.Region{Slug: "nyc1"}, SizeGigaBytes: 100, DropletIDs: []int{1}}},
+		}, nil)
+		tm.domains.EXPECT().List().Return(do.Domains{{Domain: &godo.Domain{Name: "example.com"}}}, nil)
+		tm.domains.EXPECT().Records("example.com").Return(do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "SOA", Name: "@", Data: "1800"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}},
+			{DomainRecord: &godo.DomainRecord{ID: 3, Type: "A", Name: "@", Data: "203.0.113.1", TTL: 1800}},
+			{DomainRecord: &godo.DomainRecord{ID: 4, Type: "A", Name: "old", Data: "203.0.113.2", TTL: 1800}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgManifestFile, writeTestManifest(t, `
+volumes:
+  - name: data
+    region: nyc1
+    size_gigabytes: 100
+    droplet: db-02
+domains:
+  - name: example.com
+    records:
+      - {type: A, name: "@", data: 203.0.113.1, ttl: 300}
+      - {type: CNAME, name: www, data: "@"}
+`))
+		config.Doit.Set(config.NS, doctl.ArgPrune, true)
+
+		err := RunPlan(config)
+		assert.NoError(t, err)
+
+		expected := "~ volume data\n" +
+			"    droplet:\n" +
+			"      - db-01\n" +
+			"      + db-02\n" +
+			"~ record example.com A 203.0.113.1\n" +
+			"    ttl:\n" +
+			"      - 1800\n" +
+			"      + 300\n" +
+			"+ record www.example.com CNAME @\n" +
+			"- record old.example.com A 203.0.113.2\n" +
+			"\n" +
+			"Plan: 1 to create, 2 to update, 1 to delete.\n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestFieldDiffs(t *testing.T) {
+	have := manifestLoadBalancer{Name: "web", Region: "nyc1", Size: "lb-small", RedirectHttpToHttps: true}
+	want := manifestLoadBalancer{Name: "web", Size: "lb-medium"}
+
+	diffs, err := fieldDiffs(have, want)
+	assert.NoError(t, err)
+	assert.Equal(t, []planDiff{{Field: "size", Old: `"lb-small"`, New: `"lb-medium"`}}, diffs)
+}
@@ -68,7 +68,7 @@ func Apps() *Command {
 	CmdBuilder(
 		cmd,
 		RunAppsGet,
-		"get <app id>",
+		"get <app id|name>",
 		"Get an app",
 		`Get an app with the provided id.
 
@@ -76,6 +76,7 @@ Only basic information is included with the text output format. For complete app
 		Writer,
 		aliasOpt("g"),
 		displayerType(&displayers.Apps{}),
+		fromFileOpt(),
 	)
 
 	list := CmdBuilder(
@@ -96,12 +97,13 @@ Only basic information is included with the text output format. For complete app
 	update := CmdBuilder(
 		cmd,
 		RunAppsUpdate,
-		"update <app id>",
+		"update <app id|name>",
 		"Updates an app",
 		`Updates the specified app with the given app spec. For more information about app specs, see the [app spec reference](https://www.digitalocean.com/docs/app-platform/concepts/app-spec)`,
 		Writer,
 		aliasOpt("u"),
 		displayerType(&displayers.Apps{}),
+		fromFileOpt(),
 	)
 	AddStringFlag(update, doctl.ArgAppSpec, "", "", `Path to an app spec in JSON or YAML format. Set to "-" to read from stdin.`, requiredOpt())
 	AddBoolFlag(update, doctl.ArgCommandWait, "", false,
@@ -111,13 +113,14 @@ Only basic information is included with the text output format. For complete app
 	deleteApp := CmdBuilder(
 		cmd,
 		RunAppsDelete,
-		"delete <app id>",
+		"delete <app id|name>",
 		"Deletes an app",
 		`Deletes the specified app.
 
 This permanently deletes the app and all of its associated deployments.`,
 		Writer,
 		aliasOpt("d", "rm"),
+		fromFileOpt(),
 	)
 	AddBoolFlag(deleteApp, doctl.ArgForce, doctl.ArgShortForce, false, "Delete the App without a confirmation prompt")
 	deleteApp.Example = `The following example deletes an app with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl apps delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
@@ -125,12 +128,13 @@ This permanently deletes the app and all of its associated deployments.`,
 	deploymentCreate := CmdBuilder(
 		cmd,
 		RunAppsCreateDeployment,
-		"create-deployment <app id>",
+		"create-deployment <app id|name>",
 		"Creates a deployment",
 		`Deploys the app with the latest changes from your repository.`,
 		Writer,
 		aliasOpt("cd"),
 		displayerType(&displayers.Deployments{}),
+		fromFileOpt(),
 	)
 	AddBoolFlag(deploymentCreate, doctl.ArgAppForceRebuild, "", false, "Force a re-build even if a previous build is eligible for reuse.")
 	AddBoolFlag(deploymentCreate, doctl.ArgCommandWait, "", false,
@@ -140,7 +144,7 @@ This permanently deletes the app and all of its associated deployments.`,
 	getDeployment := CmdBuilder(
 		cmd,
 		RunAppsGetDeployment,
-		"get-deployment <app id> <deployment id>",
+		"get-deployment <app id|name> <deployment id>",
 		"Get a deployment",
 		`Gets information about a specific deployment for the given app, including when the app updated and what triggered the deployment (Cause).
 
@@ -148,13 +152,14 @@ Only basic information is included with the text output format. For complete app
 		Writer,
 		aliasOpt("gd"),
 		displayerType(&displayers.Deployments{}),
+		fromFileOpt(),
 	)
 	getDeployment.Example = `The following example gets information about a deployment with the ID ` + "`" + `418b7972-fc67-41ea-ab4b-6f9477c4f7d8` + "`" + ` for an app with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `. Additionally, the command returns the deployment's ID, status, and cause: doctl apps get-deployment f81d4fae-7dec-11d0-a765-00a0c91e6bf6 418b7972-fc67-41ea-ab4b-6f9477c4f7d8 --format ID,Status,Cause`
 
 	CmdBuilder(
 		cmd,
 		RunAppsListDeployments,
-		"list-deployments <app id>",
+		"list-deployments <app id|name>",
 		"List all deployments",
 		`List all deployments for an app.
 
@@ -162,12 +167,13 @@ Only basic information is included with the text output format. For complete app
 		Writer,
 		aliasOpt("lsd"),
 		displayerType(&displayers.Deployments{}),
+		fromFileOpt(),
 	)
 
 	logs := CmdBuilder(
 		cmd,
 		RunAppsGetLogs,
-		"logs <app id> <component name (defaults to all components)>",
+		"logs <app id|name> <component name (defaults to all components)>",
 		"Retrieves logs",
 		`Retrieves component logs for a deployment of an app.
 
@@ -218,24 +224,26 @@ Only basic information is included with the text output format. For complete app
 	listAlerts := CmdBuilder(
 		cmd,
 		RunAppListAlerts,
-		"list-alerts <app id>",
+		"list-alerts <app id|name>",
 		"Lists alerts on an app",
 		`Lists all alerts associated to an app and its component, such as deployment failures and domain failures.`,
 		Writer,
 		aliasOpt("la"),
 		displayerType(&displayers.AppAlerts{}),
+		fromFileOpt(),
 	)
 	listAlerts.Example = `The following example lists all alerts associated to an app with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to specifically return the alert ID, trigger, and rule: doctl apps list-alerts f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --format ID,Trigger,Spec.Rule`
 
 	updateAlertDestinations := CmdBuilder(
 		cmd,
 		RunAppUpdateAlertDestinations,
-		"update-alert-destinations <app id> <alert id>",
+		"update-alert-destinations <app id|name> <alert id>",
 		"Updates alert destinations",
 		`Updates alert destinations`,
 		Writer,
 		aliasOpt("uad"),
 		displayerType(&displayers.AppAlerts{}),
+		fromFileOpt(),
 	)
 	updateAlertDestinations.Example = `The following example updates the alert destinations for an app with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` and the alert ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl apps update-alert-destinations f81d4fae-7dec-11d0-a765-00a0c91e6bf6 f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --alert-destinations src/your-alert-destinations.yaml`
 	AddStringFlag(updateAlertDestinations, doctl.ArgAppAlertDestinations, "", "", "Path to an alert destinations file in JSON or YAML format.")
@@ -254,11 +262,12 @@ Only basic information is included with the text output format. For complete app
 	upgradeBuildpack := CmdBuilder(
 		cmd,
 		RunAppUpgradeBuildpack,
-		"upgrade-buildpack <app id>",
+		"upgrade-buildpack <app id|name>",
 		"Upgrades app's buildpack",
 		`Upgrades an app's buildpack. For more information about buildpacks, see the [buildpack reference](https://docs.digitalocean.com/products/app-platform/reference/buildpacks/)`,
 		Writer,
 		displayerType(&displayers.Deployments{}),
+		fromFileOpt(),
 	)
 	AddStringFlag(upgradeBuildpack,
 		doctl.ArgBuildpack, "", "", "The ID of the buildpack to upgrade to. Use the list-buildpacks command to list available buildpacks.", requiredOpt())
@@ -346,12 +355,21 @@ func RunAppsCreate(c *CmdConfig) error {
 	return c.Display(displayers.Apps{app})
 }
 
+// appIDFromArgs resolves the first argument, which may be an app's ID, name,
+// or URN, to the app's ID.
+func appIDFromArgs(c *CmdConfig) (string, error) {
+	return do.ResolveAppID(c.Apps(), c.Args[0])
+}
+
 // RunAppsGet gets an app.
 func RunAppsGet(c *CmdConfig) error {
 	if len(c.Args) < 1 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	id := c.Args[0]
+	id, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 
 	app, err := c.Apps().Get(id)
 	if err != nil {
@@ -381,7 +399,10 @@ func RunAppsUpdate(c *CmdConfig) error {
 	if len(c.Args) < 1 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	id := c.Args[0]
+	id, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 
 	specPath, err := c.Doit.GetString(c.NS, doctl.ArgAppSpec)
 	if err != nil {
@@ -429,7 +450,10 @@ func RunAppsDelete(c *CmdConfig) error {
 	if len(c.Args) < 1 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	id := c.Args[0]
+	id, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 
 	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
 	if err != nil {
@@ -454,7 +478,10 @@ func RunAppsCreateDeployment(c *CmdConfig) error {
 	if len(c.Args) < 1 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 	forceRebuild, err := c.Doit.GetBool(c.NS, doctl.ArgAppForceRebuild)
 	if err != nil {
 		return err
@@ -529,7 +556,10 @@ func RunAppsGetDeployment(c *CmdConfig) error {
 	if len(c.Args) < 2 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 	deploymentID := c.Args[1]
 
 	deployment, err := c.Apps().GetDeployment(appID, deploymentID)
@@ -545,7 +575,10 @@ func RunAppsListDeployments(c *CmdConfig) error {
 	if len(c.Args) < 1 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 
 	deployments, err := c.Apps().ListDeployments(appID)
 	if err != nil {
@@ -560,7 +593,10 @@ func RunAppsGetLogs(c *CmdConfig) error {
 	if len(c.Args) < 1 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 	var component string
 	if len(c.Args) >= 2 {
 		component = c.Args[1]
@@ -701,9 +737,9 @@ func appsSpec() *Command {
 		},
 	}
 
-	getCmd := CmdBuilder(cmd, RunAppsSpecGet, "get <app id>", "Retrieve an application's spec", `Use this command to retrieve the latest spec of an app.
+	getCmd := CmdBuilder(cmd, RunAppsSpecGet, "get <app id|name>", "Retrieve an application's spec", `Use this command to retrieve the latest spec of an app.
 
-Optionally, pass a deployment ID to get the spec of that specific deployment.`, Writer)
+Optionally, pass a deployment ID to get the spec of that specific deployment.`, Writer, fromFileOpt())
 	AddStringFlag(getCmd, doctl.ArgAppDeployment, "", "", "optional: a deployment ID")
 	AddStringFlag(getCmd, doctl.ArgFormat, "", "yaml", `the format to output the spec in; either "yaml" or "json"`)
 
@@ -721,7 +757,10 @@ func RunAppsSpecGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 	deploymentID, err := c.Doit.GetString(c.NS, doctl.ArgAppDeployment)
 	if err != nil {
 		return err
@@ -913,7 +952,10 @@ func RunAppListAlerts(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 
 	alerts, err := c.Apps().ListAlerts(appID)
 	if err != nil {
@@ -927,7 +969,10 @@ func RunAppUpdateAlertDestinations(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 	alertID := c.Args[1]
 
 	alertDestinationsPath, err := c.Doit.GetString(c.NS, doctl.ArgAppAlertDestinations)
@@ -1007,7 +1052,10 @@ func RunAppUpgradeBuildpack(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	appID := c.Args[0]
+	appID, err := appIDFromArgs(c)
+	if err != nil {
+		return err
+	}
 	buildpack, err := c.Doit.GetString(c.NS, doctl.ArgBuildpack)
 	if err != nil {
 		return err
@@ -129,7 +129,7 @@ For details on creating an authentication context, see the help for `+"`"+`doctl
 
 To see a list of available authentication contexts, call `+"`"+`doctl auth list`+"`"+`.
 
-For details on creating an authentication context, see the help for `+"`"+`doctl auth init`+"`"+`.`, Writer, false)
+For details on creating an authentication context, see the help for `+"`"+`doctl auth init`+"`"+`.`, Writer, false, fromFileOpt())
 	cmdAuthRemove.AddValidArgsFunc(authContextListValidArgsFunc)
 	cmdAuthRemove.Example = `The following example removes the context ` + "`" + `your-team` + "`" + `: doctl auth remove --context your-team`
 
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/pkg/urn"
+)
+
+// addFromFileFlag adds the --from-file flag to commands taking resource IDs
+// or names. It is added with the fromFileOpt option to CmdBuilder.
+func addFromFileFlag(cmd *Command) {
+	AddStringFlag(cmd, doctl.ArgFromFile, "", "",
+		"The `path` to a file of additional resource IDs or names, given one per line, as a JSON array, or as the output of a list command run with --output ndjson. Use - to read from standard input.")
+}
+
+// appendArgsFromFile appends the resource identifiers read from --from-file,
+// if set, to the command's arguments.
+func (c *CmdConfig) appendArgsFromFile(stdin io.Reader) error {
+	path, err := c.Doit.GetString(c.NS, doctl.ArgFromFile)
+	if err != nil || path == "" {
+		return err
+	}
+
+	ids, err := readBatchArgs(stdin, path)
+	if err != nil {
+		return err
+	}
+
+	c.Args = append(c.Args, ids...)
+	return nil
+}
+
+// readBatchArgs reads resource identifiers from path, or from stdin if path
+// is `-`. The input may be a JSON array of IDs or objects, or one entry per
+// line where each line is either a bare ID or name or a JSON object. Objects
+// are identified by their `id` field, falling back to the identifier in
+// their `urn` field. Blank lines and lines starting with `#` are ignored.
+func readBatchArgs(stdin io.Reader, path string) ([]string, error) {
+	var in io.Reader
+	if path == "-" && stdin != nil {
+		in = stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("reading resource identifiers: %w", err)
+	}
+
+	b = bytes.TrimSpace(b)
+	if bytes.HasPrefix(b, []byte("[")) {
+		var entries []json.RawMessage
+		if err := json.Unmarshal(b, &entries); err != nil {
+			return nil, fmt.Errorf("parsing JSON array of resource identifiers: %w", err)
+		}
+
+		ids := make([]string, 0, len(entries))
+		for i, e := range entries {
+			id, err := batchEntryID(e)
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %w", i+1, err)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+
+	var ids []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "{") {
+			ids = append(ids, line)
+			continue
+		}
+
+		id, err := batchEntryID(json.RawMessage(line))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading resource identifiers: %w", err)
+	}
+
+	return ids, nil
+}
+
+// batchEntryID extracts the resource identifier from a single JSON value,
+// which may be a string, a number or an object with an `id` or `urn` field.
+func batchEntryID(raw json.RawMessage) (string, error) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	case map[string]any:
+		switch id := t["id"].(type) {
+		case string:
+			if id != "" {
+				return id, nil
+			}
+		case json.Number:
+			return id.String(), nil
+		}
+
+		if s, ok := t["urn"].(string); ok && s != "" {
+			u, err := urn.ParseURN(s)
+			if err != nil {
+				return "", fmt.Errorf("invalid urn %q: %w", s, err)
+			}
+			return u.Identifier(), nil
+		}
+
+		return "", fmt.Errorf("object has no %q or %q field", "id", "urn")
+	default:
+		return "", fmt.Errorf("expected a string, number or object, got %s", string(raw))
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFileOpt(t *testing.T) {
+	parent := &Command{Command: &cobra.Command{Use: "droplet"}}
+	del := CmdBuilder(parent, nil, "delete <droplet-id|droplet-name>...", "", "", Writer, fromFileOpt())
+	list := CmdBuilder(parent, nil, "list <droplet-name>", "", "", Writer)
+
+	assert.NotNil(t, del.Flags().Lookup(doctl.ArgFromFile))
+	assert.Nil(t, list.Flags().Lookup(doctl.ArgFromFile))
+}
+
+func TestReadBatchArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "newline separated",
+			input:    "1\n\n  2  \n# a comment\nweb-1\n",
+			expected: []string{"1", "2", "web-1"},
+		},
+		{
+			name:     "JSON array",
+			input:    `["1", 2, {"id": 3}, {"urn": "do:droplet:4"}]`,
+			expected: []string{"1", "2", "3", "4"},
+		},
+		{
+			name: "NDJSON",
+			input: `{"id":1337,"name":"web-1"}
+{"id":"f81d4fae-7dec-11d0-a765-00a0c91e6bf6","name":"vol"}
+{"urn":"do:dbaas:abc-123"}
+`,
+			expected: []string{"1337", "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", "abc-123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := readBatchArgs(strings.NewReader(tt.input), "-")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	t.Run("missing identifier", func(t *testing.T) {
+		_, err := readBatchArgs(strings.NewReader(`{"name":"web-1"}`), "-")
+		assert.EqualError(t, err, `line 1: object has no "id" or "urn" field`)
+	})
+}
+
+func TestAppendArgsFromFile(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := filepath.Join(t.TempDir(), "ids.txt")
+		require.NoError(t, os.WriteFile(path, []byte("2\n3\n"), 0600))
+
+		config.Args = []string{"1"}
+		config.Doit.Set(config.NS, doctl.ArgFromFile, path)
+
+		err := config.appendArgsFromFile(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, config.Args)
+	})
+}
@@ -64,25 +64,25 @@ The Time To Live (TTL) value is the length of time in seconds that a file is cac
 	AddStringFlag(cmdCDNCreate, doctl.ArgCDNCertificateID, "", "", CertIDDesc)
 	cmdCDNCreate.Example = `The following example creates a CDN for the custom domain ` + "`" + `cdn.example.com ` + "`" + ` using a DigitalOcean Spaces origin endpoint and SSL certificate ID for the custom domain: doctl compute cdn create https://tester-two.blr1.digitaloceanspaces.com --domain cdn.example.com --certificate-id f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
-	cmdRunCDNDelete := CmdBuilder(cmd, RunCDNDelete, "delete <cdn-id>", "Delete a CDN", `Deletes the CDN specified by the ID.
+	cmdRunCDNDelete := CmdBuilder(cmd, RunCDNDelete, "delete <cdn-id|origin>", "Delete a CDN", `Deletes the CDN specified by the ID.
 
 You can retrieve a list of CDN IDs by calling `+"`"+`doctl compute cdn list`+"`"+``, Writer,
-		aliasOpt("rm"))
+		aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdRunCDNDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete the specified CDN without prompting for confirmation")
 	cmdRunCDNDelete.Example = `The following example deletes a CDN with the ID ` + "`" + `418b7972-fc67-41ea-ab4b-6f9477c4f7d8` + "`" + `: doctl compute cdn delete 418b7972-fc67-41ea-ab4b-6f9477c4f7d8`
 
-	cmdRunCDNGet := CmdBuilder(cmd, RunCDNGet, "get <cdn-id>", "Retrieve details about a specific CDN", `Lists the following details for the specified Content Delivery Network (CDNs):`+CDNDetails+CDNnotes, Writer, aliasOpt("g"),
-		displayerType(&displayers.CDN{}))
+	cmdRunCDNGet := CmdBuilder(cmd, RunCDNGet, "get <cdn-id|origin>", "Retrieve details about a specific CDN", `Lists the following details for the specified Content Delivery Network (CDNs):`+CDNDetails+CDNnotes, Writer, aliasOpt("g"),
+		displayerType(&displayers.CDN{}), fromFileOpt())
 	cmdRunCDNGet.Example = `The following example retrieves the origin endpoint, CDN endpoint, and certificate ID for a CDN with the ID ` + "`" + `418b7972-fc67-41ea-ab4b-6f9477c4f7d8` + "`" + `: doctl compute cdn get 418b7972-fc67-41ea-ab4b-6f9477c4f7d8 --format ID,Origin,Endpoint,CertificateID`
 
-	cmdCDNUpdate := CmdBuilder(cmd, RunCDNUpdate, "update <cdn-id>", "Update the configuration for a CDN", `Updates the configuration details of an existing Content Delivery Network (CDN).`, Writer,
-		aliasOpt("u"), displayerType(&displayers.CDN{}))
+	cmdCDNUpdate := CmdBuilder(cmd, RunCDNUpdate, "update <cdn-id|origin>", "Update the configuration for a CDN", `Updates the configuration details of an existing Content Delivery Network (CDN).`, Writer,
+		aliasOpt("u"), displayerType(&displayers.CDN{}), fromFileOpt())
 	AddIntFlag(cmdCDNUpdate, doctl.ArgCDNTTL, "", 3600, TTLDesc)
 	AddStringFlag(cmdCDNUpdate, doctl.ArgCDNDomain, "", "", DomainDesc)
 	AddStringFlag(cmdCDNUpdate, doctl.ArgCDNCertificateID, "", "", CertIDDesc)
 	cmdCDNUpdate.Example = `The following example updates the TTL for a CDN with the ID ` + "`" + `418b7972-fc67-41ea-ab4b-6f9477c4f7d8` + "`" + ` to 600 seconds: doctl compute cdn update 418b7972-fc67-41ea-ab4b-6f9477c4f7d8 --ttl 600`
 
-	cmdCDNFlushCache := CmdBuilder(cmd, RunCDNFlushCache, "flush <cdn-id>", "Flush the cache of a CDN", `Flushes the cache of a Content Delivery Network (CDN), which:
+	cmdCDNFlushCache := CmdBuilder(cmd, RunCDNFlushCache, "flush <cdn-id|origin>", "Flush the cache of a CDN", `Flushes the cache of a Content Delivery Network (CDN), which:
 
 - purges all copies of the files in the cache
 - re-caches the files
@@ -96,7 +96,7 @@ Examples:
  doctl compute cdn flush <cdn-id>  --files "/path/to/file.one, /path/to/file.two"
  doctl compute cdn flush <cdn-id>  --files /path/to/file.one --files /path/to/file.two
  doctl compute cdn flush <cdn-id>  --files * `, Writer,
-		aliasOpt("fc"))
+		aliasOpt("fc"), fromFileOpt())
 	AddStringSliceFlag(cmdCDNFlushCache, doctl.ArgCDNFiles, "", []string{"*"}, "cdn files")
 	cmdCDNFlushCache.Example = `The following example flushes the cache of the ` + "`" + `/path/to/assets` + "`" + ` directory in a CDN: doctl compute cdn flush 418b7972-fc67-41ea-ab4b-6f9477c4f7d8 --files /path/to/assets/*`
 
@@ -119,8 +119,12 @@ func RunCDNGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
-	item, err := c.CDNs().Get(id)
+	cs := c.CDNs()
+	id, err := do.ResolveCDNID(cs, c.Args[0])
+	if err != nil {
+		return err
+	}
+	item, err := cs.Get(id)
 	if err != nil {
 		return err
 	}
@@ -170,9 +174,11 @@ func RunCDNUpdate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
-
 	cs := c.CDNs()
+	id, err := do.ResolveCDNID(cs, c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	var item *do.CDN
 	if c.Doit.IsSet(doctl.ArgCDNTTL) {
@@ -251,8 +257,12 @@ func RunCDNDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("CDN", 1) == nil {
-		id := c.Args[0]
-		return c.CDNs().Delete(id)
+		cs := c.CDNs()
+		id, err := do.ResolveCDNID(cs, c.Args[0])
+		if err != nil {
+			return err
+		}
+		return cs.Delete(id)
 	}
 
 	return errOperationAborted
@@ -264,7 +274,10 @@ func RunCDNFlushCache(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := do.ResolveCDNID(c.CDNs(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	files, err := c.Doit.GetStringSlice(c.NS, doctl.ArgCDNFiles)
 	if err != nil {
@@ -46,8 +46,8 @@ Once a certificate has been stored, it is assigned a unique certificate ID that
 - The certificate type (` + "`" + `custom` + "`" + ` or ` + "`" + `lets_encrypt` + "`" + `)
 - The certificate state (` + "`" + `pending` + "`" + `, ` + "`" + `verified` + "`" + `, or ` + "`" + `error` + "`" + `)`
 
-	cmdCertificateGet := CmdBuilder(cmd, RunCertificateGet, "get <id>", "Retrieve details about a certificate", `This command retrieves the following details about a certificate:`+certDetails, Writer,
-		aliasOpt("g"), displayerType(&displayers.Certificate{}))
+	cmdCertificateGet := CmdBuilder(cmd, RunCertificateGet, "get <certificate-id|name>", "Retrieve details about a certificate", `This command retrieves the following details about a certificate:`+certDetails, Writer,
+		aliasOpt("g"), displayerType(&displayers.Certificate{}), fromFileOpt())
 	cmdCertificateGet.Example = "The following example retrieves the ID, name, and domains associated with a certificate: doctl compute certificate get f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --format ID,Name,DNSNames"
 
 	cmdCertificateCreate := CmdBuilder(cmd, RunCertificateCreate, "create",
@@ -59,7 +59,7 @@ To create a Let's Encrypt certificate, you need to add the domain(s) to your acc
 
 	doctl compute certificate create --type lets_encrypt --name mycert --dns-names example.org
 
-To upload a custom certificate, you need to provide a certificate name, the path to the certificate, the path to the certificate's private key, and the path to the certificate chain, all in PEM format:
+To upload a custom certificate, you need to provide a certificate name, the path to the certificate, the path to the certificate's private key, and the path to the certificate chain, all in PEM format. The files are checked before they are uploaded, as described in `+"`"+`doctl compute certificate inspect`+"`"+`, and the chain is put in order if needed:
 
 	doctl compute certificate create --type custom --name mycert --leaf-certificate-path cert.pem --certificate-chain-path fullchain.pem --private-key-path privkey.pem`, Writer, aliasOpt("c"))
 	AddStringFlag(cmdCertificateCreate, doctl.ArgCertificateName, "", "",
@@ -81,10 +81,45 @@ To upload a custom certificate, you need to provide a certificate name, the path
 	AddStringFlag(cmdCertificateList, doctl.ArgCertificateName, "", "",
 		"Filter certificates by the specified name")
 
-	cmdCertificateDelete := CmdBuilder(cmd, RunCertificateDelete, "delete <id>",
+	cmdCertificateExpiring := CmdBuilder(cmd, RunCertificateExpiring, "expiring",
+		"List certificates that expire soon", `Lists the certificates that expire within the time given by `+"`"+`--within`+"`"+`, along with the load balancers and CDN endpoints that use each one. Certificates that have already expired are included.
+
+The command exits with an error when any certificate is listed, so it can be run as a scheduled CI check.`, Writer,
+		displayerType(&displayers.CertificateExpiries{}))
+	AddStringFlag(cmdCertificateExpiring, doctl.ArgCertificateWithin, "", "30d",
+		"List certificates that expire within this time, in days such as `30d` or as a duration such as `72h`")
+	AddStringFlag(cmdCertificateExpiring, doctl.ArgCertificateType, "", "custom",
+		"Only list certificates of this type, `custom` or `lets_encrypt`. Set it to an empty string to list both.")
+	cmdCertificateExpiring.Example = `The following example lists the custom certificates that expire within 14 days, and fails if there are any: doctl compute certificate expiring --within 14d`
+
+	cmdCertificateRotate := CmdBuilder(cmd, RunCertificateRotate, "rotate <certificate-id|name>",
+		"Replace a custom certificate everywhere it is used", `Uploads a replacement custom certificate and updates every load balancer forwarding rule, global load balancer domain, and CDN endpoint that uses the old certificate to use the new one.
+
+The old certificate is deleted only after every load balancer and CDN endpoint has been updated. If an update fails, the old certificate is kept and the error lists the resources that still use it.`, Writer,
+		displayerType(&displayers.Certificate{}), fromFileOpt())
+	AddStringFlag(cmdCertificateRotate, doctl.ArgLeafCertificatePath, "", "",
+		"The path on your local machine to a PEM-formatted public SSL certificate.", requiredOpt())
+	AddStringFlag(cmdCertificateRotate, doctl.ArgPrivateKeyPath, "", "",
+		"The path on your local machine to a PEM-formatted private-key corresponding to the SSL certificate.", requiredOpt())
+	AddStringFlag(cmdCertificateRotate, doctl.ArgCertificateChainPath, "", "",
+		"The path on your local machine to a full PEM-formatted trust chain between the certificate authority's certificate and your domain's SSL certificate.")
+	AddStringFlag(cmdCertificateRotate, doctl.ArgCertificateName, "", "",
+		"A name for the new certificate. Defaults to the old certificate's name followed by today's date.")
+	AddBoolFlag(cmdCertificateRotate, doctl.ArgCertificateKeepOld, "", false,
+		"Keep the old certificate after the load balancers and CDN endpoints have been updated")
+	cmdCertificateRotate.Example = `The following example replaces the certificate named ` + "`" + `web-cert` + "`" + ` on every load balancer and CDN endpoint that uses it: doctl compute certificate rotate web-cert --leaf-certificate-path cert.pem --certificate-chain-path chain.pem --private-key-path privkey.pem`
+
+	cmdCertificateInspect := CmdBuilder(cmd, RunCertificateInspect, "inspect <file>...",
+		"Check local certificate files before uploading them", `Reads PEM-formatted certificates and a private key from local files and runs the same checks as `+"`"+`doctl compute certificate create`+"`"+`, without uploading anything. The files may be given in any order, and a file may contain several certificates.
+
+The command verifies that the private key matches the leaf certificate and that each certificate in the chain issued the one before it, and warns about certificates that expire soon, weak keys, and leaf certificates without subject alternative names. It prints each certificate of the chain in order, and exits with an error if the certificate would be rejected.`, Writer,
+		displayerType(&displayers.CertificateDetails{}))
+	cmdCertificateInspect.Example = `The following example checks a certificate, its chain, and its private key: doctl compute certificate inspect cert.pem chain.pem privkey.pem`
+
+	cmdCertificateDelete := CmdBuilder(cmd, RunCertificateDelete, "delete <certificate-id|name>",
 		"Delete the specified certificate", `Deletes the specified certificate.
 
-Use `+"`"+`doctl compute certificate list`+"`"+` to see all available certificates associated with your account.`, Writer, aliasOpt("d", "rm"))
+Use `+"`"+`doctl compute certificate list`+"`"+` to see all available certificates associated with your account.`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdCertificateDelete, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Delete the certificate without a confirmation prompt")
 	cmdCertificateDelete.Example = `The following example deletes the certificate with the ID  ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute certificate delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
@@ -97,7 +132,10 @@ func RunCertificateGet(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	cID := c.Args[0]
+	cID, err := do.ResolveCertificateID(c.Certificates(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	cs := c.Certificates()
 	cer, err := cs.Get(cID)
@@ -132,48 +170,13 @@ func RunCertificateCreate(c *CmdConfig) error {
 		Type:     cType,
 	}
 
-	pkPath, err := c.Doit.GetString(c.NS, doctl.ArgPrivateKeyPath)
-	if err != nil {
-		return err
-	}
-
-	if len(pkPath) > 0 {
-		pc, err := readInputFromFile(pkPath)
-		if err != nil {
-			return err
-		}
-
-		r.PrivateKey = pc
-	}
-
-	lcPath, err := c.Doit.GetString(c.NS, doctl.ArgLeafCertificatePath)
-	if err != nil {
+	if err := readCertificateFiles(c, r); err != nil {
 		return err
 	}
-
-	if len(lcPath) > 0 {
-		lc, err := readInputFromFile(lcPath)
-		if err != nil {
-			return err
-		}
-
-		r.LeafCertificate = lc
-	}
-
-	ccPath, err := c.Doit.GetString(c.NS, doctl.ArgCertificateChainPath)
-	if err != nil {
+	if err := checkCertificateRequest(r); err != nil {
 		return err
 	}
 
-	if len(ccPath) > 0 {
-		cc, err := readInputFromFile(ccPath)
-		if err != nil {
-			return err
-		}
-
-		r.CertificateChain = cc
-	}
-
 	cs := c.Certificates()
 	cer, err := cs.Create(r)
 	if err != nil {
@@ -214,7 +217,10 @@ func RunCertificateDelete(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	cID := c.Args[0]
+	cID, err := do.ResolveCertificateID(c.Certificates(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
 	if err != nil {
@@ -233,6 +239,53 @@ func RunCertificateDelete(c *CmdConfig) error {
 	return nil
 }
 
+// readCertificateFiles sets the private key, leaf certificate and chain of r
+// from the files named by the path flags.
+func readCertificateFiles(c *CmdConfig, r *godo.CertificateRequest) error {
+	pkPath, err := c.Doit.GetString(c.NS, doctl.ArgPrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	if len(pkPath) > 0 {
+		pc, err := readInputFromFile(pkPath)
+		if err != nil {
+			return err
+		}
+
+		r.PrivateKey = pc
+	}
+
+	lcPath, err := c.Doit.GetString(c.NS, doctl.ArgLeafCertificatePath)
+	if err != nil {
+		return err
+	}
+
+	if len(lcPath) > 0 {
+		lc, err := readInputFromFile(lcPath)
+		if err != nil {
+			return err
+		}
+
+		r.LeafCertificate = lc
+	}
+
+	ccPath, err := c.Doit.GetString(c.NS, doctl.ArgCertificateChainPath)
+	if err != nil {
+		return err
+	}
+
+	if len(ccPath) > 0 {
+		cc, err := readInputFromFile(ccPath)
+		if err != nil {
+			return err
+		}
+
+		r.CertificateChain = cc
+	}
+	return nil
+}
+
 func readInputFromFile(path string) (string, error) {
 	fileBytes, err := os.ReadFile(path)
 	if err != nil {
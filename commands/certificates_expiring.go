@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+)
+
+// certificateNow returns the current time. Tests replace it.
+var certificateNow = time.Now
+
+// RunCertificateExpiring lists the certificates that expire within a window,
+// with the load balancers and CDN endpoints that use them. It returns an
+// error when any certificate is listed so that it can gate CI jobs.
+func RunCertificateExpiring(c *CmdConfig) error {
+	withinFlag, err := c.Doit.GetString(c.NS, doctl.ArgCertificateWithin)
+	if err != nil {
+		return err
+	}
+	within, err := parseDayDuration(withinFlag)
+	if err != nil {
+		return fmt.Errorf("--%s %q is not valid: %v", doctl.ArgCertificateWithin, withinFlag, err)
+	}
+	cType, err := c.Doit.GetString(c.NS, doctl.ArgCertificateType)
+	if err != nil {
+		return err
+	}
+
+	certs, err := c.Certificates().List()
+	if err != nil {
+		return err
+	}
+
+	now := certificateNow()
+	var expiring []displayers.CertificateExpiry
+	for _, cert := range certs {
+		if cType != "" && cert.Type != cType {
+			continue
+		}
+		notAfter, err := time.Parse(time.RFC3339, cert.NotAfter)
+		if err != nil {
+			return fmt.Errorf("certificate %s has an invalid expiration date %q: %v", cert.Name, cert.NotAfter, err)
+		}
+		if notAfter.After(now.Add(within)) {
+			continue
+		}
+		expiring = append(expiring, displayers.CertificateExpiry{
+			ID:       cert.ID,
+			Name:     cert.Name,
+			Type:     cert.Type,
+			DNSNames: cert.DNSNames,
+			NotAfter: cert.NotAfter,
+			DaysLeft: int(math.Floor(notAfter.Sub(now).Hours() / 24)),
+		})
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].NotAfter < expiring[j].NotAfter
+	})
+
+	if len(expiring) > 0 {
+		if err := addCertificateUsers(c, expiring); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Display(&displayers.CertificateExpiries{Certificates: expiring}); err != nil {
+		return err
+	}
+	switch len(expiring) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("1 certificate expires within %s", withinFlag)
+	default:
+		return fmt.Errorf("%d certificates expire within %s", len(expiring), withinFlag)
+	}
+}
+
+// addCertificateUsers fills in the load balancers and CDN endpoints that use
+// each certificate.
+func addCertificateUsers(c *CmdConfig, certs []displayers.CertificateExpiry) error {
+	byID := make(map[string]*displayers.CertificateExpiry, len(certs))
+	for i := range certs {
+		byID[certs[i].ID] = &certs[i]
+	}
+
+	lbs, err := c.LoadBalancers().List()
+	if err != nil {
+		return err
+	}
+	for _, lb := range lbs {
+		// Regional load balancers use certificates in their forwarding
+		// rules, and global ones in their domains.
+		var certIDs []string
+		for _, rule := range lb.ForwardingRules {
+			certIDs = append(certIDs, rule.CertificateID)
+		}
+		for _, d := range lb.Domains {
+			certIDs = append(certIDs, d.CertificateID)
+		}
+
+		seen := map[string]bool{}
+		for _, id := range certIDs {
+			cert, ok := byID[id]
+			if !ok || seen[id] {
+				continue
+			}
+			seen[id] = true
+			cert.LoadBalancers = append(cert.LoadBalancers, lb.Name)
+		}
+	}
+
+	cdns, err := c.CDNs().List()
+	if err != nil {
+		return err
+	}
+	for _, cdn := range cdns {
+		if cert, ok := byID[cdn.CertificateID]; ok {
+			endpoint := cdn.CustomDomain
+			if endpoint == "" {
+				endpoint = cdn.Endpoint
+			}
+			cert.CDNEndpoints = append(cert.CDNEndpoints, endpoint)
+		}
+	}
+	return nil
+}
+
+// parseDayDuration parses a duration such as 30d, or any duration accepted
+// by time.ParseDuration.
+func parseDayDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("must be a whole number of days, such as 30d")
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testExpiringCertificates = do.Certificates{
+	{Certificate: &godo.Certificate{ID: "c1", Name: "web", Type: "custom", DNSNames: []string{"example.com"}, NotAfter: "2024-03-11T00:00:00Z"}},
+	{Certificate: &godo.Certificate{ID: "c2", Name: "api", Type: "custom", NotAfter: "2024-06-01T00:00:00Z"}},
+	{Certificate: &godo.Certificate{ID: "c3", Name: "le", Type: "lets_encrypt", NotAfter: "2024-03-05T00:00:00Z"}},
+	{Certificate: &godo.Certificate{ID: "c4", Name: "old", Type: "custom", NotAfter: "2024-02-01T00:00:00Z"}},
+}
+
+func withCertificateNow(t *testing.T) {
+	now := certificateNow
+	t.Cleanup(func() { certificateNow = now })
+	certificateNow = func() time.Time { return time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) }
+}
+
+func TestCertificateExpiring(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withCertificateNow(t)
+
+		tm.certificates.EXPECT().List().Return(testExpiringCertificates, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{
+			{LoadBalancer: &godo.LoadBalancer{Name: "lb-web", ForwardingRules: []godo.ForwardingRule{
+				{EntryProtocol: "https", CertificateID: "c1"},
+				{EntryProtocol: "http2", CertificateID: "c1"},
+			}}},
+			{LoadBalancer: &godo.LoadBalancer{Name: "lb-api", ForwardingRules: []godo.ForwardingRule{
+				{EntryProtocol: "https", CertificateID: "c2"},
+			}}},
+			{LoadBalancer: &godo.LoadBalancer{Name: "glb-web", Type: "GLOBAL", Domains: []*godo.LBDomain{
+				{Name: "example.com", CertificateID: "c1"},
+				{Name: "www.example.com", CertificateID: "c1"},
+			}}},
+		}, nil)
+		tm.cdns.EXPECT().List().Return([]do.CDN{
+			{CDN: &godo.CDN{Endpoint: "assets.nyc3.cdn.digitaloceanspaces.com", CustomDomain: "static.example.com", CertificateID: "c1"}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgCertificateWithin, "30d")
+		config.Doit.Set(config.NS, doctl.ArgCertificateType, "custom")
+
+		err := RunCertificateExpiring(config)
+		assert.EqualError(t, err, "2 certificates expire within 30d")
+
+		out := buf.String()
+		assert.Regexp(t, `c4\s+old\s+custom\s+2024-02-01T00:00:00Z\s+-29`, out)
+		assert.Regexp(t, `c1\s+web\s+custom\s+example.com\s+2024-03-11T00:00:00Z\s+10\s+lb-web,glb-web\s+static.example.com`, out)
+		assert.NotContains(t, out, "api")
+		assert.NotContains(t, out, "lets_encrypt")
+		assert.Less(t, bytes.Index(buf.Bytes(), []byte("old")), bytes.Index(buf.Bytes(), []byte("web")))
+	})
+}
+
+func TestCertificateExpiringNone(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withCertificateNow(t)
+
+		tm.certificates.EXPECT().List().Return(testExpiringCertificates[:3], nil)
+
+		config.Doit.Set(config.NS, doctl.ArgCertificateWithin, "72h")
+		config.Doit.Set(config.NS, doctl.ArgCertificateType, "custom")
+
+		err := RunCertificateExpiring(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCertificateExpiringInvalidWithin(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgCertificateWithin, "a month")
+
+		err := RunCertificateExpiring(config)
+		assert.EqualError(t, err, `--within "a month" is not valid: time: invalid duration "a month"`)
+	})
+}
+
+func TestParseDayDuration(t *testing.T) {
+	d, err := parseDayDuration("30d")
+	require.NoError(t, err)
+	assert.Equal(t, 720*time.Hour, d)
+
+	d, err = parseDayDuration("36h")
+	require.NoError(t, err)
+	assert.Equal(t, 36*time.Hour, d)
+
+	_, err = parseDayDuration("1.5d")
+	assert.EqualError(t, err, "must be a whole number of days, such as 30d")
+}
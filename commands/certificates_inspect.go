@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/pkg/certcheck"
+	"github.com/digitalocean/godo"
+)
+
+// RunCertificateInspect checks local PEM files the same way certificate
+// create does, and prints each certificate without uploading anything.
+func RunCertificateInspect(c *CmdConfig) error {
+	if len(c.Args) == 0 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+
+	var (
+		certs []*x509.Certificate
+		key   crypto.PrivateKey
+	)
+	for _, path := range c.Args {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		found, err := certcheck.ParseCertificates(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		certs = append(certs, found...)
+		if key == nil {
+			key, err = certcheck.ParsePrivateKey(data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+	if len(certs) == 0 {
+		return errors.New("no PEM-encoded certificates were found")
+	}
+
+	leaf, chain := certcheck.FindLeaf(certs, key)
+	report := certcheck.Check(leaf, chain, key, certificateNow())
+
+	details := []displayers.CertificateDetail{certificateDetail(leaf, "leaf")}
+	for _, cert := range report.Chain {
+		role := "intermediate"
+		if cert.Subject.String() == cert.Issuer.String() {
+			role = "root"
+		}
+		details = append(details, certificateDetail(cert, role))
+	}
+	if err := c.Display(&displayers.CertificateDetails{Certificates: details}); err != nil {
+		return err
+	}
+	if key == nil {
+		warn("No private key was found, so it was not checked against the certificate")
+	}
+	return reportCertificateProblems(report)
+}
+
+// checkCertificateRequest checks the PEM files of a custom certificate
+// before they are uploaded. Warnings are printed, and problems that would
+// make the upload fail are returned. If the chain was given out of order or
+// with extra certificates, r is changed to hold the ordered chain.
+func checkCertificateRequest(r *godo.CertificateRequest) error {
+	if r.LeafCertificate == "" {
+		return nil
+	}
+
+	certs, err := certcheck.ParseCertificates([]byte(r.LeafCertificate))
+	if err != nil {
+		return fmt.Errorf("--%s: %w", doctl.ArgLeafCertificatePath, err)
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("--%s does not contain a PEM-encoded certificate", doctl.ArgLeafCertificatePath)
+	}
+	if r.PrivateKey == "" {
+		return fmt.Errorf("--%s is required with --%s", doctl.ArgPrivateKeyPath, doctl.ArgLeafCertificatePath)
+	}
+	key, err := certcheck.ParsePrivateKey([]byte(r.PrivateKey))
+	if err != nil {
+		return fmt.Errorf("--%s: %w", doctl.ArgPrivateKeyPath, err)
+	}
+	if key == nil {
+		return fmt.Errorf("--%s does not contain a PEM-encoded private key", doctl.ArgPrivateKeyPath)
+	}
+	chain, err := certcheck.ParseCertificates([]byte(r.CertificateChain))
+	if err != nil {
+		return fmt.Errorf("--%s: %w", doctl.ArgCertificateChainPath, err)
+	}
+
+	// A full chain in the leaf file is split into the leaf and its chain.
+	leaf := certs[0]
+	chain = append(append([]*x509.Certificate(nil), certs[1:]...), chain...)
+	report := certcheck.Check(leaf, chain, key, certificateNow())
+	if err := reportCertificateProblems(report); err != nil {
+		return err
+	}
+
+	if len(certs) > 1 || len(report.Chain) != len(chain) || report.Reordered {
+		if report.Reordered {
+			notice("The certificate chain was reordered to start with the issuer of the leaf certificate")
+		}
+		r.LeafCertificate = certcheck.EncodeCertificates(certs[:1])
+		r.CertificateChain = certcheck.EncodeCertificates(report.Chain)
+	}
+	return nil
+}
+
+// reportCertificateProblems prints the warnings of a report and returns its
+// errors.
+func reportCertificateProblems(report *certcheck.Report) error {
+	for _, w := range report.Warnings {
+		warn(w)
+	}
+	errs := make([]error, 0, len(report.Errors))
+	for _, e := range report.Errors {
+		errs = append(errs, errors.New(e))
+	}
+	return errors.Join(errs...)
+}
+
+func certificateDetail(cert *x509.Certificate, role string) displayers.CertificateDetail {
+	fingerprint := sha1.Sum(cert.Raw)
+	return displayers.CertificateDetail{
+		Role:               role,
+		Subject:            certcheck.Name(cert),
+		Issuer:             cert.Issuer.String(),
+		DNSNames:           cert.DNSNames,
+		NotBefore:          cert.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:           cert.NotAfter.UTC().Format(time.RFC3339),
+		Key:                certcheck.KeyDescription(cert),
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		SHA1Fingerprint:    hex.EncodeToString(fingerprint[:]),
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/pkg/certcheck/certchecktest"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestPEM(t *testing.T, dir, name, data string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+	return path
+}
+
+func TestCertificateInspect(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		leaf, intermediate, root, err := certchecktest.NewChain("example.com", "www.example.com")
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		config.Args = append(config.Args,
+			writeTestPEM(t, dir, "chain.pem", certchecktest.PEM(root, intermediate)),
+			writeTestPEM(t, dir, "cert.pem", certchecktest.PEM(leaf)),
+			writeTestPEM(t, dir, "key.pem", leaf.KeyPEM()),
+		)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+
+		err = RunCertificateInspect(config)
+		require.NoError(t, err)
+
+		out := buf.String()
+		assert.Regexp(t, `leaf\s+example.com\s+CN=Test Intermediate\s+example.com,www.example.com\s+\S+\s+ECDSA P-256\s+ECDSA-SHA256`, out)
+		assert.Regexp(t, `intermediate\s+Test Intermediate\s+CN=Test Root`, out)
+		assert.Regexp(t, `root\s+Test Root\s+CN=Test Root`, out)
+	})
+}
+
+func TestCertificateInspectKeyMismatch(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		leaf, intermediate, _, err := certchecktest.NewChain("example.com")
+		require.NoError(t, err)
+		other, _, _, err := certchecktest.NewChain("example.net")
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		config.Args = append(config.Args,
+			writeTestPEM(t, dir, "fullchain.pem", certchecktest.PEM(leaf, intermediate)),
+			writeTestPEM(t, dir, "key.pem", other.KeyPEM()),
+		)
+
+		err = RunCertificateInspect(config)
+		assert.EqualError(t, err, `the private key does not match the certificate "example.com"`)
+	})
+}
+
+func TestCertificatesCreateReordersChain(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		leaf, intermediate, root, err := certchecktest.NewChain("example.com")
+		require.NoError(t, err)
+
+		tm.certificates.EXPECT().Create(&godo.CertificateRequest{
+			Name:             "custom-cert",
+			PrivateKey:       leaf.KeyPEM(),
+			LeafCertificate:  certchecktest.PEM(leaf),
+			CertificateChain: certchecktest.PEM(intermediate, root),
+		}).Return(&testCertificate, nil)
+
+		dir := t.TempDir()
+		config.Doit.Set(config.NS, doctl.ArgCertificateName, "custom-cert")
+		config.Doit.Set(config.NS, doctl.ArgLeafCertificatePath, writeTestPEM(t, dir, "cert.pem", certchecktest.PEM(leaf, root)))
+		config.Doit.Set(config.NS, doctl.ArgCertificateChainPath, writeTestPEM(t, dir, "chain.pem", certchecktest.PEM(intermediate)))
+		config.Doit.Set(config.NS, doctl.ArgPrivateKeyPath, writeTestPEM(t, dir, "key.pem", leaf.KeyPEM()))
+
+		err = RunCertificateCreate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestCertificatesCreateInvalidFiles(t *testing.T) {
+	leaf, intermediate, _, err := certchecktest.NewChain("example.com")
+	require.NoError(t, err)
+	other, _, _, err := certchecktest.NewChain("example.net")
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc  string
+		leaf  string
+		chain string
+		key   string
+		err   string
+	}{
+		{
+			desc: "not PEM",
+			leaf: "-----BEGIN CERTIFICATE-----",
+			key:  leaf.KeyPEM(),
+			err:  "--leaf-certificate-path does not contain a PEM-encoded certificate",
+		},
+		{
+			desc:  "no key",
+			leaf:  certchecktest.PEM(leaf),
+			chain: certchecktest.PEM(intermediate),
+			key:   certchecktest.PEM(intermediate),
+			err:   "--private-key-path does not contain a PEM-encoded private key",
+		},
+		{
+			desc:  "wrong key",
+			leaf:  certchecktest.PEM(leaf),
+			chain: certchecktest.PEM(intermediate),
+			key:   other.KeyPEM(),
+			err:   `the private key does not match the certificate "example.com"`,
+		},
+		{
+			desc:  "wrong chain",
+			leaf:  certchecktest.PEM(leaf),
+			chain: certchecktest.PEM(other),
+			key:   leaf.KeyPEM(),
+			err:   `the chain does not contain the issuer of "example.com" (CN=Test Intermediate)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+				dir := t.TempDir()
+				config.Doit.Set(config.NS, doctl.ArgCertificateName, "custom-cert")
+				config.Doit.Set(config.NS, doctl.ArgLeafCertificatePath, writeTestPEM(t, dir, "cert.pem", test.leaf))
+				config.Doit.Set(config.NS, doctl.ArgPrivateKeyPath, writeTestPEM(t, dir, "key.pem", test.key))
+				if test.chain != "" {
+					config.Doit.Set(config.NS, doctl.ArgCertificateChainPath, writeTestPEM(t, dir, "chain.pem", test.chain))
+				}
+
+				err := RunCertificateCreate(config)
+				assert.EqualError(t, err, test.err)
+			})
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+)
+
+// RunCertificateRotate uploads a replacement for a custom certificate, moves
+// every load balancer and CDN endpoint that uses the old certificate to the
+// new one, and then deletes the old certificate.
+func RunCertificateRotate(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+	name, err := c.Doit.GetString(c.NS, doctl.ArgCertificateName)
+	if err != nil {
+		return err
+	}
+	keepOld, err := c.Doit.GetBool(c.NS, doctl.ArgCertificateKeepOld)
+	if err != nil {
+		return err
+	}
+
+	cs := c.Certificates()
+	oldID, err := do.ResolveCertificateID(cs, c.Args[0])
+	if err != nil {
+		return err
+	}
+	old, err := cs.Get(oldID)
+	if err != nil {
+		return err
+	}
+	if old.Type == "lets_encrypt" {
+		return fmt.Errorf("certificate %s is a Let's Encrypt certificate, which is renewed automatically", old.Name)
+	}
+	if name == "" {
+		name = old.Name + "-" + certificateNow().Format("20060102")
+	}
+
+	r := &godo.CertificateRequest{Name: name, Type: "custom"}
+	if err := readCertificateFiles(c, r); err != nil {
+		return err
+	}
+	if err := checkCertificateRequest(r); err != nil {
+		return err
+	}
+
+	lbs, err := c.LoadBalancers().List()
+	if err != nil {
+		return err
+	}
+	var lbUsers do.LoadBalancers
+	for _, lb := range lbs {
+		if loadBalancerUsesCertificate(lb.LoadBalancer, oldID) {
+			lbUsers = append(lbUsers, lb)
+		}
+	}
+	cdns, err := c.CDNs().List()
+	if err != nil {
+		return err
+	}
+	var cdnUsers []do.CDN
+	for _, cdn := range cdns {
+		if cdn.CertificateID == oldID {
+			cdnUsers = append(cdnUsers, cdn)
+		}
+	}
+
+	cert, err := cs.Create(r)
+	if err != nil {
+		return err
+	}
+	notice("Created certificate %s (%s)", cert.Name, cert.ID)
+
+	var errs []error
+	for _, lb := range lbUsers {
+		req := loadBalancerSpec(lb.LoadBalancer)
+		for i := range req.ForwardingRules {
+			if req.ForwardingRules[i].CertificateID == oldID {
+				req.ForwardingRules[i].CertificateID = cert.ID
+			}
+		}
+		for _, d := range req.Domains {
+			if d.CertificateID == oldID {
+				d.CertificateID = cert.ID
+			}
+		}
+		if _, err := c.LoadBalancers().Update(lb.ID, req); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update load balancer %s: %w", lb.Name, err))
+			continue
+		}
+		notice("Updated load balancer %s", lb.Name)
+	}
+	for _, cdn := range cdnUsers {
+		req := &godo.CDNUpdateCustomDomainRequest{CustomDomain: cdn.CustomDomain, CertificateID: cert.ID}
+		if _, err := c.CDNs().UpdateCustomDomain(cdn.ID, req); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update CDN endpoint %s: %w", cdn.CustomDomain, err))
+			continue
+		}
+		notice("Updated CDN endpoint %s", cdn.CustomDomain)
+	}
+	if len(errs) > 0 {
+		errs = append(errs, fmt.Errorf("certificate %s was not deleted; update the remaining resources to use certificate %s and then delete it", old.Name, cert.ID))
+		return errors.Join(errs...)
+	}
+
+	if len(lbUsers) == 0 && len(cdnUsers) == 0 {
+		warn("No load balancers or CDN endpoints use certificate %s", old.Name)
+	}
+	if keepOld {
+		notice("Kept certificate %s", old.Name)
+	} else {
+		if err := cs.Delete(oldID); err != nil {
+			return fmt.Errorf("every resource now uses certificate %s, but deleting certificate %s failed: %w", cert.ID, old.Name, err)
+		}
+		notice("Deleted certificate %s", old.Name)
+	}
+
+	return c.Display(&displayers.Certificate{Certificates: do.Certificates{*cert}})
+}
+
+// loadBalancerUsesCertificate reports whether a forwarding rule of lb, or one
+// of the domains of a global load balancer, uses the certificate.
+func loadBalancerUsesCertificate(lb *godo.LoadBalancer, certID string) bool {
+	for _, rule := range lb.ForwardingRules {
+		if rule.CertificateID == certID {
+			return true
+		}
+	}
+	for _, d := range lb.Domains {
+		if d.CertificateID == certID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/certcheck/certchecktest"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testRotateOldID = "892071a0-bb95-49bc-8021-3afd67a210bf"
+	testRotateNewID = "b8c8a4a0-2dd9-4e5e-a4b4-0e2a52e2b1f6"
+)
+
+var (
+	testRotateOld = &do.Certificate{Certificate: &godo.Certificate{ID: testRotateOldID, Name: "web-cert", Type: "custom"}}
+	testRotateNew = &do.Certificate{Certificate: &godo.Certificate{ID: testRotateNewID, Name: "web-cert-20240301", Type: "custom"}}
+
+	testRotateLB = do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{
+		ID:     "lb-1",
+		Name:   "web",
+		Region: &godo.Region{Slug: "nyc1"},
+		ForwardingRules: []godo.ForwardingRule{
+			{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 80},
+			{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 80, CertificateID: testRotateOldID},
+		},
+	}}
+	testRotateCDN = do.CDN{CDN: &godo.CDN{ID: "cdn-1", CustomDomain: "static.example.com", CertificateID: testRotateOldID}}
+)
+
+// setRotateFlags writes a certificate that is valid at the time set by
+// withCertificateNow, and sets the flags that upload it.
+func setRotateFlags(t *testing.T, config *CmdConfig) *godo.CertificateRequest {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ca, err := certchecktest.New(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+	}, nil, nil)
+	require.NoError(t, err)
+	leaf, err := certchecktest.New(&x509.Certificate{
+		Subject:   pkix.Name{CommonName: "example.com"},
+		DNSNames:  []string{"example.com"},
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+	}, ca, nil)
+	require.NoError(t, err)
+
+	r := &godo.CertificateRequest{
+		Name:             "web-cert-20240301",
+		Type:             "custom",
+		LeafCertificate:  certchecktest.PEM(leaf),
+		CertificateChain: certchecktest.PEM(ca),
+		PrivateKey:       leaf.KeyPEM(),
+	}
+
+	dir := t.TempDir()
+	leafPath := filepath.Join(dir, "cert.pem")
+	chainPath := filepath.Join(dir, "chain.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(leafPath, []byte(r.LeafCertificate), 0600))
+	require.NoError(t, os.WriteFile(chainPath, []byte(r.CertificateChain), 0600))
+	require.NoError(t, os.WriteFile(keyPath, []byte(r.PrivateKey), 0600))
+
+	config.Args = append(config.Args, testRotateOldID)
+	config.Doit.Set(config.NS, doctl.ArgLeafCertificatePath, leafPath)
+	config.Doit.Set(config.NS, doctl.ArgCertificateChainPath, chainPath)
+	config.Doit.Set(config.NS, doctl.ArgPrivateKeyPath, keyPath)
+	return r
+}
+
+func expectRotateUsers(tm *tcMocks, r *godo.CertificateRequest) {
+	tm.certificates.EXPECT().Get(testRotateOldID).Return(testRotateOld, nil)
+	tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{
+		testRotateLB,
+		{LoadBalancer: &godo.LoadBalancer{ID: "lb-2", Name: "other"}},
+	}, nil)
+	tm.cdns.EXPECT().List().Return([]do.CDN{testRotateCDN, {CDN: &godo.CDN{ID: "cdn-2"}}}, nil)
+	tm.certificates.EXPECT().Create(r).Return(testRotateNew, nil)
+}
+
+func TestCertificateRotate(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withCertificateNow(t)
+		expectRotateUsers(tm, setRotateFlags(t, config))
+
+		lbReq := loadBalancerSpec(testRotateLB.LoadBalancer)
+		lbReq.ForwardingRules[1].CertificateID = testRotateNewID
+		tm.loadBalancers.EXPECT().Update("lb-1", lbReq).Return(&testRotateLB, nil)
+		tm.cdns.EXPECT().UpdateCustomDomain("cdn-1", &godo.CDNUpdateCustomDomainRequest{
+			CustomDomain:  "static.example.com",
+			CertificateID: testRotateNewID,
+		}).Return(&testRotateCDN, nil)
+		tm.certificates.EXPECT().Delete(testRotateOldID).Return(nil)
+
+		err := RunCertificateRotate(config)
+		assert.NoError(t, err)
+		assert.Equal(t, testRotateOldID, testRotateLB.ForwardingRules[1].CertificateID)
+	})
+}
+
+func TestCertificateRotateGlobalLoadBalancer(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withCertificateNow(t)
+		r := setRotateFlags(t, config)
+
+		glb := do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{
+			ID:   "glb-1",
+			Name: "global-web",
+			Type: "GLOBAL",
+			Domains: []*godo.LBDomain{
+				{Name: "example.com", IsManaged: true},
+				{Name: "www.example.org", CertificateID: testRotateOldID},
+			},
+		}}
+		tm.certificates.EXPECT().Get(testRotateOldID).Return(testRotateOld, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{glb}, nil)
+		tm.cdns.EXPECT().List().Return(nil, nil)
+		tm.certificates.EXPECT().Create(r).Return(testRotateNew, nil)
+
+		lbReq := loadBalancerSpec(glb.LoadBalancer)
+		lbReq.Domains[1].CertificateID = testRotateNewID
+		tm.loadBalancers.EXPECT().Update("glb-1", lbReq).Return(&glb, nil)
+		tm.certificates.EXPECT().Delete(testRotateOldID).Return(nil)
+
+		err := RunCertificateRotate(config)
+		assert.NoError(t, err)
+		assert.Equal(t, testRotateOldID, glb.Domains[1].CertificateID)
+	})
+}
+
+func TestCertificateRotateUpdateFails(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withCertificateNow(t)
+		expectRotateUsers(tm, setRotateFlags(t, config))
+
+		tm.loadBalancers.EXPECT().Update("lb-1", gomock.Any()).Return(nil, errors.New("lb is busy"))
+		tm.cdns.EXPECT().UpdateCustomDomain("cdn-1", gomock.Any()).Return(&testRotateCDN, nil)
+
+		err := RunCertificateRotate(config)
+		assert.EqualError(t, err, "failed to update load balancer web: lb is busy\n"+
+			"certificate web-cert was not deleted; update the remaining resources to use certificate "+testRotateNewID+" and then delete it")
+	})
+}
+
+func TestCertificateRotateLetsEncrypt(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.certificates.EXPECT().Get(testRotateOldID).Return(&do.Certificate{Certificate: &godo.Certificate{
+			ID: testRotateOldID, Name: "le-cert", Type: "lets_encrypt",
+		}}, nil)
+
+		setRotateFlags(t, config)
+
+		err := RunCertificateRotate(config)
+		assert.EqualError(t, err, "certificate le-cert is a Let's Encrypt certificate, which is renewed automatically")
+	})
+}
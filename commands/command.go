@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/digitalocean/doctl"
@@ -100,6 +101,9 @@ func cmdBuilderWithInit(parent *Command, cr CmdRunner, cliText, shortdesc string
 		)
 		checkErr(err)
 
+		err = c.appendArgsFromFile(os.Stdin)
+		checkErr(err)
+
 		err = cr(c)
 		checkErr(err)
 	}
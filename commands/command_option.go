@@ -43,6 +43,14 @@ func hiddenCmd() cmdOption {
 	}
 }
 
+// fromFileOpt adds the --from-file flag to a command that takes resource IDs
+// or names as arguments.
+func fromFileOpt() cmdOption {
+	return func(c *Command) {
+		addFromFileFlag(c)
+	}
+}
+
 // overrideCmdNS specifies a namespace to use in config overriding the
 // normal usage of the parent command's name. This is useful in cases
 // where deeply nested subcommands have conflicting names. See uptime_alerts.go
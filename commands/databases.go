@@ -65,9 +65,9 @@ func Databases() *Command {
 
 	cmdDatabaseList := CmdBuilder(cmd, RunDatabaseList, "list", "List your database clusters", `Retrieves a list of database clusters and their following details:`+clusterDetails, Writer, aliasOpt("ls"), displayerType(&displayers.Databases{}))
 	cmdDatabaseList.Example = `The following example lists all database associated with your account and uses the ` + "`" + `--format` + "`" + ` flag to return only the ID, engine, and engine version of each database: doctl databases list --format ID,Engine,Version`
-	cmdDatabaseGet := CmdBuilder(cmd, RunDatabaseGet, "get <database-cluster-id>", "Get details for a database cluster", `Retrieves the following details about the specified database cluster: `+clusterDetails+`
+	cmdDatabaseGet := CmdBuilder(cmd, RunDatabaseGet, "get <database-cluster-id|name>", "Get details for a database cluster", `Retrieves the following details about the specified database cluster: `+clusterDetails+`
 - A connection string for the database cluster
-- The date and time when the database cluster was created`+databaseListDetails, Writer, aliasOpt("g"), displayerType(&displayers.Databases{}))
+- The date and time when the database cluster was created`+databaseListDetails, Writer, aliasOpt("g"), displayerType(&displayers.Databases{}), fromFileOpt())
 	cmdDatabaseGet.Example = `The following example retrieves the details for a database cluster with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to return only the database's ID, engine, and engine version: doctl databases get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	nodeSizeDetails := "The size of the nodes in the database cluster, for example `db-s-1vcpu-1gb` indicates a 1 CPU, 1GB node. For a list of available size slugs, visit: https://docs.digitalocean.com/reference/api/api-reference/#tag/Databases"
@@ -76,7 +76,7 @@ func Databases() *Command {
 	cmdDatabaseCreate := CmdBuilder(cmd, RunDatabaseCreate, "create <name>", "Create a database cluster", `Creates a database cluster with the specified name.
 
 You can customize the configuration using the listed flags, all of which are optional. Without any flags set, the command creates a single-node, single-CPU PostgreSQL database cluster.`, Writer,
-		aliasOpt("c"))
+		aliasOpt("c"), fromFileOpt())
 	AddIntFlag(cmdDatabaseCreate, doctl.ArgDatabaseNumNodes, "", defaultDatabaseNodeCount, nodeNumberDetails)
 	AddStringFlag(cmdDatabaseCreate, doctl.ArgRegionSlug, "", defaultDatabaseRegion, "The data center region where the database cluster resides, such as `nyc1` or `sfo2`.")
 	AddStringFlag(cmdDatabaseCreate, doctl.ArgSizeSlug, "", defaultDatabaseNodeSize, nodeSizeDetails)
@@ -90,14 +90,14 @@ You can customize the configuration using the listed flags, all of which are opt
 	AddStringSliceFlag(cmdDatabaseCreate, doctl.ArgTag, "", nil, "A comma-separated list of tags to apply to the database cluster.")
 	cmdDatabaseCreate.Example = `The following example creates a database cluster named ` + "`" + `example-database` + "`" + ` in the ` + "`" + `nyc1` + "`" + ` region with a single  1 GB node: doctl databases create example-database --region nyc1 --size db-s-1vcpu-1gb --num-nodes 1`
 
-	cmdDatabaseDelete := CmdBuilder(cmd, RunDatabaseDelete, "delete <database-cluster-id>", "Delete a database cluster", `Deletes the database cluster with the specified ID.
+	cmdDatabaseDelete := CmdBuilder(cmd, RunDatabaseDelete, "delete <database-cluster-id|name>", "Delete a database cluster", `Deletes the database cluster with the specified ID.
 
 To retrieve a list of your database clusters and their IDs, use `+"`"+`doctl databases list`+"`"+`.`, Writer,
-		aliasOpt("rm"))
+		aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdDatabaseDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete the database cluster without a confirmation prompt")
 	cmdDatabaseDelete.Example = `The following example deletes the database cluster with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl databases delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
-	cmdDatabaseGetConn := CmdBuilder(cmd, RunDatabaseConnectionGet, "connection <database-cluster-id>", "Retrieve connection details for a database cluster", `Retrieves the following connection details for a database cluster:
+	cmdDatabaseGetConn := CmdBuilder(cmd, RunDatabaseConnectionGet, "connection <database-cluster-id|name>", "Retrieve connection details for a database cluster", `Retrieves the following connection details for a database cluster:
 
 - A connection string for the database cluster
 - The default database name
@@ -108,17 +108,25 @@ To retrieve a list of your database clusters and their IDs, use `+"`"+`doctl dat
 - A boolean value indicating if the connection should be made over SSL
 
 While you can use these connection details, you can manually update the connection string's parameters to change how you connect to the database, such using a private hostname, custom username, or a different database.`, Writer,
-		aliasOpt("conn"), displayerType(&displayers.DatabaseConnection{}))
+		aliasOpt("conn"), displayerType(&displayers.DatabaseConnection{}), fromFileOpt())
 	AddBoolFlag(cmdDatabaseGetConn, doctl.ArgDatabasePrivateConnectionBool, "", false, "Returns connection details that use the database's VPC network connection.")
 	cmdDatabaseGetConn.Example = `The following example retrieves the connection details for a database cluster with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl databases connection f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
-	cmdDatabaseListBackups := CmdBuilder(cmd, RunDatabaseBackupsList, "backups <database-cluster-id>", "List database cluster backups", `Retrieves a list of backups created for the specified database cluster.
+	cmdDatabaseTunnel := CmdBuilder(cmd, RunDatabaseTunnel, "tunnel <database-cluster-id|name>", "Forward a local port to a database cluster's private network address", `Forwards a local port to the private network address of a database cluster, through a Droplet in the same VPC network, so that you can connect to the cluster from your computer without adding it to the cluster's trusted sources.
+
+The Droplet to connect through is set with the `+"`"+`--droplet`+"`"+` flag, or is the first Droplet with a public IP address in the cluster's VPC network. The local port is the cluster's port unless `+"`"+`--local-port`+"`"+` is set. The port is forwarded until the command is stopped.`, Writer, fromFileOpt())
+	AddStringFlag(cmdDatabaseTunnel, doctl.ArgDatabaseTunnelDroplet, "", "", "The ID or name of a Droplet in the cluster's VPC network to connect through")
+	AddIntFlag(cmdDatabaseTunnel, doctl.ArgDatabaseTunnelLocalPort, "", 0, "The local port to listen on. Defaults to the cluster's port")
+	addSSHConnectionFlags(cmdDatabaseTunnel, sshDefaultKeyPath())
+	cmdDatabaseTunnel.Example = `The following example forwards a local port to a database cluster with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` through a Droplet named ` + "`" + `bastion` + "`" + `: doctl databases tunnel f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --droplet bastion`
+
+	cmdDatabaseListBackups := CmdBuilder(cmd, RunDatabaseBackupsList, "backups <database-cluster-id|name>", "List database cluster backups", `Retrieves a list of backups created for the specified database cluster.
 
 The list contains the size in GB, and the date and time the backup was created.`, Writer,
-		aliasOpt("bu"), displayerType(&displayers.DatabaseBackups{}))
+		aliasOpt("bu"), displayerType(&displayers.DatabaseBackups{}), fromFileOpt())
 	cmdDatabaseListBackups.Example = `The following example retrieves a list of backups for a database cluster with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl databases backups f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
-	cmdDatabaseResize := CmdBuilder(cmd, RunDatabaseResize, "resize <database-cluster-id>", "Resize a database cluster", `Resizes the specified database cluster.
+	cmdDatabaseResize := CmdBuilder(cmd, RunDatabaseResize, "resize <database-cluster-id|name>", "Resize a database cluster", `Resizes the specified database cluster.
 
 You must specify the desired number of nodes and size of the nodes. For example:
 
@@ -127,18 +135,18 @@ You must specify the desired number of nodes and size of the nodes. For example:
 Database nodes cannot be resized to smaller sizes due to the risk of data loss.
 
 For PostgreSQL and MySQL clusters, you can also provide a disk size in MiB to scale the storage up to 15 TB, depending on your plan. You cannot reduce the storage size of a cluster.`, Writer,
-		aliasOpt("rs"))
+		aliasOpt("rs"), fromFileOpt())
 	AddIntFlag(cmdDatabaseResize, doctl.ArgDatabaseNumNodes, "", 0, nodeNumberDetails, requiredOpt())
 	AddStringFlag(cmdDatabaseResize, doctl.ArgSizeSlug, "", "", nodeSizeDetails, requiredOpt())
 	AddIntFlag(cmdDatabaseResize, doctl.ArgDatabaseStorageSizeMib, "", 0, storageSizeMiBDetails)
 	cmdDatabaseResize.Example = `The following example resizes a PostgreSQL or MySQL database to have two nodes, 16 vCPUs, 64 GB of memory, and 2048 GiB of storage space: doctl databases resize ca9f591d-9999-5555-a0ef-1c02d1d1e352 --num-nodes 2 --size db-s-16vcpu-64gb --storage-size-mib 2048000`
 
-	cmdDatabaseMigrate := CmdBuilder(cmd, RunDatabaseMigrate, "migrate <database-cluster-id>", "Migrate a database cluster to a new region", `Migrates the specified database cluster to a new region.`, Writer,
-		aliasOpt("m"))
+	cmdDatabaseMigrate := CmdBuilder(cmd, RunDatabaseMigrate, "migrate <database-cluster-id|name>", "Migrate a database cluster to a new region", `Migrates the specified database cluster to a new region.`, Writer,
+		aliasOpt("m"), fromFileOpt())
 	AddStringFlag(cmdDatabaseMigrate, doctl.ArgRegionSlug, "", "", "The region to which the database cluster should be migrated, such as `sfo2` or `nyc3`.", requiredOpt())
 	AddStringFlag(cmdDatabaseMigrate, doctl.ArgPrivateNetworkUUID, "", "", "The UUID of a VPC network to create the database cluster in. The command uses the region's default VPC network if not specified.")
 
-	cmdDatabaseFork := CmdBuilder(cmd, RunDatabaseFork, "fork <name>", "Create a new database cluster by forking an existing database cluster.", `Creates a new database cluster from an existing cluster. The forked database contains all of the data from the original database at the time the fork is created.`, Writer, aliasOpt("f"))
+	cmdDatabaseFork := CmdBuilder(cmd, RunDatabaseFork, "fork <name>", "Create a new database cluster by forking an existing database cluster.", `Creates a new database cluster from an existing cluster. The forked database contains all of the data from the original database at the time the fork is created.`, Writer, aliasOpt("f"), fromFileOpt())
 	AddStringFlag(cmdDatabaseFork, doctl.ArgDatabaseRestoreFromClusterID, "", "", "The ID of an existing database cluster from which the new database will be forked from", requiredOpt())
 	AddStringFlag(cmdDatabaseFork, doctl.ArgDatabaseRestoreFromTimestamp, "", "", "The timestamp of an existing database cluster backup in UTC combined date and time format (2006-01-02 15:04:05 +0000 UTC). The most recent backup is used if excluded.")
 	AddBoolFlag(cmdDatabaseFork, doctl.ArgCommandWait, "", false, "A boolean that specifies whether to wait for a database to complete before returning control to the terminal")
@@ -172,13 +180,22 @@ func RunDatabaseList(c *CmdConfig) error {
 	return displayDatabases(c, true, dbs...)
 }
 
+// databaseClusterID resolves the first argument, which may be a database
+// cluster's ID, name, or URN, to the cluster's ID.
+func databaseClusterID(c *CmdConfig) (string, error) {
+	return do.ResolveDatabaseID(c.Databases(), c.Args[0])
+}
+
 // RunDatabaseGet returns an individual database cluster
 func RunDatabaseGet(c *CmdConfig) error {
 	if len(c.Args) == 0 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	db, err := c.Databases().Get(id)
 	if err != nil {
 		return err
@@ -424,7 +441,10 @@ func RunDatabaseDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("database cluster", 1) == nil {
-		id := c.Args[0]
+		id, err := databaseClusterID(c)
+		if err != nil {
+			return err
+		}
 		return c.Databases().Delete(id)
 	}
 
@@ -445,7 +465,10 @@ func RunDatabaseConnectionGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	private, err := c.Doit.GetBool(c.NS, doctl.ArgDatabasePrivateConnectionBool)
 	if err != nil {
 		return err
@@ -470,7 +493,10 @@ func RunDatabaseBackupsList(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	backups, err := c.Databases().ListBackups(id)
 	if err != nil {
 		return err
@@ -490,7 +516,10 @@ func RunDatabaseResize(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	r, err := buildDatabaseResizeRequestFromArgs(c)
 	if err != nil {
@@ -530,7 +559,10 @@ func RunDatabaseMigrate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	r, err := buildDatabaseMigrateRequestFromArgs(c)
 	if err != nil {
@@ -570,7 +602,7 @@ Maintenance windows are hour-long blocks of time during which DigitalOcean perfo
 		},
 	}
 
-	cmdMaintenanceGet := CmdBuilder(cmd, RunDatabaseMaintenanceGet, "get <database-cluster-id>",
+	cmdMaintenanceGet := CmdBuilder(cmd, RunDatabaseMaintenanceGet, "get <database-cluster-id|name>",
 		"Retrieve details about a database cluster's maintenance windows", `Retrieves the following information on currently-scheduled maintenance windows for the specified database cluster:
 
 - The day of the week the maintenance window occurs
@@ -578,17 +610,17 @@ Maintenance windows are hour-long blocks of time during which DigitalOcean perfo
 - A boolean representing whether maintenance updates are currently pending
 
 To see a list of your databases and their IDs, run `+"`"+`doctl databases list`+"`"+`.`, Writer, aliasOpt("g"),
-		displayerType(&displayers.DatabaseMaintenanceWindow{}))
+		displayerType(&displayers.DatabaseMaintenanceWindow{}), fromFileOpt())
 	cmdMaintenanceGet.Example = `The following example retrieves the maintenance window for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases maintenance-window ca9f591d-f38h-5555-a0ef-1c02d1d1e35`
 
 	cmdDatabaseCreate := CmdBuilder(cmd, RunDatabaseMaintenanceUpdate,
-		"update <database-cluster-id>", "Update the maintenance window for a database cluster", `Updates the maintenance window for the specified database cluster.
+		"update <database-cluster-id|name>", "Update the maintenance window for a database cluster", `Updates the maintenance window for the specified database cluster.
 
 Maintenance windows are hour-long blocks of time during which DigitalOcean performs automatic maintenance on databases every week. During this time, health checks, security updates, version upgrades, and more are performed.
 
 To change the maintenance window for your database cluster, specify a day of the week and an hour of that day during which you would prefer such maintenance would occur.
 
-To see a list of your databases and their IDs, run `+"`"+`doctl databases list`+"`"+`.`, Writer, aliasOpt("u"))
+To see a list of your databases and their IDs, run `+"`"+`doctl databases list`+"`"+`.`, Writer, aliasOpt("u"), fromFileOpt())
 	AddStringFlag(cmdDatabaseCreate, doctl.ArgDatabaseMaintenanceDay, "", "",
 		"The day of the week the maintenance window occurs, for example: 'tuesday')", requiredOpt())
 	AddStringFlag(cmdDatabaseCreate, doctl.ArgDatabaseMaintenanceHour, "", "",
@@ -606,7 +638,10 @@ func RunDatabaseMaintenanceGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	window, err := c.Databases().GetMaintenance(id)
 	if err != nil {
@@ -627,7 +662,10 @@ func RunDatabaseMaintenanceUpdate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	r, err := buildDatabaseUpdateMaintenanceRequestFromArgs(c)
 	if err != nil {
 		return err
@@ -675,35 +713,35 @@ Database user accounts are scoped to one database cluster, to which they have fu
 Primary user accounts are created by DigitalOcean at database cluster creation time and can't be deleted. You can create additional users with a "normal" role. Both have administrative privileges on the database cluster.
 
 To retrieve a list of your databases and their IDs, call ` + "`" + `doctl databases list` + "`" + `.`
-	cmdDatabaseUserList := CmdBuilder(cmd, RunDatabaseUserList, "list <database-cluster-id>", "Retrieve list of database users",
-		`Retrieves a list of users for the specified database with the following details:`+userDetailsDesc, Writer, aliasOpt("ls"), displayerType(&displayers.DatabaseUsers{}))
+	cmdDatabaseUserList := CmdBuilder(cmd, RunDatabaseUserList, "list <database-cluster-id|name>", "Retrieve list of database users",
+		`Retrieves a list of users for the specified database with the following details:`+userDetailsDesc, Writer, aliasOpt("ls"), displayerType(&displayers.DatabaseUsers{}), fromFileOpt())
 	cmdDatabaseUserList.Example = `The following example retrieves a list of users for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + ` and uses the ` + "`" + `--format flag` + "`" + ` to return only the name and role for each each user: doctl databases user list ca9f591d-f38h-5555-a0ef-1c02d1d1e35 --format Name,Role`
 
-	cmdDatabaseUserGet := CmdBuilder(cmd, RunDatabaseUserGet, "get <database-cluster-id> <user-name>",
+	cmdDatabaseUserGet := CmdBuilder(cmd, RunDatabaseUserGet, "get <database-cluster-id|name> <user-name>",
 		"Retrieve details about a database user", `Retrieves the following details about the specified user:`+userDetailsDesc+`
 
 To retrieve a list of database users for a database cluster, call `+"`"+`doctl databases user list <database-cluster-id>`+"`"+`.`, Writer, aliasOpt("g"),
-		displayerType(&displayers.DatabaseUsers{}))
+		displayerType(&displayers.DatabaseUsers{}), fromFileOpt())
 	cmdDatabaseUserGet.Example = `The following example retrieves the details for the user with the username ` + "`" + `example-user` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to return only the user's name and role: doctl databases user get ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-user --format Name,Role`
 
-	cmdDatabaseUserCreate := CmdBuilder(cmd, RunDatabaseUserCreate, "create <database-cluster-id> <user-name>",
+	cmdDatabaseUserCreate := CmdBuilder(cmd, RunDatabaseUserCreate, "create <database-cluster-id|name> <user-name>",
 		"Create a database user", `Creates a new user for a database. New users are given a role of `+"`"+`normal`+"`"+` and are given an automatically-generated password.
 
-To retrieve a list of your databases and their IDs, call `+"`"+`doctl databases list`+"`"+`.`, Writer, aliasOpt("c"))
+To retrieve a list of your databases and their IDs, call `+"`"+`doctl databases list`+"`"+`.`, Writer, aliasOpt("c"), fromFileOpt())
 
 	AddStringFlag(cmdDatabaseUserCreate, doctl.ArgDatabaseUserMySQLAuthPlugin, "", "",
 		"Sets authorization plugin for a MySQL user. Possible values: `caching_sha2_password` or `mysql_native_password`")
 	AddStringSliceFlag(cmdDatabaseUserCreate, doctl.ArgDatabaseUserKafkaACLs, "", []string{}, databaseKafkaACLsTxt)
 	cmdDatabaseUserCreate.Example = `The following example creates a new user with the username ` + "`" + `example-user` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases user create ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-user`
 
-	cmdDatabaseUserResetAuth := CmdBuilder(cmd, RunDatabaseUserResetAuth, "reset <database-cluster-id> <user-name> <new-auth-mode>",
+	cmdDatabaseUserResetAuth := CmdBuilder(cmd, RunDatabaseUserResetAuth, "reset <database-cluster-id|name> <user-name> <new-auth-mode>",
 		"Resets a user's auth", "Resets the auth password or the MySQL authorization plugin for a given user and returns the user's new credentials. When resetting MySQL auth, valid values for `<new-auth-mode>` are `caching_sha2_password` and `mysql_native_password`.", Writer, aliasOpt("rs"))
 	cmdDatabaseUserResetAuth.Example = `The following example resets the auth plugin for the user with the username ` + "`" + `example-user` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + ` to ` + "`" + `mysql_native_password` + "`" + `: doctl databases user reset ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-user mysql_native_password`
 
 	cmdDatabaseUserDelete := CmdBuilder(cmd, RunDatabaseUserDelete,
-		"delete <database-cluster-id> <user-id>", "Delete a database user", `Deletes the specified database user.
+		"delete <database-cluster-id|name> <user-id>", "Delete a database user", `Deletes the specified database user.
 
-To retrieve a list of your databases and their IDs, call `+"`"+`doctl databases list`+"`"+`.`, Writer, aliasOpt("rm"))
+To retrieve a list of your databases and their IDs, call `+"`"+`doctl databases list`+"`"+`.`, Writer, aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdDatabaseUserDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete the user without a confirmation prompt")
 	cmdDatabaseUserDelete.Example = `The following example deletes the user with the username ` + "`" + `example-user` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases user delete ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-user`
 
@@ -718,7 +756,10 @@ func RunDatabaseUserList(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	users, err := c.Databases().ListUsers(id)
 	if err != nil {
@@ -734,7 +775,10 @@ func RunDatabaseUserGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	userID := c.Args[1]
 
 	user, err := c.Databases().GetUser(databaseID, userID)
@@ -751,10 +795,11 @@ func RunDatabaseUserCreate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	var (
-		databaseID = c.Args[0]
-		userName   = c.Args[1]
-	)
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
+	userName := c.Args[1]
 
 	req := &godo.DatabaseCreateUserRequest{Name: userName}
 
@@ -813,10 +858,11 @@ func RunDatabaseUserResetAuth(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	var (
-		databaseID = c.Args[0]
-		userName   = c.Args[1]
-	)
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
+	userName := c.Args[1]
 
 	database, err := c.Databases().Get(databaseID)
 
@@ -859,7 +905,10 @@ func RunDatabaseUserDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("database user", 1) == nil {
-		databaseID := c.Args[0]
+		databaseID, err := databaseClusterID(c)
+		if err != nil {
+			return err
+		}
 		userID := c.Args[1]
 		return c.Databases().DeleteUser(databaseID, userID)
 	}
@@ -1074,17 +1123,17 @@ You can get a list of existing database clusters and their IDs by calling:
 
 	doctl databases list`
 
-	cmdDatabasePoolList := CmdBuilder(cmd, RunDatabasePoolList, "list <database-cluster-id>", "List connection pools for a database cluster", `Lists the existing connection pools for the specified database. The command returns the following details about each connection pool:`+connectionPoolDetails,
-		Writer, aliasOpt("ls"), displayerType(&displayers.DatabasePools{}))
+	cmdDatabasePoolList := CmdBuilder(cmd, RunDatabasePoolList, "list <database-cluster-id|name>", "List connection pools for a database cluster", `Lists the existing connection pools for the specified database. The command returns the following details about each connection pool:`+connectionPoolDetails,
+		Writer, aliasOpt("ls"), displayerType(&displayers.DatabasePools{}), fromFileOpt())
 	cmdDatabasePoolList.Example = `The following example lists the connection pools for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to return only each pool's name and connection string: doctl databases pool list ca9f591d-f38h-5555-a0ef-1c02d1d1e35 --format Name,URI`
 
-	cmdDatabasePoolGet := CmdBuilder(cmd, RunDatabasePoolGet, "get <database-cluster-id> <pool-name>",
+	cmdDatabasePoolGet := CmdBuilder(cmd, RunDatabasePoolGet, "get <database-cluster-id|name> <pool-name>",
 		"Retrieve information about a database connection pool", `This command retrieves the following information about the specified connection pool for the specified database cluster:`+connectionPoolDetails+getPoolDetails, Writer, aliasOpt("g"),
-		displayerType(&displayers.DatabasePools{}))
+		displayerType(&displayers.DatabasePools{}), fromFileOpt())
 	cmdDatabasePoolGet.Example = `The following example retrieves the details for a connection pool named ` + "`" + `example-pool` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to return only the pool's name and connection string: doctl databases pool get ca9f591d-fb58-5555-a0ef-1c02d1d1e352 example-pool --format Name,URI`
 
 	cmdDatabasePoolCreate := CmdBuilder(cmd, RunDatabasePoolCreate,
-		"create <database-cluster-id> <pool-name>", "Create a connection pool for a database cluster", `Creates a connection pool for the specified database cluster.
+		"create <database-cluster-id|name> <pool-name>", "Create a connection pool for a database cluster", `Creates a connection pool for the specified database cluster.
 
 In addition to the pool's name, you must also use flags to specify the pool's target database, its size, and a database user that the pool uses to authenticate. If you do not specify a user, the field is set to inbound user. An example call would be:
 
@@ -1096,7 +1145,7 @@ There’s no perfect formula to determine how large your pool should be, but the
 - A pool that’s much smaller than the number of clients communicating with the database can act as a bottleneck, reducing the rate when your database receives and responds to transactions.
 
 We recommend starting with a pool size of about half your available connections and adjusting later based on performance. If you see slow query responses, check the CPU usage on the database’s Overview tab. We recommend decreasing your pool size if CPU usage is high, and increasing your pool size if it’s low.`+getPoolDetails, Writer,
-		aliasOpt("c"))
+		aliasOpt("c"), fromFileOpt())
 	AddStringFlag(cmdDatabasePoolCreate, doctl.ArgDatabasePoolMode, "",
 		"transaction", "The pool mode for the connection pool, such as `session`, `transaction`, and `statement`")
 	AddIntFlag(cmdDatabasePoolCreate, doctl.ArgSizeSlug, "", 0, "pool size",
@@ -1108,8 +1157,9 @@ We recommend starting with a pool size of about half your available connections
 	cmdDatabasePoolCreate.Example = `The following example creates a connection pool named ` + "`" + `example-pool` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `. The command uses the ` + "`" + `--size` + "`" + ` flag to set the pool size to 10 and sets the user to the database's default user: doctl databases pool create ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-pool --size 10`
 
 	cmdDatabasePoolUpdate := CmdBuilder(cmd, RunDatabasePoolUpdate,
-		"update <database-cluster-id> <pool-name>", "Update a connection pool for a database", `Updates the specified connection pool for the specified database cluster.`+getPoolDetails, Writer,
+		"update <database-cluster-id|name> <pool-name>", "Update a connection pool for a database", `Updates the specified connection pool for the specified database cluster.`+getPoolDetails, Writer,
 		aliasOpt("u"),
+		fromFileOpt(),
 	)
 	AddStringFlag(cmdDatabasePoolUpdate, doctl.ArgDatabasePoolMode, "",
 		"transaction", "The pool mode for the connection pool, such as `session`, `transaction`, and `statement`")
@@ -1121,8 +1171,8 @@ We recommend starting with a pool size of about half your available connections
 	cmdDatabasePoolUpdate.Example = `The following example updates a connection pool named ` + "`" + `example-pool` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `. The command uses the ` + "`" + `--size` + "`" + ` flag to set the pool size to 10 and sets the user to the database's default user: doctl databases pool update ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-pool --size 10`
 
 	cmdDatabasePoolDelete := CmdBuilder(cmd, RunDatabasePoolDelete,
-		"delete <database-cluster-id> <pool-name>", "Delete a connection pool for a database", `Deletes the specified connection pool for the specified database cluster.`+getPoolDetails, Writer,
-		aliasOpt("rm"))
+		"delete <database-cluster-id|name> <pool-name>", "Delete a connection pool for a database", `Deletes the specified connection pool for the specified database cluster.`+getPoolDetails, Writer,
+		aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdDatabasePoolDelete, doctl.ArgForce, doctl.ArgShortForce,
 		false, "Delete the connection pool without confirmation prompt")
 	cmdDatabasePoolDelete.Example = `The following example deletes a connection pool named ` + "`" + `example-pool` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases pool delete ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-pool`
@@ -1138,7 +1188,10 @@ func RunDatabasePoolList(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	pools, err := c.Databases().ListPools(id)
 	if err != nil {
@@ -1154,7 +1207,10 @@ func RunDatabasePoolGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	poolID := c.Args[1]
 
 	pool, err := c.Databases().GetPool(databaseID, poolID)
@@ -1171,7 +1227,10 @@ func RunDatabasePoolCreate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	r, err := buildDatabaseCreatePoolRequestFromArgs(c)
 	if err != nil {
 		return err
@@ -1221,7 +1280,10 @@ func RunDatabasePoolUpdate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	poolName := c.Args[1]
 
 	pool, err := c.Databases().GetPool(databaseID, poolName)
@@ -1283,7 +1345,10 @@ func RunDatabasePoolDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("database pool", 1) == nil {
-		databaseID := c.Args[0]
+		databaseID, err := databaseClusterID(c)
+		if err != nil {
+			return err
+		}
 		poolID := c.Args[1]
 		return c.Databases().DeletePool(databaseID, poolID)
 	}
@@ -1315,20 +1380,20 @@ You can get a list of existing databases that are hosted within a cluster by cal
 		},
 	}
 
-	cmdDatabaseDBList := CmdBuilder(cmd, RunDatabaseDBList, "list <database-cluster-id>", "Retrieve a list of databases within a cluster", "Retrieves a list of databases being hosted in the specified database cluster."+getClusterList, Writer,
-		aliasOpt("ls"), displayerType(&displayers.DatabaseDBs{}))
+	cmdDatabaseDBList := CmdBuilder(cmd, RunDatabaseDBList, "list <database-cluster-id|name>", "Retrieve a list of databases within a cluster", "Retrieves a list of databases being hosted in the specified database cluster."+getClusterList, Writer,
+		aliasOpt("ls"), displayerType(&displayers.DatabaseDBs{}), fromFileOpt())
 	cmdDatabaseDBList.Example = `The following example retrieves a list of databases in a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases db list ca9f591d-f38h-5555-a0ef-1c02d1d1e35`
 
-	cmdDatabaseDBGet := CmdBuilder(cmd, RunDatabaseDBGet, "get <database-cluster-id> <database-name>", "Retrieve the name of a database within a cluster", "Retrieves the name of the specified database hosted in the specified database cluster."+getClusterList+getDBList,
-		Writer, aliasOpt("g"), displayerType(&displayers.DatabaseDBs{}))
+	cmdDatabaseDBGet := CmdBuilder(cmd, RunDatabaseDBGet, "get <database-cluster-id|name> <database-name>", "Retrieve the name of a database within a cluster", "Retrieves the name of the specified database hosted in the specified database cluster."+getClusterList+getDBList,
+		Writer, aliasOpt("g"), displayerType(&displayers.DatabaseDBs{}), fromFileOpt())
 	cmdDatabaseDBGet.Example = `The following example retrieves the name of a database in a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + ` and the name ` + "`" + `example-db` + "`" + `: doctl databases db get ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-db`
 
-	cmdDatabaseDBCreate := CmdBuilder(cmd, RunDatabaseDBCreate, "create <database-cluster-id> <database-name>",
-		"Create a database within a cluster", "Creates a database with the specified name in the specified database cluster."+getClusterList, Writer, aliasOpt("c"))
+	cmdDatabaseDBCreate := CmdBuilder(cmd, RunDatabaseDBCreate, "create <database-cluster-id|name> <database-name>",
+		"Create a database within a cluster", "Creates a database with the specified name in the specified database cluster."+getClusterList, Writer, aliasOpt("c"), fromFileOpt())
 	cmdDatabaseDBCreate.Example = `The following example creates a database named ` + "`" + `example-db` + "`" + ` in a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases db create ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-db`
 
 	cmdDatabaseDBDelete := CmdBuilder(cmd, RunDatabaseDBDelete,
-		"delete <database-cluster-id> <database-name>", "Delete the specified database from the cluster", "Deletes the specified database from the specified database cluster."+getClusterList+getDBList, Writer, aliasOpt("rm"))
+		"delete <database-cluster-id|name> <database-name>", "Delete the specified database from the cluster", "Deletes the specified database from the specified database cluster."+getClusterList+getDBList, Writer, aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdDatabaseDBDelete, doctl.ArgForce, doctl.ArgShortForce,
 		false, "Deletes the database without a confirmation prompt")
 	cmdDatabaseDBDelete.Example = `The following example deletes a database named ` + "`" + `example-db` + "`" + ` in a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases db delete ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-db`
@@ -1344,7 +1409,10 @@ func RunDatabaseDBList(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	dbs, err := c.Databases().ListDBs(id)
 	if err != nil {
@@ -1360,7 +1428,10 @@ func RunDatabaseDBGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	dbID := c.Args[1]
 
 	db, err := c.Databases().GetDB(databaseID, dbID)
@@ -1377,7 +1448,10 @@ func RunDatabaseDBCreate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	req := &godo.DatabaseCreateDBRequest{Name: c.Args[1]}
 
 	db, err := c.Databases().CreateDB(databaseID, req)
@@ -1400,7 +1474,10 @@ func RunDatabaseDBDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("database", 1) == nil {
-		databaseID := c.Args[0]
+		databaseID, err := databaseClusterID(c)
+		if err != nil {
+			return err
+		}
 		dbID := c.Args[1]
 		return c.Databases().DeleteDB(databaseID, dbID)
 	}
@@ -1435,12 +1512,12 @@ This command requires that you pass in the replica's name, which you can retriev
 - The region where the database cluster is located, such as ` + "`" + `nyc3` + "`" + `, ` + "`" + `sfo2` + "`" + `
 - The replica's status. Possible values: ` + "`" + `forking` + "`" + ` and ` + "`" + `active` + "`" + `
 `
-	cmdDatabaseReplicaList := CmdBuilder(cmd, RunDatabaseReplicaList, "list <database-cluster-id>", "Retrieve list of read-only database replicas", `Lists the following details for read-only replicas for the specified database cluster.`+replicaDetails+databaseListDetails,
+	cmdDatabaseReplicaList := CmdBuilder(cmd, RunDatabaseReplicaList, "list <database-cluster-id|name>", "Retrieve list of read-only database replicas", `Lists the following details for read-only replicas for the specified database cluster.`+replicaDetails+databaseListDetails,
 		Writer, aliasOpt("ls"),
-		displayerType(&displayers.DatabaseReplicas{}))
+		displayerType(&displayers.DatabaseReplicas{}), fromFileOpt())
 	cmdDatabaseReplicaList.Example = `The following example retrieves a list of read-only replicas for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to return only the ID and URI for each replica: doctl databases replica list ca9f591d-f38h-5555-a0ef-1c02d1d1e35 --format ID,URI`
 
-	DatabaseReplicaGet := CmdBuilder(cmd, RunDatabaseReplicaGet, "get <database-cluster-id> <replica-name>", "Retrieve information about a read-only database replica",
+	DatabaseReplicaGet := CmdBuilder(cmd, RunDatabaseReplicaGet, "get <database-cluster-id|name> <replica-name>", "Retrieve information about a read-only database replica",
 		`Gets the following details for the specified read-only replica of the specified database cluster:
 
 - The name of the replica
@@ -1449,12 +1526,12 @@ This command requires that you pass in the replica's name, which you can retriev
 - The status of the replica. Possible values: `+"`"+`creating`+"`"+`, `+"`"+`forking`+"`"+`, `+"`"+`active`+"`"+`
 - When the read-only replica was created, in ISO8601 date/time format`+howToGetReplica+databaseListDetails,
 		Writer, aliasOpt("g"),
-		displayerType(&displayers.DatabaseReplicas{}))
+		displayerType(&displayers.DatabaseReplicas{}), fromFileOpt())
 	DatabaseReplicaGet.Example = `The following example retrieves the details for a read-only replica named ` + "`" + `example-replica` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases replica get ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-replica`
 
 	cmdDatabaseReplicaCreate := CmdBuilder(cmd, RunDatabaseReplicaCreate,
-		"create <database-cluster-id> <replica-name>", "Create a read-only database replica", `Creates a read-only database replica for the specified database cluster, giving it the specified name.`+databaseListDetails,
-		Writer, aliasOpt("c"))
+		"create <database-cluster-id|name> <replica-name>", "Create a read-only database replica", `Creates a read-only database replica for the specified database cluster, giving it the specified name.`+databaseListDetails,
+		Writer, aliasOpt("c"), fromFileOpt())
 	AddStringFlag(cmdDatabaseReplicaCreate, doctl.ArgRegionSlug, "",
 		defaultDatabaseRegion, `Specifies the region in which to create the replica, such as `+"`"+`nyc3`+"`"+` or `+"`"+`sfo2`+"`"+`.`)
 	AddStringFlag(cmdDatabaseReplicaCreate, doctl.ArgSizeSlug, "",
@@ -1464,23 +1541,23 @@ This command requires that you pass in the replica's name, which you can retriev
 	cmdDatabaseReplicaCreate.Example = `The following example creates a read-only replica named ` + "`" + `example-replica` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases replica create ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-replica --size db-s-1vcpu-1gb`
 
 	cmdDatabaseReplicaDelete := CmdBuilder(cmd, RunDatabaseReplicaDelete,
-		"delete <database-cluster-id> <replica-name>", "Delete a read-only database replica",
+		"delete <database-cluster-id|name> <replica-name>", "Delete a read-only database replica",
 		`Deletes the specified read-only replica for the specified database cluster.`+howToGetReplica+databaseListDetails,
-		Writer, aliasOpt("rm"))
+		Writer, aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdDatabaseReplicaDelete, doctl.ArgForce, doctl.ArgShortForce,
 		false, "Deletes the replica without a confirmation prompt.")
 	cmdDatabaseReplicaDelete.Example = `The following example deletes a read-only replica named ` + "`" + `example-replica` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases replica delete ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-replica`
 
 	cmdDatabaseReplicaPromote := CmdBuilder(cmd, RunDatabaseReplicaPromote,
-		"promote <database-cluster-id> <replica-name>", "Promote a read-only database replica to become a primary cluster",
+		"promote <database-cluster-id|name> <replica-name>", "Promote a read-only database replica to become a primary cluster",
 		`Promotes a read-only database replica to become its own independent primary cluster. Promoted replicas no longer stay in sync with primary cluster they were forked from.`+howToGetReplica+databaseListDetails,
-		Writer, aliasOpt("p"))
+		Writer, aliasOpt("p"), fromFileOpt())
 	cmdDatabaseReplicaPromote.Example = `The following example promotes a read-only replica named ` + "`" + `example-replica` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases replica promote ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-replica`
 
 	cmdDatabaseReplicaConnectionGet := CmdBuilder(cmd, RunDatabaseReplicaConnectionGet,
-		"connection <database-cluster-id> <replica-name>",
+		"connection <database-cluster-id|name> <replica-name>",
 		"Retrieve information for connecting to a read-only database replica",
-		`Retrieves information for connecting to the specified read-only database replica in the specified database cluster`+howToGetReplica+databaseListDetails, Writer, aliasOpt("conn"))
+		`Retrieves information for connecting to the specified read-only database replica in the specified database cluster`+howToGetReplica+databaseListDetails, Writer, aliasOpt("conn"), fromFileOpt())
 	cmdDatabaseReplicaConnectionGet.Example = `The following example retrieves the connection details for a read-only replica named ` + "`" + `example-replica` + "`" + ` for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases replica connection get ca9f591d-f38h-5555-a0ef-1c02d1d1e35 example-replica`
 
 	return cmd
@@ -1494,7 +1571,10 @@ func RunDatabaseReplicaList(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	replicas, err := c.Databases().ListReplicas(id)
 	if err != nil {
@@ -1510,7 +1590,10 @@ func RunDatabaseReplicaGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	replicaID := c.Args[1]
 
 	replica, err := c.Databases().GetReplica(databaseID, replicaID)
@@ -1527,7 +1610,10 @@ func RunDatabaseReplicaCreate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	r, err := buildDatabaseCreateReplicaRequestFromArgs(c)
 	if err != nil {
 		return err
@@ -1577,7 +1663,10 @@ func RunDatabaseReplicaDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("database replica", 1) == nil {
-		databaseID := c.Args[0]
+		databaseID, err := databaseClusterID(c)
+		if err != nil {
+			return err
+		}
 		replicaID := c.Args[1]
 		return c.Databases().DeleteReplica(databaseID, replicaID)
 	}
@@ -1591,7 +1680,10 @@ func RunDatabaseReplicaPromote(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	replicaID := c.Args[1]
 	return c.Databases().PromoteReplica(databaseID, replicaID)
 }
@@ -1610,7 +1702,10 @@ func RunDatabaseReplicaConnectionGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	replicaID := c.Args[1]
 	connInfo, err := c.Databases().GetReplicaConnection(databaseID, replicaID)
 	if err != nil {
@@ -1636,16 +1731,16 @@ func sqlMode() *Command {
 	}
 
 	getSqlModeDesc := "Displays the configured SQL modes for the specified MySQL database cluster."
-	cmdDatabaseGetSQLModes := CmdBuilder(cmd, RunDatabaseGetSQLModes, "get <database-cluster-id>",
+	cmdDatabaseGetSQLModes := CmdBuilder(cmd, RunDatabaseGetSQLModes, "get <database-cluster-id|name>",
 		"Get a MySQL database cluster's SQL modes", getSqlModeDesc, Writer,
-		displayerType(&displayers.DatabaseSQLModes{}), aliasOpt("g"))
+		displayerType(&displayers.DatabaseSQLModes{}), aliasOpt("g"), fromFileOpt())
 	cmdDatabaseGetSQLModes.Example = `The following example retrieves the SQL modes for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases sql-mode get ca9f591d-f38h-5555-a0ef-1c02d1d1e35`
 
 	setSqlModeDesc := `This command configures the SQL modes for the specified MySQL database cluster. The SQL modes should be provided as a space separated list.
 
 This command replaces the existing SQL mode configuration completely. Include all of the current values when adding a new one.
 `
-	cmdDatabaseSetSQLModes := CmdBuilder(cmd, RunDatabaseSetSQLModes, "set <database-cluster-id> <sql-mode-1> ... <sql-mode-n>",
+	cmdDatabaseSetSQLModes := CmdBuilder(cmd, RunDatabaseSetSQLModes, "set <database-cluster-id|name> <sql-mode-1> ... <sql-mode-n>",
 		"Set a MySQL database cluster's SQL modes", setSqlModeDesc, Writer, aliasOpt("s"))
 	cmdDatabaseSetSQLModes.Example = `The following example sets the SQL mode ALLOW_INVALID_DATES for an existing database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `. The cluster already has the modes ` + "`" + `NO_ZERO_DATE` + "`" + `, ` + "`" + `NO_ZERO_IN_DATE` + "`" + `, ` + "`" + `STRICT_ALL_TABLES` + "`" + ` set, but they must be included in the command to avoid being overwritten by the additional mode: doctl databases sql-mode set ca9f591d-f38h-5555-a0ef-1c02d1d1e35 NO_ZERO_DATE NO_ZERO_IN_DATE STRICT_ALL_TABLES ALLOW_INVALID_DATES`
 	return cmd
@@ -1658,7 +1753,10 @@ func RunDatabaseGetSQLModes(c *CmdConfig) error {
 		return err
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	sqlModes, err := c.Databases().GetSQLMode(databaseID)
 	if err != nil {
 		return err
@@ -1678,7 +1776,10 @@ func RunDatabaseSetSQLModes(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	sqlModes := c.Args[1:]
 
 	return c.Databases().SetSQLMode(databaseID, sqlModes...)
@@ -1689,7 +1790,10 @@ func RunDatabaseTopicList(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	topics, err := c.Databases().ListTopics(databaseID)
 	if err != nil {
 		return err
@@ -1703,7 +1807,10 @@ func RunDatabaseTopicGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	topicName := c.Args[1]
 	topic, err := c.Databases().GetTopic(databaseID, topicName)
 	if err != nil {
@@ -1719,7 +1826,10 @@ func RunDatabaseTopicListPartition(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	topicName := c.Args[1]
 	topic, err := c.Databases().GetTopic(databaseID, topicName)
 	if err != nil {
@@ -1741,7 +1851,10 @@ func RunDatabaseTopicDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("kafka topic", 1) == nil {
-		databaseID := c.Args[0]
+		databaseID, err := databaseClusterID(c)
+		if err != nil {
+			return err
+		}
 		topicName := c.Args[1]
 		return c.Databases().DeleteTopic(databaseID, topicName)
 	}
@@ -1754,7 +1867,10 @@ func RunDatabaseTopicCreate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	topicName := c.Args[1]
 
 	createReq := &godo.DatabaseCreateTopicRequest{Name: topicName}
@@ -1780,7 +1896,10 @@ func RunDatabaseTopicUpdate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	topicName := c.Args[1]
 
 	updateReq := &godo.DatabaseUpdateTopicRequest{}
@@ -1974,15 +2093,15 @@ This command lists the following details for each partition of a given topic in
 	- The EarliestOffset - earliest offset read amongst all consumers of the partition.
 	`
 
-	CmdBuilder(cmd, RunDatabaseTopicList, "list <database-uuid>", "Retrieve a list of topics for a given kafka database", topicListDetails, Writer, displayerType(&displayers.DatabaseKafkaTopics{}), aliasOpt("ls"))
-	CmdBuilder(cmd, RunDatabaseTopicGet, "get <database-uuid> <topic-name>", "Retrieve the configuration for a given kafka topic", topicGetDetails, Writer, displayerType(&displayers.DatabaseKafkaTopic{}), aliasOpt("g"))
-	CmdBuilder(cmd, RunDatabaseTopicListPartition, "partitions <database-id> <topic-name>", "Retrieve the partitions for a given kafka topic", topicGetPartitionDetails, Writer, aliasOpt("p"))
-	cmdDatabaseTopicDelete := CmdBuilder(cmd, RunDatabaseTopicDelete, "delete <database-uuid> <topic-name>", "Deletes a kafka topic by topic name", "", Writer, aliasOpt("rm"))
+	CmdBuilder(cmd, RunDatabaseTopicList, "list <database-uuid|name>", "Retrieve a list of topics for a given kafka database", topicListDetails, Writer, displayerType(&displayers.DatabaseKafkaTopics{}), aliasOpt("ls"), fromFileOpt())
+	CmdBuilder(cmd, RunDatabaseTopicGet, "get <database-uuid|name> <topic-name>", "Retrieve the configuration for a given kafka topic", topicGetDetails, Writer, displayerType(&displayers.DatabaseKafkaTopic{}), aliasOpt("g"), fromFileOpt())
+	CmdBuilder(cmd, RunDatabaseTopicListPartition, "partitions <database-id|name> <topic-name>", "Retrieve the partitions for a given kafka topic", topicGetPartitionDetails, Writer, aliasOpt("p"), fromFileOpt())
+	cmdDatabaseTopicDelete := CmdBuilder(cmd, RunDatabaseTopicDelete, "delete <database-uuid|name> <topic-name>", "Deletes a kafka topic by topic name", "", Writer, aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdDatabaseTopicDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Deletes the kafka topic without a confirmation prompt")
-	cmdDatabaseTopicCreate := CmdBuilder(cmd, RunDatabaseTopicCreate, "create <database-uuid> <topic-name>", "Creates a topic for a given kafka database",
-		"This command creates a kafka topic for the specified kafka database cluster, giving it the specified name. Example: doctl databases topics create <database-uuid> <topic-name> --replication_factor 2 --partition_count 4", Writer, aliasOpt("c"))
-	cmdDatabaseTopicUpdate := CmdBuilder(cmd, RunDatabaseTopicUpdate, "update <database-uuid> <topic-name>", "Updates a topic for a given kafka database",
-		"This command updates a kafka topic for the specified kafka database cluster. Example: doctl databases topics update <database-uuid> <topic-name>", Writer, aliasOpt("u"))
+	cmdDatabaseTopicCreate := CmdBuilder(cmd, RunDatabaseTopicCreate, "create <database-uuid|name> <topic-name>", "Creates a topic for a given kafka database",
+		"This command creates a kafka topic for the specified kafka database cluster, giving it the specified name. Example: doctl databases topics create <database-uuid> <topic-name> --replication_factor 2 --partition_count 4", Writer, aliasOpt("c"), fromFileOpt())
+	cmdDatabaseTopicUpdate := CmdBuilder(cmd, RunDatabaseTopicUpdate, "update <database-uuid|name> <topic-name>", "Updates a topic for a given kafka database",
+		"This command updates a kafka topic for the specified kafka database cluster. Example: doctl databases topics update <database-uuid> <topic-name>", Writer, aliasOpt("u"), fromFileOpt())
 	cmdsWithConfig := []*Command{cmdDatabaseTopicCreate, cmdDatabaseTopicUpdate}
 	for _, c := range cmdsWithConfig {
 		AddIntFlag(c, doctl.ArgDatabaseTopicReplicationFactor, "", 2, "Specifies the number of nodes to replicate data across the kafka cluster")
@@ -2082,22 +2201,22 @@ Removes single rule from the list of firewall rules for a specified database. Yo
 
 	doctl database firewalls list <database-cluster-id>`
 
-	cmdDatabaseFirewallRulesList := CmdBuilder(cmd, RunDatabaseFirewallRulesList, "list <database-cluster-id>", "Retrieve a list of firewall rules for a given database", firewallRuleDetails+databaseFirewallRuleDetails,
-		Writer, aliasOpt("ls"))
+	cmdDatabaseFirewallRulesList := CmdBuilder(cmd, RunDatabaseFirewallRulesList, "list <database-cluster-id|name>", "Retrieve a list of firewall rules for a given database", firewallRuleDetails+databaseFirewallRuleDetails,
+		Writer, aliasOpt("ls"), fromFileOpt())
 	cmdDatabaseFirewallRulesList.Example = `The following example retrieves a list of firewall rules for a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases firewalls list ca9f591d-f38h-5555-a0ef-1c02d1d1e35`
 
-	cmdDatabaseFirewallUpdate := CmdBuilder(cmd, RunDatabaseFirewallRulesUpdate, "replace <database-cluster-id> --rules type:value [--rule type:value]", `Replaces the firewall rules for a given database. The rules passed to the `+"`"+`--rules`+"`"+` flag replace the firewall rules previously assigned to the database,`, databaseFirewallUpdateDetails,
-		Writer, aliasOpt("r"))
+	cmdDatabaseFirewallUpdate := CmdBuilder(cmd, RunDatabaseFirewallRulesUpdate, "replace <database-cluster-id|name> --rules type:value [--rule type:value]", `Replaces the firewall rules for a given database. The rules passed to the `+"`"+`--rules`+"`"+` flag replace the firewall rules previously assigned to the database,`, databaseFirewallUpdateDetails,
+		Writer, aliasOpt("r"), fromFileOpt())
 	AddStringSliceFlag(cmdDatabaseFirewallUpdate, doctl.ArgDatabaseFirewallRule, "", []string{}, databaseFirewallRulesTxt, requiredOpt())
 	cmdDatabaseFirewallUpdate.Example = `The following example replaces the firewall rules for a database cluster, with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `, with rules that allow a specific Droplet, a specific IP address, and any resources with the ` + "`" + `example-tag` + "`" + ` to access the database: doctl databases firewalls replace ca9f591d-f38h-5555-a0ef-1c02d1d1e35 --rules droplet:f81d4fae-7dec-11d0-a765-00a0c91e6bf6,ip_addr:192.168.1.1,tag:example-tag`
 
-	cmdDatabaseFirewallCreate := CmdBuilder(cmd, RunDatabaseFirewallRulesAppend, "append <database-cluster-id> --rule <type>:<value>", "Add a database firewall rule to a given database", databaseFirewallAddDetails,
+	cmdDatabaseFirewallCreate := CmdBuilder(cmd, RunDatabaseFirewallRulesAppend, "append <database-cluster-id|name> --rule <type>:<value>", "Add a database firewall rule to a given database", databaseFirewallAddDetails,
 		Writer, aliasOpt("a"))
 	AddStringFlag(cmdDatabaseFirewallCreate, doctl.ArgDatabaseFirewallRule, "", "", "", requiredOpt())
 	cmdDatabaseFirewallCreate.Example = `The following example appends a firewall rule to a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + ` that allows any resources with the ` + "`" + `example-tag` + "`" + ` to access the database: doctl databases firewalls append ca9f591d-f38h-5555-a0ef-1c02d1d1e35 --rule tag:example-tag`
 
-	cmdDatabaseFirewallRemove := CmdBuilder(cmd, RunDatabaseFirewallRulesRemove, "remove <database-cluster-id> --uuid <firerule-uuid>", "Remove a firewall rule for a given database", databaseFirewallRemoveDetails,
-		Writer, aliasOpt("rm"))
+	cmdDatabaseFirewallRemove := CmdBuilder(cmd, RunDatabaseFirewallRulesRemove, "remove <database-cluster-id|name> --uuid <firerule-uuid>", "Remove a firewall rule for a given database", databaseFirewallRemoveDetails,
+		Writer, aliasOpt("rm"), fromFileOpt())
 	AddStringFlag(cmdDatabaseFirewallRemove, doctl.ArgDatabaseFirewallRuleUUID, "", "", "", requiredOpt())
 	cmdDatabaseFirewallRemove.Example = `The following example removes a firewall rule with the UUID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` from a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases firewalls remove ca9f591d-f38h-5555-a0ef-1c02d1d1e35 f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
@@ -2136,7 +2255,10 @@ func RunDatabaseFirewallRulesList(c *CmdConfig) error {
 		return err
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	return displayDatabaseFirewallRules(c, true, id)
 }
@@ -2148,7 +2270,10 @@ func RunDatabaseFirewallRulesUpdate(c *CmdConfig) error {
 		return err
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	r, err := buildDatabaseUpdateFirewallRulesRequestFromArgs(c)
 	if err != nil {
 		return err
@@ -2212,7 +2337,10 @@ func RunDatabaseFirewallRulesAppend(c *CmdConfig) error {
 		return err
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	firewallRuleArg, err := c.Doit.GetString(c.NS, doctl.ArgDatabaseFirewallRule)
 	if err != nil {
 		return err
@@ -2269,7 +2397,10 @@ func RunDatabaseFirewallRulesRemove(c *CmdConfig) error {
 		return err
 	}
 
-	databaseID := c.Args[0]
+	databaseID, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	firewallRuleUUIDArg, err := c.Doit.GetString(c.NS, doctl.ArgDatabaseFirewallRuleUUID)
 	if err != nil {
@@ -2359,7 +2490,7 @@ func databaseConfiguration() *Command {
 
 		cmd,
 		RunDatabaseConfigurationGet,
-		"get <database-cluster-id>",
+		"get <database-cluster-id|name>",
 		"Get a database cluster's configuration",
 		getConfigurationLongDesc,
 		Writer,
@@ -2367,6 +2498,7 @@ func databaseConfiguration() *Command {
 		displayerType(&displayers.MySQLConfiguration{}),
 		displayerType(&displayers.PostgreSQLConfiguration{}),
 		displayerType(&displayers.RedisConfiguration{}),
+		fromFileOpt(),
 	)
 	AddStringFlag(
 		getDatabaseCfgCommand,
@@ -2385,6 +2517,7 @@ func databaseConfiguration() *Command {
 		updateConfigurationLongDesc,
 		Writer,
 		aliasOpt("u"),
+		fromFileOpt(),
 	)
 	AddStringFlag(
 		updateDatabaseCfgCommand,
@@ -2429,7 +2562,10 @@ func RunDatabaseConfigurationGet(c *CmdConfig) error {
 		return fmt.Errorf("(%s) command: engine must be one of: 'pg', 'mysql', 'redis'", c.NS)
 	}
 
-	dbId := args[0]
+	dbId, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	if engine == "mysql" {
 		config, err := c.Databases().GetMySQLConfiguration(dbId)
 		if err != nil {
@@ -2492,7 +2628,10 @@ func RunDatabaseConfigurationUpdate(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	dbId := args[0]
+	dbId, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 	if engine == "mysql" {
 		err := c.Databases().UpdateMySQLConfiguration(dbId, configJson)
 		if err != nil {
@@ -2525,7 +2664,7 @@ You can get a list of database events by calling:
 			Long:  `The subcommands under ` + "`" + `doctl databases events` + "`" + ` are for listing database cluster events.` + listDatabaseEvents,
 		},
 	}
-	cmdDatabaseEventsList := CmdBuilder(cmd, RunDatabaseEvents, "list <database-cluster-id>", "List your database cluster events", `Retrieves a list of database clusters events:`+listDatabaseEvents, Writer, aliasOpt("ls"), displayerType(&displayers.DatabaseEvents{}))
+	cmdDatabaseEventsList := CmdBuilder(cmd, RunDatabaseEvents, "list <database-cluster-id|name>", "List your database cluster events", `Retrieves a list of database clusters events:`+listDatabaseEvents, Writer, aliasOpt("ls"), displayerType(&displayers.DatabaseEvents{}), fromFileOpt())
 
 	cmdDatabaseEventsList.Example = `The following example retrieves a list of databases events in a database cluster with the ID ` + "`" + `ca9f591d-f38h-5555-a0ef-1c02d1d1e35` + "`" + `: doctl databases events list ca9f591d-f38h-5555-a0ef-1c02d1d1e35`
 
@@ -2538,7 +2677,10 @@ func RunDatabaseEvents(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
 
 	dbEvents, err := c.Databases().ListDatabaseEvents(id)
 	if err != nil {
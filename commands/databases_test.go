@@ -2,6 +2,7 @@ package commands
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -68,7 +69,7 @@ var (
 
 	testKafkaDBCluster = do.Database{
 		Database: &godo.Database{
-			ID:          "ea93928g-8se0-929e-m1ns-029daj2k3j12",
+			ID:          "ea93928f-85e0-929e-a1b5-029dafe2b3e1",
 			Name:        "kafka-db-cluster",
 			RegionSlug:  "nyc1",
 			EngineSlug:  "kafka",
@@ -262,6 +263,7 @@ func TestDatabasesCommand(t *testing.T) {
 		"sql-mode",
 		"configuration",
 		"topics",
+		"tunnel",
 	)
 }
 
@@ -364,10 +366,11 @@ func TestDatabasesGet(t *testing.T) {
 	// Error
 	notFound := "not-found"
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
-		tm.databases.EXPECT().Get(notFound).Return(nil, errTest)
+		tm.databases.EXPECT().Get(notFound).Return(nil, &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}})
+		tm.databases.EXPECT().List().Return(testDBClusters, nil)
 		config.Args = append(config.Args, notFound)
 		err := RunDatabaseGet(config)
-		assert.Error(t, err)
+		assert.EqualError(t, err, `database cluster with the ID or name "not-found" could not be found`)
 	})
 
 	// ID not provided
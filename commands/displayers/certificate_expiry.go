@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+	"strings"
+)
+
+// CertificateExpiry is a certificate that expires soon, along with the
+// resources that use it.
+type CertificateExpiry struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	DNSNames      []string `json:"dns_names"`
+	NotAfter      string   `json:"not_after"`
+	DaysLeft      int      `json:"days_left"`
+	LoadBalancers []string `json:"load_balancers"`
+	CDNEndpoints  []string `json:"cdn_endpoints"`
+}
+
+type CertificateExpiries struct {
+	Certificates []CertificateExpiry
+}
+
+var _ Displayable = &CertificateExpiries{}
+
+func (c *CertificateExpiries) JSON(out io.Writer) error {
+	return writeJSON(c.Certificates, out)
+}
+
+func (c *CertificateExpiries) Cols() []string {
+	return []string{"ID", "Name", "Type", "DNSNames", "NotAfter", "DaysLeft", "LoadBalancers", "CDNEndpoints"}
+}
+
+func (c *CertificateExpiries) ColMap() map[string]string {
+	return map[string]string{
+		"ID":            "ID",
+		"Name":          "Name",
+		"Type":          "Type",
+		"DNSNames":      "DNS Names",
+		"NotAfter":      "Expiration Date",
+		"DaysLeft":      "Days Left",
+		"LoadBalancers": "Load Balancers",
+		"CDNEndpoints":  "CDN Endpoints",
+	}
+}
+
+func (c *CertificateExpiries) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(c.Certificates))
+
+	for _, x := range c.Certificates {
+		m := map[string]any{
+			"ID":            x.ID,
+			"Name":          x.Name,
+			"Type":          x.Type,
+			"DNSNames":      strings.Join(x.DNSNames, ","),
+			"NotAfter":      x.NotAfter,
+			"DaysLeft":      x.DaysLeft,
+			"LoadBalancers": strings.Join(x.LoadBalancers, ","),
+			"CDNEndpoints":  strings.Join(x.CDNEndpoints, ","),
+		}
+		out = append(out, m)
+	}
+
+	return out
+}
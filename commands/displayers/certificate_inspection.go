@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+	"strings"
+)
+
+// CertificateDetail describes one certificate of a local PEM bundle.
+type CertificateDetail struct {
+	Role               string   `json:"role"`
+	Subject            string   `json:"subject"`
+	Issuer             string   `json:"issuer"`
+	DNSNames           []string `json:"dns_names"`
+	NotBefore          string   `json:"not_before"`
+	NotAfter           string   `json:"not_after"`
+	Key                string   `json:"key"`
+	SignatureAlgorithm string   `json:"signature_algorithm"`
+	SHA1Fingerprint    string   `json:"sha1_fingerprint"`
+}
+
+type CertificateDetails struct {
+	Certificates []CertificateDetail
+}
+
+var _ Displayable = &CertificateDetails{}
+
+func (c *CertificateDetails) JSON(out io.Writer) error {
+	return writeJSON(c.Certificates, out)
+}
+
+func (c *CertificateDetails) Cols() []string {
+	return []string{"Role", "Subject", "Issuer", "DNSNames", "NotAfter", "Key", "SignatureAlgorithm"}
+}
+
+func (c *CertificateDetails) ColMap() map[string]string {
+	return map[string]string{
+		"Role":               "Role",
+		"Subject":            "Subject",
+		"Issuer":             "Issuer",
+		"DNSNames":           "DNS Names",
+		"NotBefore":          "Valid From",
+		"NotAfter":           "Expiration Date",
+		"Key":                "Key",
+		"SignatureAlgorithm": "Signature Algorithm",
+		"SHA1Fingerprint":    "SHA-1 Fingerprint",
+	}
+}
+
+func (c *CertificateDetails) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(c.Certificates))
+
+	for _, x := range c.Certificates {
+		m := map[string]any{
+			"Role":               x.Role,
+			"Subject":            x.Subject,
+			"Issuer":             x.Issuer,
+			"DNSNames":           strings.Join(x.DNSNames, ","),
+			"NotBefore":          x.NotBefore,
+			"NotAfter":           x.NotAfter,
+			"Key":                x.Key,
+			"SignatureAlgorithm": x.SignatureAlgorithm,
+			"SHA1Fingerprint":    x.SHA1Fingerprint,
+		}
+		out = append(out, m)
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+	"strings"
+)
+
+// DNSCheck is the result of comparing one server's answer for a DNS name and
+// record type with the records stored in DigitalOcean DNS.
+type DNSCheck struct {
+	Server   string   `json:"server"`
+	Role     string   `json:"role"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Status   string   `json:"status"`
+	Expected []string `json:"expected"`
+	Answer   []string `json:"answer"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type DNSChecks struct {
+	Checks []DNSCheck
+}
+
+var _ Displayable = &DNSChecks{}
+
+func (d *DNSChecks) JSON(out io.Writer) error {
+	return writeJSON(d.Checks, out)
+}
+
+func (d *DNSChecks) Cols() []string {
+	return []string{"Server", "Role", "Name", "Type", "Status", "Expected", "Answer"}
+}
+
+func (d *DNSChecks) ColMap() map[string]string {
+	return map[string]string{
+		"Server":   "Server",
+		"Role":     "Role",
+		"Name":     "Name",
+		"Type":     "Type",
+		"Status":   "Status",
+		"Expected": "Expected",
+		"Answer":   "Answer",
+	}
+}
+
+func (d *DNSChecks) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(d.Checks))
+
+	for _, x := range d.Checks {
+		answer := strings.Join(x.Answer, ",")
+		if x.Error != "" {
+			answer = x.Error
+		}
+		m := map[string]any{
+			"Server":   x.Server,
+			"Role":     x.Role,
+			"Name":     x.Name,
+			"Type":     x.Type,
+			"Status":   x.Status,
+			"Expected": strings.Join(x.Expected, ","),
+			"Answer":   answer,
+		}
+		out = append(out, m)
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+)
+
+// FirewallFinding is a problem found by a firewall audit.
+type FirewallFinding struct {
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Resource string `json:"resource"`
+	Detail   string `json:"detail"`
+}
+
+type FirewallFindings struct {
+	Findings []FirewallFinding
+}
+
+var _ Displayable = &FirewallFindings{}
+
+func (f *FirewallFindings) JSON(out io.Writer) error {
+	return writeJSON(f.Findings, out)
+}
+
+func (f *FirewallFindings) Cols() []string {
+	return []string{"Severity", "Check", "Resource", "Detail"}
+}
+
+func (f *FirewallFindings) ColMap() map[string]string {
+	return map[string]string{
+		"Severity": "Severity",
+		"Check":    "Check",
+		"Resource": "Resource",
+		"Detail":   "Detail",
+	}
+}
+
+func (f *FirewallFindings) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(f.Findings))
+
+	for _, x := range f.Findings {
+		m := map[string]any{
+			"Severity": x.Severity,
+			"Check":    x.Check,
+			"Resource": x.Resource,
+			"Detail":   x.Detail,
+		}
+		out = append(out, m)
+	}
+
+	return out
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+)
+
+// FirewallCheck is the result of evaluating the firewall rules of one
+// Droplet for traffic between two endpoints.
+type FirewallCheck struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Direction string `json:"direction"`
+	Droplet   string `json:"droplet"`
+	Result    string `json:"result"`
+	Firewall  string `json:"firewall"`
+	Rule      string `json:"rule"`
+}
+
+type FirewallChecks struct {
+	Checks []FirewallCheck
+}
+
+var _ Displayable = &FirewallChecks{}
+
+func (f *FirewallChecks) JSON(out io.Writer) error {
+	return writeJSON(f.Checks, out)
+}
+
+func (f *FirewallChecks) Cols() []string {
+	return []string{"From", "To", "Direction", "Droplet", "Result", "Firewall", "Rule"}
+}
+
+func (f *FirewallChecks) ColMap() map[string]string {
+	return map[string]string{
+		"From":      "From",
+		"To":        "To",
+		"Direction": "Direction",
+		"Droplet":   "Checked On",
+		"Result":    "Result",
+		"Firewall":  "Firewall",
+		"Rule":      "Rule",
+	}
+}
+
+func (f *FirewallChecks) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(f.Checks))
+
+	for _, x := range f.Checks {
+		m := map[string]any{
+			"From":      x.From,
+			"To":        x.To,
+			"Direction": x.Direction,
+			"Droplet":   x.Droplet,
+			"Result":    x.Result,
+			"Firewall":  x.Firewall,
+			"Rule":      x.Rule,
+		}
+		out = append(out, m)
+	}
+
+	return out
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+	"strings"
+)
+
+// IPOwner is a resource that an IP address belongs to.
+type IPOwner struct {
+	Type    string   `json:"type"`
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	URN     string   `json:"urn,omitempty"`
+	Field   string   `json:"field"`
+	Project string   `json:"project,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+type IPOwners struct {
+	Owners []IPOwner
+}
+
+var _ Displayable = &IPOwners{}
+
+func (o *IPOwners) JSON(out io.Writer) error {
+	return writeJSON(o.Owners, out)
+}
+
+func (o *IPOwners) Cols() []string {
+	return []string{"Type", "ID", "Name", "Field", "Project", "Tags", "URN"}
+}
+
+func (o *IPOwners) ColMap() map[string]string {
+	return map[string]string{
+		"Type":    "Type",
+		"ID":      "ID",
+		"Name":    "Name",
+		"Field":   "Matched Field",
+		"Project": "Project",
+		"Tags":    "Tags",
+		"URN":     "URN",
+	}
+}
+
+func (o *IPOwners) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(o.Owners))
+
+	for _, x := range o.Owners {
+		m := map[string]any{
+			"Type":    x.Type,
+			"ID":      x.ID,
+			"Name":    x.Name,
+			"Field":   x.Field,
+			"Project": x.Project,
+			"Tags":    strings.Join(x.Tags, ","),
+			"URN":     x.URN,
+		}
+		out = append(out, m)
+	}
+
+	return out
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+)
+
+// ItemResult is the outcome of acting on a single resource as part of a
+// command that targets several resources at once.
+type ItemResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ItemResults struct {
+	Results []ItemResult
+}
+
+var _ Displayable = &ItemResults{}
+
+func (r *ItemResults) JSON(out io.Writer) error {
+	return writeJSON(r.Results, out)
+}
+
+func (r *ItemResults) Cols() []string {
+	return []string{"ID", "Status", "Error"}
+}
+
+func (r *ItemResults) ColMap() map[string]string {
+	return map[string]string{
+		"ID":     "ID",
+		"Status": "Status",
+		"Error":  "Error",
+	}
+}
+
+func (r *ItemResults) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(r.Results))
+
+	for _, x := range r.Results {
+		o := map[string]any{
+			"ID":     x.ID,
+			"Status": x.Status,
+			"Error":  x.Error,
+		}
+		out = append(out, o)
+	}
+
+	return out
+}
@@ -41,7 +41,7 @@ type Displayer struct {
 	Out  io.Writer
 }
 
-// Display ends up rendering the content in one of two formats (text|json)
+// Display ends up rendering the content in one of three formats (text|json|ndjson)
 func (d *Displayer) Display() error {
 	switch d.OutputType {
 	case "json":
@@ -50,6 +50,11 @@ func (d *Displayer) Display() error {
 			return err
 		}
 		return d.Item.JSON(d.Out)
+	case "ndjson":
+		if containsOnlyNilSlice(d.Item) {
+			return nil
+		}
+		return writeNDJSON(d.Item, d.Out)
 	case "text":
 		var cols []string
 		for _, c := range strings.Split(strings.Join(strings.Fields(d.ColumnList), ""), ",") {
@@ -133,6 +138,33 @@ func writeJSON(item any, w io.Writer) error {
 	return err
 }
 
+// writeNDJSON renders the item's JSON representation as newline-delimited
+// JSON, writing each element of a list on its own line.
+func writeNDJSON(item Displayable, w io.Writer) error {
+	var buf bytes.Buffer
+	if err := item.JSON(&buf); err != nil {
+		return err
+	}
+
+	var elems []json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &elems); err != nil {
+		elems = []json.RawMessage{buf.Bytes()}
+	}
+
+	for _, e := range elems {
+		var out bytes.Buffer
+		if err := json.Compact(&out, e); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+		if _, err := out.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // containsOnlyNiSlice returns true if the given interface's concrete type is
 // a pointer to a struct that contains a single nil slice field.
 func containsOnlyNilSlice(i any) bool {
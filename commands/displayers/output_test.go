@@ -46,3 +46,22 @@ func TestDisplayerDisplay(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayerDisplayNDJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+
+	displayer := Displayer{
+		OutputType: "ndjson",
+		Item: &ItemResults{Results: []ItemResult{
+			{ID: "1", Status: "ok"},
+			{ID: "2", Status: "failed", Error: "boom"},
+		}},
+		Out: out,
+	}
+
+	err := displayer.Display()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":"1","status":"ok"}
+{"id":"2","status":"failed","error":"boom"}
+`, out.String())
+}
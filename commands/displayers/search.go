@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+)
+
+// SearchResult is a resource that matched a search term.
+type SearchResult struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	URN   string `json:"urn,omitempty"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+type SearchResults struct {
+	Results []SearchResult
+}
+
+var _ Displayable = &SearchResults{}
+
+func (r *SearchResults) JSON(out io.Writer) error {
+	return writeJSON(r.Results, out)
+}
+
+func (r *SearchResults) Cols() []string {
+	return []string{"Type", "ID", "Name", "Field", "Value", "URN"}
+}
+
+func (r *SearchResults) ColMap() map[string]string {
+	return map[string]string{
+		"Type":  "Type",
+		"ID":    "ID",
+		"Name":  "Name",
+		"Field": "Matched Field",
+		"Value": "Matched Value",
+		"URN":   "URN",
+	}
+}
+
+func (r *SearchResults) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(r.Results))
+
+	for _, x := range r.Results {
+		o := map[string]any{
+			"Type":  x.Type,
+			"ID":    x.ID,
+			"Name":  x.Name,
+			"Field": x.Field,
+			"Value": x.Value,
+			"URN":   x.URN,
+		}
+		out = append(out, o)
+	}
+
+	return out
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package displayers
+
+import (
+	"io"
+)
+
+// SSHResult is the outcome of running a command on one Droplet over SSH. An
+// exit status of -1 means that the command did not run or did not exit.
+type SSHResult struct {
+	Droplet    string `json:"droplet"`
+	Host       string `json:"host"`
+	ExitStatus int    `json:"exit_status"`
+	Error      string `json:"error,omitempty"`
+}
+
+type SSHResults struct {
+	Results []SSHResult
+}
+
+var _ Displayable = &SSHResults{}
+
+func (s *SSHResults) JSON(out io.Writer) error {
+	return writeJSON(s.Results, out)
+}
+
+func (s *SSHResults) Cols() []string {
+	return []string{"Droplet", "Host", "ExitStatus", "Error"}
+}
+
+func (s *SSHResults) ColMap() map[string]string {
+	return map[string]string{
+		"Droplet":    "Droplet",
+		"Host":       "Host",
+		"ExitStatus": "Exit Status",
+		"Error":      "Error",
+	}
+}
+
+func (s *SSHResults) KV() []map[string]any {
+	out := make([]map[string]any, 0, len(s.Results))
+
+	for _, x := range s.Results {
+		m := map[string]any{
+			"Droplet":    x.Droplet,
+			"Host":       x.Host,
+			"ExitStatus": x.ExitStatus,
+			"Error":      x.Error,
+		}
+		out = append(out, m)
+	}
+
+	return out
+}
@@ -85,7 +85,7 @@ func init() {
 	rootPFlagSet.StringVarP(&Token, doctl.ArgAccessToken, "t", "", "API V2 access token")
 	viper.BindPFlag(doctl.ArgAccessToken, rootPFlagSet.Lookup(doctl.ArgAccessToken))
 
-	rootPFlagSet.StringVarP(&Output, doctl.ArgOutput, "o", "text", "Desired output format [text|json]")
+	rootPFlagSet.StringVarP(&Output, doctl.ArgOutput, "o", "text", "Desired output format [text|json|ndjson]")
 	viper.BindPFlag("output", rootPFlagSet.Lookup(doctl.ArgOutput))
 
 	rootPFlagSet.StringVarP(&Context, doctl.ArgContext, "", "", "Specify a custom authentication context name")
@@ -189,6 +189,11 @@ func addCommands() {
 	DoitCmd.AddCommand(OneClicks())
 	DoitCmd.AddCommand(Monitoring())
 	DoitCmd.AddCommand(Serverless())
+	DoitCmd.AddCommand(Network())
+	DoitCmd.AddCommand(Search())
+	DoitCmd.AddCommand(Plan())
+	DoitCmd.AddCommand(Apply())
+	DoitCmd.AddCommand(Export())
 }
 
 func computeCmd() *Command {
@@ -222,9 +227,10 @@ func computeCmd() *Command {
 	cmd.AddCommand(Volume())
 	cmd.AddCommand(VolumeAction())
 
-	// SSH is different since it doesn't have any subcommands. In this case, let's
-	// give it a parent at init time.
+	// SSH and SCP are different since they don't have any subcommands. In this case, let's
+	// give them a parent at init time.
 	SSH(cmd)
+	SCP(cmd)
 
 	return cmd
 }
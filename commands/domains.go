@@ -14,13 +14,20 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"net"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/commands/displayers"
 	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/zonefile"
 	"github.com/digitalocean/godo"
 	"github.com/spf13/cobra"
 )
@@ -52,6 +59,19 @@ func Domain() *Command {
 	AddBoolFlag(cmdRunDomainDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Deletes the domain without a confirmation prompt")
 	cmdRunDomainDelete.Example = `The following command deletes the domain example.com: doctl compute domain delete example.com`
 
+	cmdDomainImport := CmdBuilder(cmd, RunDomainImport, "import <domain> <zone-file>", "Import DNS records from a zone file", `Creates DNS records for a domain from a BIND zone file, as described in RFC 1035, so that you can migrate a zone from another DNS provider. Pass `+"`"+`-`+"`"+` as the file name to read the zone from standard input.
+
+The A, AAAA, CAA, CNAME, MX, NS, SRV, and TXT records in the file are imported. SOA records and NS records for the domain itself are skipped because DigitalOcean manages them. If the domain does not exist, it is added to your account.
+
+The command prints the changes it will make before making them. Records that already exist are left in place, or updated if their TTL differs. Records that are not in the file are kept unless you use the `+"`"+`--prune`+"`"+` flag.`, Writer)
+	AddBoolFlag(cmdDomainImport, doctl.ArgPlan, "", false, "Shows the changes the import would make without making them")
+	AddBoolFlag(cmdDomainImport, doctl.ArgPrune, "", false, "Deletes records that are not in the zone file")
+	AddBoolFlag(cmdDomainImport, doctl.ArgForce, doctl.ArgShortForce, false, "Imports the records without a confirmation prompt")
+	cmdDomainImport.Example = `The following command shows the changes needed to make the records of example.com match the zone file ` + "`" + `zone.txt` + "`" + `: doctl compute domain import example.com zone.txt --plan`
+
+	cmdDomainExport := CmdBuilder(cmd, RunDomainExport, "export <domain>", "Export DNS records as a zone file", `Writes the DNS records for a domain as a BIND zone file, as described in RFC 1035. The SOA record is omitted.`, Writer)
+	cmdDomainExport.Example = `The following command writes the records of example.com to the file ` + "`" + `zone.txt` + "`" + `: doctl compute domain export example.com > zone.txt`
+
 	cmdRecord := &Command{
 		Command: &cobra.Command{
 			Use:   "records",
@@ -65,6 +85,32 @@ func Domain() *Command {
 		aliasOpt("ls"), displayerType(&displayers.DomainRecord{}))
 	cmdRecordList.Example = `The following command lists the DNS records for the domain example.com. The command also uses the ` + "`" + `--format` + "`" + ` flag to only return each record's ID, type, and TTL: doctl compute domain records list example.com --format ID,Type,TTL`
 
+	cmdRecordCheck := CmdBuilder(cmdRecord, RunRecordCheck, "check <domain>", "Check that DNS records have propagated", `Queries the authoritative DigitalOcean name servers and a list of public resolvers for the DNS records of a domain, and compares each answer with the records stored in DigitalOcean DNS. Use this command after changing records to find out when the change is live.
+
+The command exits with an error if any answer does not match. Resolvers cache answers until their TTL expires, so a resolver can return the old answer for up to the record's TTL after a change. Use the `+"`"+`--wait`+"`"+` flag to check again until every server returns the expected answer.`, Writer,
+		displayerType(&displayers.DNSChecks{}))
+	AddStringFlag(cmdRecordCheck, doctl.ArgRecordCheckName, "", "", "Checks only the records with this name, such as `www` or `@`")
+	AddStringSliceFlag(cmdRecordCheck, doctl.ArgNameServers, "", digitalOceanNameServers, "The authoritative name servers to query, as `host` or `host:port`")
+	AddStringSliceFlag(cmdRecordCheck, doctl.ArgResolvers, "", publicResolvers, "The recursive resolvers to query, as `host` or `host:port`")
+	AddBoolFlag(cmdRecordCheck, doctl.ArgCommandWait, "", false, "Checks again until every server returns the expected answer")
+	AddDurationFlag(cmdRecordCheck, doctl.ArgTimeout, "", 10*time.Minute, "How long to wait for the records to propagate when using `--wait`")
+	cmdRecordCheck.Example = `The following command waits until the ` + "`" + `www` + "`" + ` records for example.com are returned by the DigitalOcean name servers and Cloudflare's resolver: doctl compute domain records check example.com --name www --resolvers 1.1.1.1 --wait`
+
+	cmdRecordDDNS := CmdBuilder(cmdRecord, RunRecordDDNS, "ddns <domain> <name>", "Point a record at this machine's public address", `Creates or updates the A record, and optionally the AAAA record, for a name so that it points at the current public address of this machine. Use this command for dynamic DNS on hosts whose address changes.
+
+By default, the address is read from a web service that returns the caller's address. Use `+"`"+`--ip-source interface`+"`"+` with `+"`"+`--interface`+"`"+` to use the first public address of a network interface instead. Private addresses, such as those in 10.0.0.0/8 or 192.168.0.0/16, are skipped.
+
+Without `+"`"+`--interval`+"`"+`, the command updates the records once and exits. With `+"`"+`--interval`+"`"+`, it runs in the foreground until it is interrupted, checks the address at each interval, and changes the records only when the address changes. Each change is logged with a timestamp.`, Writer, fromFileOpt())
+	AddDurationFlag(cmdRecordDDNS, doctl.ArgDDNSInterval, "", 0, "How often to check the public address, such as `5m`. If not set, the records are updated once.")
+	AddStringFlag(cmdRecordDDNS, doctl.ArgDDNSIPSource, "", ddnsSourceURL, "Where to find the public address. Valid values are `url` and `interface`.")
+	AddStringFlag(cmdRecordDDNS, doctl.ArgDDNSIPv4URL, "", defaultDDNSIPv4URL, "A URL that returns the public IPv4 address as plain text")
+	AddStringFlag(cmdRecordDDNS, doctl.ArgDDNSIPv6URL, "", defaultDDNSIPv6URL, "A URL that returns the public IPv6 address as plain text")
+	AddStringFlag(cmdRecordDDNS, doctl.ArgDDNSInterface, "", "", "The network interface to read the address from when using `--ip-source interface`, such as `eth0`")
+	AddBoolFlag(cmdRecordDDNS, doctl.ArgDDNSIPv4, "", true, "Updates the A record with the public IPv4 address")
+	AddBoolFlag(cmdRecordDDNS, doctl.ArgDDNSIPv6, "", false, "Updates the AAAA record with the public IPv6 address")
+	AddIntFlag(cmdRecordDDNS, doctl.ArgRecordTTL, "", 300, "The TTL, in seconds, of records that are created")
+	cmdRecordDDNS.Example = `The following command points home.example.com at this machine's IPv4 and IPv6 addresses, and checks for changes every five minutes: doctl compute domain records ddns example.com home --ipv6 --interval 5m`
+
 	cmdRecordCreate := CmdBuilder(cmdRecord, RunRecordCreate, "create <domain>", "Create a DNS record", `Create DNS records for a domain.`, Writer,
 		aliasOpt("c"), displayerType(&displayers.DomainRecord{}))
 	AddStringFlag(cmdRecordCreate, doctl.ArgRecordType, "", "", `The type of DNS record. Valid values are: `+"`"+`A`+"`"+`, `+"`"+`AAAA`+"`"+`, `+"`"+`CAA`+"`"+`, `+"`"+`CNAME`+"`"+`, `+"`"+`MX`+"`"+`, `+"`"+`NS`+"`"+`, `+"`"+`SOA`+"`"+`, `+"`"+`SRV`+"`"+`, and `+"`"+`TXT`+"`"+`.`)
@@ -80,8 +126,9 @@ func Domain() *Command {
 	cmdRecordCreate.Example = `The following command creates an A record for the domain example.com: doctl compute domain records create example.com --record-type A --record-name example.com --record-data 198.51.100.215`
 
 	cmdRunRecordDelete := CmdBuilder(cmdRecord, RunRecordDelete, "delete <domain> <record-id>...", "Delete a DNS record", `Deletes DNS records for a domain.`, Writer,
-		aliasOpt("d", "rm"))
+		aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunRecordDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete record without confirmation prompt")
+	addFanOutFlags(cmdRunRecordDelete)
 	cmdRunRecordDelete.Example = `The following command deletes a DNS record with the ID ` + "`" + `98858421` + "`" + ` from the domain ` + "`" + `example.com` + "`" + `: doctl compute domain records delete example.com 98858421`
 
 	cmdRecordUpdate := CmdBuilder(cmdRecord, RunRecordUpdate, "update <domain>", "Update a DNS record", `Updates or changes the properties of DNS records for a domain.`, Writer,
@@ -194,6 +241,157 @@ func RunDomainDelete(c *CmdConfig) error {
 	return errOperationAborted
 }
 
+// importableRecordTypes are the record types DigitalOcean DNS can serve.
+var importableRecordTypes = map[string]bool{
+	"A": true, "AAAA": true, "CAA": true, "CNAME": true, "MX": true, "NS": true, "SRV": true, "TXT": true,
+}
+
+// RunDomainImport creates DNS records for a domain from a zone file.
+func RunDomainImport(c *CmdConfig) error {
+	if len(c.Args) < 2 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	if len(c.Args) > 2 {
+		return doctl.NewTooManyArgsErr(c.NS)
+	}
+	domain, path := strings.TrimSuffix(c.Args[0], "."), c.Args[1]
+
+	planOnly, err := c.Doit.GetBool(c.NS, doctl.ArgPlan)
+	if err != nil {
+		return err
+	}
+	prune, err := c.Doit.GetBool(c.NS, doctl.ArgPrune)
+	if err != nil {
+		return err
+	}
+
+	b, err := readInput(os.Stdin, path)
+	if err != nil {
+		return err
+	}
+	records, err := zonefile.Parse(bytes.NewReader(b), domain)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	p := &plan{}
+	want := manifestDomain{Name: domain}
+	for _, r := range records {
+		switch {
+		case r.Type == "SOA":
+		case r.Type == "NS" && r.Name == "@":
+			p.warn("line %d: skipping the NS record for %s; DigitalOcean manages the domain's name servers", r.Line, domain)
+		case !importableRecordTypes[r.Type]:
+			p.warn("line %d: skipping %s %s; DigitalOcean DNS does not support %s records", r.Line, r.Name, r.Type, r.Type)
+		default:
+			want.Records = append(want.Records, zoneToManifestRecord(domain, r))
+		}
+	}
+
+	ds := c.Domains()
+	domains, err := ds.List()
+	if err != nil {
+		return err
+	}
+	exists := false
+	for _, d := range domains {
+		exists = exists || d.Name == domain
+	}
+	if !exists {
+		p.add(planChange{Action: planCreate, Kind: "domain", Name: domain, apply: func(*applyState) error {
+			_, err := ds.Create(&godo.DomainCreateRequest{Name: domain})
+			return err
+		}})
+	}
+
+	deletes, err := planRecords(&planInput{c: c, prune: prune}, p, want, exists)
+	if err != nil {
+		return err
+	}
+	for _, ch := range deletes {
+		p.add(ch)
+	}
+
+	p.write(c.Out)
+	if planOnly {
+		return nil
+	}
+	return applyPlan(c, p, &applyState{})
+}
+
+// zoneToManifestRecord converts a zone file record to the form used by
+// planRecords. Host names in the data keep their trailing dot, except for
+// the domain itself, which becomes "@".
+func zoneToManifestRecord(domain string, r zonefile.Record) manifestRecord {
+	data := r.Data
+	switch r.Type {
+	case "CNAME", "MX", "NS", "SRV":
+		if strings.EqualFold(data, zonefile.Fqdn(domain)) {
+			data = "@"
+		}
+	}
+	return manifestRecord{
+		Type:     r.Type,
+		Name:     r.Name,
+		Data:     data,
+		Priority: r.Priority,
+		Port:     r.Port,
+		Weight:   r.Weight,
+		TTL:      r.TTL,
+		Flags:    r.Flags,
+		Tag:      r.Tag,
+	}
+}
+
+// RunDomainExport writes the records for a domain as a zone file.
+func RunDomainExport(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSuffix(c.Args[0], ".")
+
+	ds := c.Domains()
+	d, err := ds.Get(name)
+	if err != nil {
+		return err
+	}
+	list, err := ds.Records(name)
+	if err != nil {
+		return err
+	}
+
+	var records []zonefile.Record
+	for _, r := range list {
+		if r.Type == "SOA" {
+			continue
+		}
+		data := r.Data
+		switch r.Type {
+		case "CNAME", "MX", "NS", "SRV":
+			// The API returns fully qualified host names without the
+			// trailing dot, and "@" for the domain itself.
+			if data == "@" {
+				data = name
+			}
+			data = zonefile.Fqdn(data)
+		}
+		records = append(records, zonefile.Record{
+			Name:     r.Name,
+			TTL:      r.TTL,
+			Type:     r.Type,
+			Data:     data,
+			Priority: r.Priority,
+			Weight:   r.Weight,
+			Port:     r.Port,
+			Flags:    r.Flags,
+			Tag:      r.Tag,
+		})
+	}
+
+	return zonefile.Write(c.Out, name, d.TTL, records)
+}
+
 // RunRecordList list records for a domain.
 func RunRecordList(c *CmdConfig) error {
 	err := ensureOneArg(c)
@@ -286,6 +484,9 @@ func RunRecordCreate(c *CmdConfig) error {
 	if len(drcr.Type) == 0 {
 		return errors.New("Record request is missing type.")
 	}
+	if err := validateRecordRequest(c, drcr, true); err != nil {
+		return err
+	}
 
 	r, err := ds.CreateRecord(name, drcr)
 	if err != nil {
@@ -315,23 +516,21 @@ func RunRecordDelete(c *CmdConfig) error {
 	if force || AskForConfirmDelete("domain record", len(ids)) == nil {
 		ds := c.Domains()
 
+		recordIDs := make([]int, 0, len(ids))
 		for _, i := range ids {
 			id, err := strconv.Atoi(i)
 			if err != nil {
 				return fmt.Errorf("Invalid record id %q", i)
 			}
-
-			err = ds.DeleteRecord(domainName, id)
-			if err != nil {
-				return err
-			}
+			recordIDs = append(recordIDs, id)
 		}
-	} else {
-		return errOperationAborted
-	}
 
-	return nil
+		return fanOutInts(c, recordIDs, func(id int) error {
+			return ds.DeleteRecord(domainName, id)
+		})
+	}
 
+	return errOperationAborted
 }
 
 // RunRecordUpdate updates a domain record.
@@ -406,6 +605,10 @@ func RunRecordUpdate(c *CmdConfig) error {
 		Tag:      rTag,
 	}
 
+	if err := validateRecordRequest(c, drcr, false); err != nil {
+		return err
+	}
+
 	r, err := ds.EditRecord(domainName, recordID, drcr)
 	if err != nil {
 		return err
@@ -414,6 +617,91 @@ func RunRecordUpdate(c *CmdConfig) error {
 	return displayDomainRecords(c, *r)
 }
 
+// recordTypes are the record types that can be created with records create.
+var recordTypes = []string{"A", "AAAA", "CAA", "CNAME", "MX", "NS", "SOA", "SRV", "TXT"}
+
+// validateRecordRequest checks a record create or update request before it
+// is sent, so that mistakes are reported against the flag that caused them
+// instead of as an API error. Fields that are required for the record type
+// are only enforced on create, since an update may change a single field.
+// TXT data longer than 255 bytes is split into quoted character strings.
+func validateRecordRequest(c *CmdConfig, r *do.DomainRecordEditRequest, create bool) error {
+	r.Type = strings.ToUpper(r.Type)
+
+	if r.TTL != 0 && r.TTL < 30 {
+		return fmt.Errorf("--%s must be at least 30 seconds", doctl.ArgRecordTTL)
+	}
+	if r.Priority < 0 || r.Priority > 65535 {
+		return fmt.Errorf("--%s must be between 0 and 65535", doctl.ArgRecordPriority)
+	}
+	if r.Weight < 0 || r.Weight > 65535 {
+		return fmt.Errorf("--%s must be between 0 and 65535", doctl.ArgRecordWeight)
+	}
+	if r.Port != nil && (*r.Port < 0 || *r.Port > 65535) {
+		return fmt.Errorf("--%s must be between 0 and 65535", doctl.ArgRecordPort)
+	}
+	if r.Flags < 0 || r.Flags > 255 {
+		return fmt.Errorf("--%s must be between 0 and 255", doctl.ArgRecordFlags)
+	}
+
+	if r.Type == "" {
+		return nil
+	}
+	if !slices.Contains(recordTypes, r.Type) {
+		return fmt.Errorf("--%s %q is not supported; valid values are %s", doctl.ArgRecordType, r.Type, strings.Join(recordTypes, ", "))
+	}
+
+	require := func(flag string) error {
+		if create && !c.Doit.IsSet(flag) {
+			return fmt.Errorf("%s records require --%s", r.Type, flag)
+		}
+		return nil
+	}
+	if r.Data == "" {
+		return require(doctl.ArgRecordData)
+	}
+
+	switch r.Type {
+	case "A":
+		if ip := net.ParseIP(r.Data); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("--%s %q is not an IPv4 address", doctl.ArgRecordData, r.Data)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(r.Data); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("--%s %q is not an IPv6 address", doctl.ArgRecordData, r.Data)
+		}
+	case "CNAME", "MX", "NS", "SRV":
+		if r.Data != "@" && !strings.HasSuffix(r.Data, ".") {
+			return fmt.Errorf("--%s %q must be a fully qualified host name ending in a dot, such as %q, or @ for the domain itself", doctl.ArgRecordData, r.Data, r.Data+".")
+		}
+	case "CAA":
+		switch r.Tag {
+		case "issue", "issuewild", "iodef":
+		case "":
+			return require(doctl.ArgRecordTag)
+		default:
+			return fmt.Errorf("--%s %q is not valid; valid values are issue, issuewild, and iodef", doctl.ArgRecordTag, r.Tag)
+		}
+	case "TXT":
+		// Values that are already quoted are sent as given.
+		if len(r.Data) > 255 && !strings.HasPrefix(r.Data, `"`) {
+			r.Data = zonefile.QuoteText(r.Data)
+		}
+	}
+
+	switch r.Type {
+	case "MX":
+		return require(doctl.ArgRecordPriority)
+	case "SRV":
+		for _, flag := range []string{doctl.ArgRecordPriority, doctl.ArgRecordWeight, doctl.ArgRecordPort} {
+			if err := require(flag); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func displayDomainRecords(c *CmdConfig, records ...do.DomainRecord) error {
 	// Check the format flag to determine if the displayer should use the short
 	// layout of the record display.The short version is used by default, but to format
@@ -0,0 +1,282 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/dnsquery"
+	"github.com/digitalocean/doctl/pkg/zonefile"
+)
+
+var (
+	// digitalOceanNameServers are the authoritative name servers for
+	// domains managed by DigitalOcean DNS.
+	digitalOceanNameServers = []string{"ns1.digitalocean.com", "ns2.digitalocean.com", "ns3.digitalocean.com"}
+	// publicResolvers are the recursive resolvers checked by default.
+	publicResolvers = []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}
+
+	// recordCheckInterval is how long records check --wait waits between
+	// rounds of queries.
+	recordCheckInterval = 10 * time.Second
+	// recordQueryTimeout limits each DNS query.
+	recordQueryTimeout = 5 * time.Second
+)
+
+// recordCheck is a DNS name and record type, and the answer DigitalOcean DNS
+// should give for it.
+type recordCheck struct {
+	name       string
+	recordType string
+	expected   []string
+}
+
+// RunRecordCheck compares the answers of name servers and resolvers with the
+// records for a domain.
+func RunRecordCheck(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+	domain := strings.ToLower(strings.TrimSuffix(c.Args[0], "."))
+
+	name, err := c.Doit.GetString(c.NS, doctl.ArgRecordCheckName)
+	if err != nil {
+		return err
+	}
+	nameServers, err := c.Doit.GetStringSlice(c.NS, doctl.ArgNameServers)
+	if err != nil {
+		return err
+	}
+	resolvers, err := c.Doit.GetStringSlice(c.NS, doctl.ArgResolvers)
+	if err != nil {
+		return err
+	}
+	wait, err := c.Doit.GetBool(c.NS, doctl.ArgCommandWait)
+	if err != nil {
+		return err
+	}
+	timeout, err := c.Doit.GetDuration(c.NS, doctl.ArgTimeout)
+	if err != nil {
+		return err
+	}
+	if len(nameServers)+len(resolvers) == 0 {
+		return fmt.Errorf("at least one of --%s or --%s is required", doctl.ArgNameServers, doctl.ArgResolvers)
+	}
+
+	records, err := c.Domains().Records(domain)
+	if err != nil {
+		return err
+	}
+	checks := recordChecks(domain, records, name)
+	if len(checks) == 0 {
+		if name != "" {
+			return fmt.Errorf("%s has no records named %q", domain, name)
+		}
+		return fmt.Errorf("%s has no records to check", domain)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		results := runRecordChecks(checks, nameServers, resolvers)
+
+		pending := 0
+		for _, r := range results {
+			if r.Status != "match" {
+				pending++
+			}
+		}
+		if pending == 0 || !wait {
+			if err := c.Display(&displayers.DNSChecks{Checks: results}); err != nil {
+				return err
+			}
+			if pending > 0 {
+				return fmt.Errorf("%d of %d answers do not match the records for %s", pending, len(results), domain)
+			}
+			return nil
+		}
+
+		if timeout > 0 && time.Now().Add(recordCheckInterval).After(deadline) {
+			if err := c.Display(&displayers.DNSChecks{Checks: results}); err != nil {
+				return err
+			}
+			return fmt.Errorf("timed out after %s with %d of %d answers not matching the records for %s", timeout, pending, len(results), domain)
+		}
+		notice("%d of %d answers do not match yet, checking again in %s", pending, len(results), recordCheckInterval)
+		time.Sleep(recordCheckInterval)
+	}
+}
+
+// runRecordChecks queries every server for every check at the same time.
+func runRecordChecks(checks []recordCheck, nameServers, resolvers []string) []displayers.DNSCheck {
+	type server struct{ addr, role string }
+	var servers []server
+	for _, s := range nameServers {
+		servers = append(servers, server{s, "authoritative"})
+	}
+	for _, s := range resolvers {
+		servers = append(servers, server{s, "resolver"})
+	}
+
+	results := make([]displayers.DNSCheck, len(servers)*len(checks))
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		for j, check := range checks {
+			wg.Add(1)
+			go func(result *displayers.DNSCheck, s server, check recordCheck) {
+				defer wg.Done()
+				*result = queryRecordCheck(s.addr, s.role, check)
+			}(&results[i*len(checks)+j], s, check)
+		}
+	}
+	wg.Wait()
+	return results
+}
+
+func queryRecordCheck(server, role string, check recordCheck) displayers.DNSCheck {
+	result := displayers.DNSCheck{
+		Server:   server,
+		Role:     role,
+		Name:     check.name,
+		Type:     check.recordType,
+		Expected: check.expected,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), recordQueryTimeout)
+	defer cancel()
+	answer, err := dnsquery.Query(ctx, server, check.name, check.recordType)
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+		return result
+	}
+
+	slices.Sort(answer)
+	result.Answer = slices.Compact(answer)
+	if slices.Equal(result.Answer, check.expected) {
+		result.Status = "match"
+	} else {
+		result.Status = "mismatch"
+	}
+	return result
+}
+
+// recordChecks groups the records for domain by name and type, optionally
+// limited to the records named name, with the answer each group should
+// produce.
+func recordChecks(domain string, records do.DomainRecords, name string) []recordCheck {
+	if name != "" {
+		name = recordCheckName(domain, name)
+	}
+
+	expected := map[[2]string][]string{}
+	for _, r := range records {
+		if _, ok := dnsquery.Types[r.Type]; !ok {
+			continue
+		}
+		fqdn := recordCheckName(domain, r.Name)
+		if name != "" && fqdn != name {
+			continue
+		}
+		k := [2]string{fqdn, r.Type}
+		expected[k] = append(expected[k], expectedAnswer(domain, r))
+	}
+
+	checks := make([]recordCheck, 0, len(expected))
+	for k, answer := range expected {
+		slices.Sort(answer)
+		checks = append(checks, recordCheck{name: k[0], recordType: k[1], expected: slices.Compact(answer)})
+	}
+	slices.SortFunc(checks, func(a, b recordCheck) int {
+		if a.name != b.name {
+			return strings.Compare(a.name, b.name)
+		}
+		return strings.Compare(a.recordType, b.recordType)
+	})
+	return checks
+}
+
+// recordCheckName returns the fully qualified form of a record name, which
+// may be relative to domain, "@", or already fully qualified.
+func recordCheckName(domain, name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "@" || name == domain || name == "" {
+		return domain + "."
+	}
+	if strings.HasSuffix(name, "."+domain) {
+		return name + "."
+	}
+	return name + "." + domain + "."
+}
+
+// expectedAnswer returns the data of a record in the form returned by
+// dnsquery.Query.
+func expectedAnswer(domain string, r do.DomainRecord) string {
+	host := func(data string) string {
+		if data == "@" {
+			return domain + "."
+		}
+		return zonefile.Fqdn(strings.ToLower(data))
+	}
+
+	switch r.Type {
+	case "A", "AAAA":
+		if ip := net.ParseIP(r.Data); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "NS":
+		return host(r.Data)
+	case "MX":
+		return fmt.Sprintf("%d %s", r.Priority, host(r.Data))
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, host(r.Data))
+	case "CAA":
+		return fmt.Sprintf("%d %s %s", r.Flags, strings.ToLower(r.Tag), r.Data)
+	case "TXT":
+		return unquoteText(r.Data)
+	}
+	return r.Data
+}
+
+var quotedString = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// unquoteText joins the character strings of TXT data that was stored as
+// several quoted strings, such as a long DKIM key. Other data is returned
+// unchanged.
+func unquoteText(data string) string {
+	if !strings.HasPrefix(data, `"`) {
+		return data
+	}
+	var b strings.Builder
+	for _, m := range quotedString.FindAllStringSubmatch(data, -1) {
+		s := m[1]
+		for i := 0; i < len(s); i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+			}
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
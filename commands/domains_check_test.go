@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/dnsquery/dnsquerytest"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCheckRecords = do.DomainRecords{
+	{DomainRecord: &godo.DomainRecord{ID: 1, Type: "SOA", Name: "@", Data: "1800"}},
+	{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "@", Data: "192.0.2.1", TTL: 300}},
+	{DomainRecord: &godo.DomainRecord{ID: 3, Type: "CNAME", Name: "www", Data: "@", TTL: 300}},
+	{DomainRecord: &godo.DomainRecord{ID: 4, Type: "MX", Name: "@", Data: "mx1.example.net", Priority: 10, TTL: 300}},
+	{DomainRecord: &godo.DomainRecord{ID: 5, Type: "TXT", Name: "@", Data: `"v=spf1 " "-all"`, TTL: 300}},
+}
+
+func newTestDNSServer(t *testing.T) *dnsquerytest.Server {
+	srv, err := dnsquerytest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(func() { srv.Close() })
+
+	srv.Set("example.com", "A", "192.0.2.1")
+	srv.Set("www.example.com", "CNAME", "example.com.")
+	srv.Set("example.com", "MX", "10 mx1.example.net.")
+	srv.Set("example.com", "TXT", "v=spf1 -all")
+	return srv
+}
+
+func TestRecordCheck(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		authoritative := newTestDNSServer(t)
+		resolver := newTestDNSServer(t)
+		resolver.Set("www.example.com", "CNAME", "old.example.com.")
+
+		tm.domains.EXPECT().Records("example.com").Return(testCheckRecords, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgNameServers, []string{authoritative.Addr})
+		config.Doit.Set(config.NS, doctl.ArgResolvers, []string{resolver.Addr})
+		config.Doit.Set(config.NS, doctl.ArgRecordCheckName, "www")
+
+		err := RunRecordCheck(config)
+		assert.EqualError(t, err, "1 of 2 answers do not match the records for example.com")
+
+		out := buf.String()
+		assert.Regexp(t, authoritative.Addr+`\s+authoritative\s+www.example.com.\s+CNAME\s+match\s+example.com.\s+example.com.`, out)
+		assert.Regexp(t, resolver.Addr+`\s+resolver\s+www.example.com.\s+CNAME\s+mismatch\s+example.com.\s+old.example.com.`, out)
+	})
+}
+
+func TestRecordCheckAllRecords(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		srv := newTestDNSServer(t)
+
+		tm.domains.EXPECT().Records("example.com").Return(testCheckRecords, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgNameServers, []string{srv.Addr})
+		config.Doit.Set(config.NS, doctl.ArgResolvers, []string{})
+
+		err := RunRecordCheck(config)
+		require.NoError(t, err)
+		assert.Regexp(t, `example.com.\s+MX\s+match\s+10 mx1.example.net.\s+10 mx1.example.net.`, buf.String())
+		assert.Regexp(t, `example.com.\s+TXT\s+match\s+v=spf1 -all\s+v=spf1 -all`, buf.String())
+		assert.NotContains(t, buf.String(), "SOA")
+		assert.NotContains(t, buf.String(), "mismatch")
+	})
+}
+
+func TestRecordCheckWait(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		defer func(d time.Duration) { recordCheckInterval = d }(recordCheckInterval)
+		recordCheckInterval = 10 * time.Millisecond
+
+		srv := newTestDNSServer(t)
+		srv.Set("example.com", "A", "192.0.2.9")
+		time.AfterFunc(50*time.Millisecond, func() { srv.Set("example.com", "A", "192.0.2.1") })
+
+		tm.domains.EXPECT().Records("example.com").Return(testCheckRecords, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgNameServers, []string{})
+		config.Doit.Set(config.NS, doctl.ArgResolvers, []string{srv.Addr})
+		config.Doit.Set(config.NS, doctl.ArgRecordCheckName, "@")
+		config.Doit.Set(config.NS, doctl.ArgCommandWait, true)
+		config.Doit.Set(config.NS, doctl.ArgTimeout, time.Minute)
+
+		err := RunRecordCheck(config)
+		require.NoError(t, err)
+		assert.Regexp(t, `example.com.\s+A\s+match\s+192.0.2.1`, buf.String())
+	})
+}
+
+func TestRecordCheckNoRecords(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.EXPECT().Records("example.com").Return(testCheckRecords, nil)
+
+		config.Args = append(config.Args, "example.com")
+		config.Doit.Set(config.NS, doctl.ArgResolvers, []string{"127.0.0.1"})
+		config.Doit.Set(config.NS, doctl.ArgRecordCheckName, "api")
+
+		err := RunRecordCheck(config)
+		assert.EqualError(t, err, `example.com has no records named "api"`)
+	})
+}
+
+func TestUnquoteText(t *testing.T) {
+	assert.Equal(t, "v=spf1 -all", unquoteText("v=spf1 -all"))
+	assert.Equal(t, `ab"c`, unquoteText(`"a" "b\"c"`))
+}
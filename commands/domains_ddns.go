@@ -0,0 +1,298 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+)
+
+const (
+	ddnsSourceURL       = "url"
+	ddnsSourceInterface = "interface"
+
+	defaultDDNSIPv4URL = "https://api.ipify.org"
+	defaultDDNSIPv6URL = "https://api6.ipify.org"
+)
+
+// ddnsRequestTimeout limits each request for the public address.
+var ddnsRequestTimeout = 30 * time.Second
+
+// ddnsUpdater keeps the A or AAAA record for a name pointed at the current
+// public address of this machine.
+type ddnsUpdater struct {
+	c      *CmdConfig
+	domain string
+	name   string
+	ttl    int
+
+	source    string
+	iface     string
+	ipv4URL   string
+	ipv6URL   string
+	families  []string
+	addresses map[string]string
+}
+
+// RunRecordDDNS updates the A and AAAA records for a name with the public
+// address of this machine, once or every interval.
+func RunRecordDDNS(c *CmdConfig) error {
+	if len(c.Args) < 2 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	if len(c.Args) > 2 {
+		return doctl.NewTooManyArgsErr(c.NS)
+	}
+
+	u := &ddnsUpdater{
+		c:         c,
+		domain:    strings.ToLower(strings.TrimSuffix(c.Args[0], ".")),
+		name:      c.Args[1],
+		addresses: map[string]string{},
+	}
+
+	interval, err := c.Doit.GetDuration(c.NS, doctl.ArgDDNSInterval)
+	if err != nil {
+		return err
+	}
+	u.ttl, err = c.Doit.GetInt(c.NS, doctl.ArgRecordTTL)
+	if err != nil {
+		return err
+	}
+	u.source, err = c.Doit.GetString(c.NS, doctl.ArgDDNSIPSource)
+	if err != nil {
+		return err
+	}
+	u.iface, err = c.Doit.GetString(c.NS, doctl.ArgDDNSInterface)
+	if err != nil {
+		return err
+	}
+	u.ipv4URL, err = c.Doit.GetString(c.NS, doctl.ArgDDNSIPv4URL)
+	if err != nil {
+		return err
+	}
+	u.ipv6URL, err = c.Doit.GetString(c.NS, doctl.ArgDDNSIPv6URL)
+	if err != nil {
+		return err
+	}
+	ipv4, err := c.Doit.GetBool(c.NS, doctl.ArgDDNSIPv4)
+	if err != nil {
+		return err
+	}
+	ipv6, err := c.Doit.GetBool(c.NS, doctl.ArgDDNSIPv6)
+	if err != nil {
+		return err
+	}
+
+	switch u.source {
+	case ddnsSourceURL:
+	case ddnsSourceInterface:
+		if u.iface == "" {
+			return fmt.Errorf("--%s %s requires --%s", doctl.ArgDDNSIPSource, ddnsSourceInterface, doctl.ArgDDNSInterface)
+		}
+	default:
+		return fmt.Errorf("--%s %q is not valid; valid values are %s and %s", doctl.ArgDDNSIPSource, u.source, ddnsSourceURL, ddnsSourceInterface)
+	}
+	if ipv4 {
+		u.families = append(u.families, "A")
+	}
+	if ipv6 {
+		u.families = append(u.families, "AAAA")
+	}
+	if len(u.families) == 0 {
+		return fmt.Errorf("at least one of --%s or --%s is required", doctl.ArgDDNSIPv4, doctl.ArgDDNSIPv6)
+	}
+	if interval < 0 {
+		return fmt.Errorf("--%s must not be negative", doctl.ArgDDNSInterval)
+	}
+
+	if interval == 0 {
+		return u.update(context.Background())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return u.run(ctx, interval)
+}
+
+// run updates the records every interval until ctx is done. Errors are
+// logged so that a temporary outage does not stop the updater.
+func (u *ddnsUpdater) run(ctx context.Context, interval time.Duration) error {
+	u.logf("updating %s every %s", u.fqdn(), interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := u.update(ctx); err != nil && ctx.Err() == nil {
+			u.logf("error: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			u.logf("stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// update points each record type at the current public address. The
+// records are only read from the API when the address differs from the
+// last one seen.
+func (u *ddnsUpdater) update(ctx context.Context) error {
+	var errs []error
+	for _, recordType := range u.families {
+		addr, err := u.publicAddress(ctx, recordType)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to find the public address for the %s record: %w", recordType, err))
+			continue
+		}
+		if addr == u.addresses[recordType] {
+			continue
+		}
+		if err := u.apply(recordType, addr); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update the %s record: %w", recordType, err))
+			continue
+		}
+		u.addresses[recordType] = addr
+	}
+	return errors.Join(errs...)
+}
+
+// apply creates or updates the record of recordType for the name.
+func (u *ddnsUpdater) apply(recordType, addr string) error {
+	ds := u.c.Domains()
+	records, err := ds.Records(u.domain)
+	if err != nil {
+		return err
+	}
+
+	var matches []do.DomainRecord
+	for _, r := range records {
+		if r.Type == recordType && recordCheckName(u.domain, r.Name) == u.fqdn() {
+			matches = append(matches, r)
+		}
+	}
+
+	req := &do.DomainRecordEditRequest{Type: recordType, Name: u.name, Data: addr, TTL: u.ttl}
+	if len(matches) == 0 {
+		if _, err := ds.CreateRecord(u.domain, req); err != nil {
+			return err
+		}
+		u.logf("%s %s created with %s", u.fqdn(), recordType, addr)
+		return nil
+	}
+
+	if len(matches) > 1 {
+		u.logf("warning: %s has %d %s records; only record %d is updated", u.fqdn(), len(matches), recordType, matches[0].ID)
+	}
+	r := matches[0]
+	if r.Data == addr {
+		u.logf("%s %s is %s", u.fqdn(), recordType, addr)
+		return nil
+	}
+	req.Name = r.Name
+	req.TTL = r.TTL
+	if _, err := ds.EditRecord(u.domain, r.ID, req); err != nil {
+		return err
+	}
+	u.logf("%s %s changed from %s to %s", u.fqdn(), recordType, r.Data, addr)
+	return nil
+}
+
+// publicAddress returns the current address of this machine for the record
+// type, from the configured URL or network interface.
+func (u *ddnsUpdater) publicAddress(ctx context.Context, recordType string) (string, error) {
+	if u.source == ddnsSourceInterface {
+		iface, err := net.InterfaceByName(u.iface)
+		if err != nil {
+			return "", err
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return "", err
+		}
+		return interfaceAddress(addrs, recordType)
+	}
+
+	url := u.ipv4URL
+	if recordType == "AAAA" {
+		url = u.ipv6URL
+	}
+	ctx, cancel := context.WithTimeout(ctx, ddnsRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil || (ip.To4() != nil) != (recordType == "A") {
+		return "", fmt.Errorf("%s did not return an IPv%s address", url, ipVersion(recordType))
+	}
+	return ip.String(), nil
+}
+
+// interfaceAddress returns the first public address of the family used by
+// recordType. Private addresses, such as 10.0.0.0/8 and fd00::/8, are skipped
+// because they cannot be reached from the internet.
+func interfaceAddress(addrs []net.Addr, recordType string) (string, error) {
+	for _, a := range addrs {
+		ipnet, ok := a.(*net.IPNet)
+		if !ok || !ipnet.IP.IsGlobalUnicast() || ipnet.IP.IsPrivate() {
+			continue
+		}
+		if (ipnet.IP.To4() != nil) == (recordType == "A") {
+			return ipnet.IP.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no public IPv%s address found", ipVersion(recordType))
+}
+
+func ipVersion(recordType string) string {
+	if recordType == "AAAA" {
+		return "6"
+	}
+	return "4"
+}
+
+func (u *ddnsUpdater) fqdn() string {
+	return recordCheckName(u.domain, u.name)
+}
+
+func (u *ddnsUpdater) logf(format string, args ...any) {
+	fmt.Fprintf(u.c.Out, "%s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestIPServer(t *testing.T, addr *atomic.Value) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, addr.Load())
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRecordDDNSUpdate(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var addr atomic.Value
+		addr.Store("203.0.113.7")
+		srv := newTestIPServer(t, &addr)
+
+		tm.domains.EXPECT().Records("example.com").Return(do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "@", Data: "203.0.113.1", TTL: 1800}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "home", Data: "203.0.113.1", TTL: 60}},
+		}, nil)
+		tm.domains.EXPECT().EditRecord("example.com", 2, &do.DomainRecordEditRequest{
+			Type: "A", Name: "home", Data: "203.0.113.7", TTL: 60,
+		}).Return(&testRecord, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com", "home.example.com")
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPSource, ddnsSourceURL)
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPv4URL, srv.URL)
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPv4, true)
+		config.Doit.Set(config.NS, doctl.ArgRecordTTL, 300)
+
+		err := RunRecordDDNS(config)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "home.example.com. A changed from 203.0.113.1 to 203.0.113.7\n")
+	})
+}
+
+func TestRecordDDNSCreate(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var addr atomic.Value
+		addr.Store("2001:db8::7")
+		srv := newTestIPServer(t, &addr)
+
+		tm.domains.EXPECT().Records("example.com").Return(do.DomainRecords{}, nil)
+		tm.domains.EXPECT().CreateRecord("example.com", &do.DomainRecordEditRequest{
+			Type: "AAAA", Name: "home", Data: "2001:db8::7", TTL: 300,
+		}).Return(&testRecord, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com", "home")
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPSource, ddnsSourceURL)
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPv6URL, srv.URL)
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPv6, true)
+		config.Doit.Set(config.NS, doctl.ArgRecordTTL, 300)
+
+		err := RunRecordDDNS(config)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "home.example.com. AAAA created with 2001:db8::7\n")
+	})
+}
+
+func TestRecordDDNSWrongFamily(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var addr atomic.Value
+		addr.Store("2001:db8::7")
+		srv := newTestIPServer(t, &addr)
+
+		config.Args = append(config.Args, "example.com", "home")
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPSource, ddnsSourceURL)
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPv4URL, srv.URL)
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPv4, true)
+
+		err := RunRecordDDNS(config)
+		assert.EqualError(t, err, "failed to find the public address for the A record: "+srv.URL+" did not return an IPv4 address")
+	})
+}
+
+func TestRecordDDNSInvalidFlags(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "example.com", "home")
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPSource, ddnsSourceInterface)
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPv4, true)
+
+		err := RunRecordDDNS(config)
+		assert.EqualError(t, err, "--ip-source interface requires --interface")
+
+		config.Doit.Set(config.NS, doctl.ArgDDNSIPSource, "dns")
+		err = RunRecordDDNS(config)
+		assert.EqualError(t, err, `--ip-source "dns" is not valid; valid values are url and interface`)
+	})
+}
+
+func TestRecordDDNSRun(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var addr atomic.Value
+		addr.Store("203.0.113.1")
+		srv := newTestIPServer(t, &addr)
+
+		// The records are read once at start, and again only after the
+		// address changes.
+		tm.domains.EXPECT().Records("example.com").Return(do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "home", Data: "203.0.113.1", TTL: 60}},
+		}, nil).Times(2)
+		edited := make(chan struct{})
+		tm.domains.EXPECT().EditRecord("example.com", 2, gomock.Any()).DoAndReturn(
+			func(string, int, *do.DomainRecordEditRequest) (*do.DomainRecord, error) {
+				close(edited)
+				return &testRecord, nil
+			})
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		u := &ddnsUpdater{
+			c:         config,
+			domain:    "example.com",
+			name:      "home",
+			source:    ddnsSourceURL,
+			ipv4URL:   srv.URL,
+			families:  []string{"A"},
+			addresses: map[string]string{},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- u.run(ctx, 10*time.Millisecond) }()
+
+		time.Sleep(50 * time.Millisecond)
+		addr.Store("203.0.113.9")
+		select {
+		case <-edited:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the record was not updated")
+		}
+		cancel()
+		require.NoError(t, <-done)
+
+		out := buf.String()
+		assert.Contains(t, out, "updating home.example.com. every 10ms\n")
+		assert.Contains(t, out, "home.example.com. A is 203.0.113.1\n")
+		assert.Contains(t, out, "home.example.com. A changed from 203.0.113.1 to 203.0.113.9\n")
+		assert.Contains(t, out, "stopped\n")
+	})
+}
+
+func TestInterfaceAddress(t *testing.T) {
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+		&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("192.168.1.20"), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.ParseIP("fd00::20"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("198.51.100.4"), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.ParseIP("2001:db8::4"), Mask: net.CIDRMask(64, 128)},
+	}
+
+	ip, err := interfaceAddress(addrs, "A")
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.4", ip)
+
+	ip, err = interfaceAddress(addrs, "AAAA")
+	require.NoError(t, err)
+	assert.Equal(t, "2001:db8::4", ip)
+
+	_, err = interfaceAddress(addrs[:4], "A")
+	assert.EqualError(t, err, "no public IPv4 address found")
+
+	_, err = interfaceAddress(addrs[:4], "AAAA")
+	assert.EqualError(t, err, "no public IPv6 address found")
+}
@@ -14,12 +14,19 @@ limitations under the License.
 package commands
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/zonefile"
 	"github.com/digitalocean/godo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 var (
@@ -34,7 +41,7 @@ var (
 func TestDomainsCommand(t *testing.T) {
 	cmd := Domain()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "create", "list", "get", "delete", "records")
+	assertCommandNames(t, cmd, "create", "list", "get", "delete", "import", "export", "records")
 }
 
 func TestDomainsCreate(t *testing.T) {
@@ -169,3 +176,204 @@ func TestRecordsUpdate(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 3600
+@	IN	SOA	ns1.example.net. hostmaster.example.com. 1 7200 3600 1209600 3600
+@	IN	NS	ns1.example.net.
+@	300	IN	A	192.0.2.1
+www	IN	CNAME	@
+@	IN	MX	10 mx1.example.net.
+@	IN	HINFO	"PC" "Linux"
+`
+
+func writeTestZoneFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "zone.txt")
+	require.NoError(t, os.WriteFile(path, []byte(testZoneFile), 0600))
+	return path
+}
+
+func TestDomainsImportPlan(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.EXPECT().List().Return(testDomainList, nil)
+		tm.domains.EXPECT().Records("example.com").Return(do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "@", Data: "192.0.2.1", TTL: 1800}},
+			{DomainRecord: &godo.DomainRecord{ID: 3, Type: "CNAME", Name: "www", Data: "@", TTL: 3600}},
+			{DomainRecord: &godo.DomainRecord{ID: 4, Type: "A", Name: "old", Data: "192.0.2.9", TTL: 3600}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com", writeTestZoneFile(t))
+		config.Doit.Set(config.NS, doctl.ArgPlan, true)
+		config.Doit.Set(config.NS, doctl.ArgPrune, true)
+
+		err := RunDomainImport(config)
+		require.NoError(t, err)
+
+		expected := "~ record example.com A 192.0.2.1\n" +
+			"    ttl:\n" +
+			"      - 1800\n" +
+			"      + 300\n" +
+			"+ record example.com MX mx1.example.net.\n" +
+			"- record old.example.com A 192.0.2.9\n"
+		assert.Contains(t, buf.String(), expected)
+		assert.Contains(t, buf.String(), "line 4: skipping the NS record for example.com")
+		assert.Contains(t, buf.String(), "line 8: skipping @ HINFO")
+		assert.Contains(t, buf.String(), "Plan: 1 to create, 1 to update, 1 to delete.")
+	})
+}
+
+func TestDomainsImport(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.EXPECT().List().Return(do.Domains{}, nil)
+		gomock.InOrder(
+			tm.domains.EXPECT().Create(&godo.DomainCreateRequest{Name: "example.com"}).Return(&testDomain, nil),
+			tm.domains.EXPECT().CreateRecord("example.com", gomock.Any()).Return(&testRecord, nil).Times(3),
+		)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com", writeTestZoneFile(t))
+		config.Doit.Set(config.NS, doctl.ArgForce, true)
+
+		err := RunDomainImport(config)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Created domain example.com\n")
+		assert.Contains(t, buf.String(), "Created record www.example.com CNAME @\n")
+	})
+}
+
+func TestDomainsImport_ParseError(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := filepath.Join(t.TempDir(), "zone.txt")
+		require.NoError(t, os.WriteFile(path, []byte("@ A 2001:db8::1\n"), 0600))
+		config.Args = append(config.Args, "example.com", path)
+
+		err := RunDomainImport(config)
+		assert.EqualError(t, err, "failed to parse "+path+`: line 1: A record: invalid address "2001:db8::1"`)
+	})
+}
+
+func TestDomainsExport(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.domains.EXPECT().Get("example.com").Return(&do.Domain{Domain: &godo.Domain{Name: "example.com", TTL: 1800}}, nil)
+		tm.domains.EXPECT().Records("example.com").Return(do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "SOA", Name: "@", Data: "1800"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "@", Data: "192.0.2.1", TTL: 1800}},
+			{DomainRecord: &godo.DomainRecord{ID: 3, Type: "CNAME", Name: "www", Data: "@", TTL: 3600}},
+			{DomainRecord: &godo.DomainRecord{ID: 4, Type: "MX", Name: "@", Data: "mx1.example.net", Priority: 10, TTL: 1800}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "example.com")
+
+		err := RunDomainExport(config)
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "SOA")
+
+		records, err := zonefile.Parse(&buf, "example.com")
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, zonefile.Record{Name: "@", TTL: 1800, Type: "MX", Data: "mx1.example.net.", Priority: 10, Line: 4}, records[1])
+		assert.Equal(t, "example.com.", records[2].Data)
+		assert.Equal(t, 3600, records[2].TTL)
+	})
+}
+
+func TestRecordsCreate_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]any
+		err   string
+	}{
+		{
+			name:  "unknown type",
+			flags: map[string]any{doctl.ArgRecordType: "PTR", doctl.ArgRecordData: "host.example.com."},
+			err:   `--record-type "PTR" is not supported; valid values are A, AAAA, CAA, CNAME, MX, NS, SOA, SRV, TXT`,
+		},
+		{
+			name:  "A record with IPv6 address",
+			flags: map[string]any{doctl.ArgRecordType: "A", doctl.ArgRecordData: "2001:db8::1"},
+			err:   `--record-data "2001:db8::1" is not an IPv4 address`,
+		},
+		{
+			name:  "CNAME without trailing dot",
+			flags: map[string]any{doctl.ArgRecordType: "cname", doctl.ArgRecordName: "www", doctl.ArgRecordData: "example.org"},
+			err:   `--record-data "example.org" must be a fully qualified host name ending in a dot, such as "example.org.", or @ for the domain itself`,
+		},
+		{
+			name:  "MX without priority",
+			flags: map[string]any{doctl.ArgRecordType: "MX", doctl.ArgRecordData: "mx1.example.org."},
+			err:   "MX records require --record-priority",
+		},
+		{
+			name:  "SRV without port",
+			flags: map[string]any{doctl.ArgRecordType: "SRV", doctl.ArgRecordName: "_sip._tcp", doctl.ArgRecordData: "sip.example.org.", doctl.ArgRecordPriority: 10, doctl.ArgRecordWeight: 5},
+			err:   "SRV records require --record-port",
+		},
+		{
+			name:  "CAA with bad tag",
+			flags: map[string]any{doctl.ArgRecordType: "CAA", doctl.ArgRecordData: "letsencrypt.org", doctl.ArgRecordTag: "issues"},
+			err:   `--record-tag "issues" is not valid; valid values are issue, issuewild, and iodef`,
+		},
+		{
+			name:  "CAA flags out of range",
+			flags: map[string]any{doctl.ArgRecordType: "CAA", doctl.ArgRecordData: "letsencrypt.org", doctl.ArgRecordTag: "issue", doctl.ArgRecordFlags: 256},
+			err:   "--record-flags must be between 0 and 255",
+		},
+		{
+			name:  "missing data",
+			flags: map[string]any{doctl.ArgRecordType: "TXT"},
+			err:   "TXT records require --record-data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+				for k, v := range tt.flags {
+					config.Doit.Set(config.NS, k, v)
+				}
+				config.Args = append(config.Args, "example.com")
+
+				err := RunRecordCreate(config)
+				assert.EqualError(t, err, tt.err)
+			})
+		})
+	}
+}
+
+func TestRecordsCreate_SplitsLongTXT(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		value := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 300)
+		dcer := &do.DomainRecordEditRequest{
+			Type: "TXT",
+			Name: "dkim._domainkey",
+			Data: `"` + value[:255] + `" "` + value[255:] + `"`,
+		}
+		tm.domains.EXPECT().CreateRecord("example.com", dcer).Return(&testRecord, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgRecordType, "TXT")
+		config.Doit.Set(config.NS, doctl.ArgRecordName, "dkim._domainkey")
+		config.Doit.Set(config.NS, doctl.ArgRecordData, value)
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordCreate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRecordsUpdate_Validation(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgRecordID, 1)
+		config.Doit.Set(config.NS, doctl.ArgRecordType, "CNAME")
+		config.Doit.Set(config.NS, doctl.ArgRecordData, "example.org")
+		config.Args = append(config.Args, "example.com")
+
+		err := RunRecordUpdate(config)
+		assert.ErrorContains(t, err, `--record-data "example.org" must be a fully qualified host name`)
+	})
+}
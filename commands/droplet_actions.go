@@ -65,31 +65,31 @@ You can use Droplet actions to perform tasks on a Droplet, such as rebooting, re
 	}
 
 	cmdDropletActionGet := CmdBuilder(cmd, RunDropletActionGet, "get <droplet-id>", "Retrieve a specific Droplet action", `Retrieves information about an action performed on a Droplet, including its status, type, and completion time.`, Writer,
-		aliasOpt("g"), displayerType(&displayers.Action{}))
+		aliasOpt("g"), displayerType(&displayers.Action{}), fromFileOpt())
 	AddIntFlag(cmdDropletActionGet, doctl.ArgActionID, "", 0, "Action ID", requiredOpt())
 	cmdDropletActionGet.Example = `The following example retrieves information about an action, with the ID ` + "`" + `1978716488` + "`" + `, performed on a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action get 1978716488 --action-id 386734086`
 
 	cmdDropletActionEnableBackups := CmdBuilder(cmd, RunDropletActionEnableBackups,
 		"enable-backups <droplet-id>", "Enable backups on a Droplet", `Enables backups on a Droplet. This automatically creates and stores a disk image of the Droplet at weekly intervals.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionEnableBackups, doctl.ArgCommandWait, "", false, "Wait for action to complete")
 	cmdDropletActionEnableBackups.Example = `The following example enables backups on a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action enable-backups 386734086`
 
 	cmdDropletActionDisableBackups := CmdBuilder(cmd, RunDropletActionDisableBackups,
 		"disable-backups <droplet-id>", "Disable backups on a Droplet", `Disables backups on a Droplet. This does not delete existing backups.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionDisableBackups, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionDisableBackups.Example = `The following example disables backups on a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action disable-backups 386734086`
 
 	cmdDropletActionReboot := CmdBuilder(cmd, RunDropletActionReboot,
 		"reboot <droplet-id>", "Reboot a Droplet", `Reboots a Droplet. A reboot action is an attempt to reboot the Droplet in a graceful way, similar to using the reboot command from the Droplet's console.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionReboot, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionReboot.Example = `The following example reboots a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action reboot 386734086`
 
 	cmdDropletActionPowerCycle := CmdBuilder(cmd, RunDropletActionPowerCycle,
 		"power-cycle <droplet-id>", "Powercycle a Droplet", `Powercycles a Droplet. A powercycle action is similar to pushing the reset button on a physical machine.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionPowerCycle, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionPowerCycle.Example = `The following example powercycles a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action power-cycle 386734086`
 
@@ -99,7 +99,7 @@ You can use Droplet actions to perform tasks on a Droplet, such as rebooting, re
 A shutdown action is an attempt to shutdown the Droplet in a graceful way, similar to using the shutdown command from the Droplet's console. Since a shutdown command can fail, this action guarantees that the command is issued, not that it succeeds. The preferred way to turn off a Droplet is to attempt a shutdown, with a reasonable timeout, followed by a `+"`"+`doctl compute droplet-action power_off`+"`"+` action to ensure the Droplet is off.
 		
 Droplets that are powered off are still billable. To stop incurring charges on a Droplet, destroy it.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionShutdown, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionShutdown.Example = `The following example shuts down a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action shutdown 386734086`
 
@@ -109,13 +109,13 @@ Droplets that are powered off are still billable. To stop incurring charges on a
 A `+"`"+`power_off`+"`"+` event is a hard shutdown and should only be used if the shutdown action is not successful. It is similar to cutting the power on a server and could lead to complications.
 
 Droplets that are powered off are still billable. To stop incurring charges on a Droplet, destroy it.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionPowerOff, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionPowerOff.Example = `The following example powers off a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action power-off 386734086`
 
 	cmdDropletActionPowerOn := CmdBuilder(cmd, RunDropletActionPowerOn,
 		"power-on <droplet-id>", "Power on a Droplet", `Powers on a Droplet. This is similar to pressing the power button on a physical machine.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionPowerOn, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionPowerOn.Example = `The following example powers on a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action power-on 386734086`
 
@@ -123,7 +123,7 @@ Droplets that are powered off are still billable. To stop incurring charges on a
 		"password-reset <droplet-id>", "Reset the root password for a Droplet", `Initiates a root password reset on a Droplet. We provide a new password for the Droplet via the accounts email address. The password must be changed after first use. 
 
 This also powercycles the Droplet.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionPasswordReset, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionPasswordReset.Example = `The following example resets the root password for a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action password-reset 386734086`
 
@@ -131,7 +131,7 @@ This also powercycles the Droplet.`, Writer,
 		"enable-ipv6 <droplet-id>", "Enable IPv6 on a Droplet", `Enables IPv6 networking on a Droplet. When executed, we automatically assign an IPv6 address to the Droplet. 
 
 The Droplet may require additional network configuration to properly use the new IPv6 address. For more information, see: https://docs.digitalocean.com/products/networking/ipv6/how-to/enable`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionEnableIPv6, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionEnableIPv6.Example = `The following example enables IPv6 on a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action enable-ipv6 386734086`
 
@@ -141,7 +141,7 @@ The Droplet may require additional network configuration to properly use the new
 All Droplets created after 1 October 2020 are provided a private IP address and placed into a VPC network by default. You can use this command to enable private networking on a Droplet that was created before 1 October 2020 and was not already in a VPC network.
 
 Once you have manually enabled private networking for a Droplet, the Droplet requires additional internal network configuration for it to become accessible through the VPC network. For more information, see: https://docs.digitalocean.com/products/networking/vpc/how-to/enable`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionEnablePrivateNetworking, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionEnablePrivateNetworking.Example = `The following example enables private networking on a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet-action enable-private-networking 386734086`
 
@@ -149,7 +149,7 @@ Once you have manually enabled private networking for a Droplet, the Droplet req
 		"restore <droplet-id>", "Restore a Droplet from a backup", `Restores a Droplet from a backup image. You must pass an image ID that is a backup of the current Droplet instance. The operation leaves any embedded SSH keys intact.
 		
 		To retrieve a list of backup images, use the `+"`"+`doctl compute image list`+"`"+` command.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddIntFlag(cmdDropletActionRestore, doctl.ArgImageID, "", 0, "The ID of the image to restore the Droplet from", requiredOpt())
 	AddBoolFlag(cmdDropletActionRestore, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionRestore.Example = `The following example restores a Droplet with the ID ` + "`" + `386734086` + "`" + ` from a backup image with the ID ` + "`" + `146288445` + "`" + `: doctl compute droplet-action restore 386734086 --image-id 146288445`
@@ -165,7 +165,7 @@ For a list of size slugs, use the ` + "`" + `doctl compute size list` + "`" + `
 This command automatically powers off the Droplet before resizing it.`
 	cmdDropletActionResize := CmdBuilder(cmd, RunDropletActionResize,
 		"resize <droplet-id>", "Resize a Droplet", dropletResizeDesc, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddBoolFlag(cmdDropletActionResize, doctl.ArgResizeDisk, "", false, "Resize the Droplet's disk size in addition to its RAM and CPUs")
 	AddStringFlag(cmdDropletActionResize, doctl.ArgSizeSlug, "", "", "A slug indicating the new size for the Droplet, for example `s-2vcpu-2gb`. Run `doctl compute size list` for a list of valid sizes.", requiredOpt())
 	AddBoolFlag(cmdDropletActionResize, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
@@ -175,14 +175,14 @@ This command automatically powers off the Droplet before resizing it.`
 		"rebuild <droplet-id>", "Rebuild a Droplet", `Rebuilds a Droplet from an image, such as an Ubuntu base image or a backup image of the Droplet. Set the image attribute to an image ID or slug.
 
 To retrieve a list of images on your account, use the `+"`"+`doctl compute image list`+"`"+` command. To retrieve a list of base images, use the `+"`"+`doctl compute image list-distribution`+"`"+` command.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddStringFlag(cmdDropletActionRebuild, doctl.ArgImage, "", "", "An image ID or slug", requiredOpt())
 	AddBoolFlag(cmdDropletActionRebuild, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionRebuild.Example = `The following example rebuilds a Droplet with the ID ` + "`" + `386734086` + "`" + ` from the image with the ID ` + "`" + `146288445` + "`" + `: doctl compute droplet-action rebuild 386734086 --image 146288445`
 
 	cmdDropletActionRename := CmdBuilder(cmd, RunDropletActionRename,
 		"rename <droplet-id>", "Rename a Droplet", `Renames a Droplet. When using a Fully Qualified Domain Name (FQDN) this also updates the Droplet's pointer (PTR) record.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddStringFlag(cmdDropletActionRename, doctl.ArgDropletName, "", "", "The new name for the Droplet", requiredOpt())
 	AddBoolFlag(cmdDropletActionRename, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 	cmdDropletActionRename.Example = `The following example renames a Droplet with the ID ` + "`" + `386734086` + "`" + ` to ` + "`" + `example.com` + "`" + ` an FQDN: doctl compute droplet-action rename 386734086 --droplet-name example.com`
@@ -191,7 +191,7 @@ To retrieve a list of images on your account, use the `+"`"+`doctl compute image
 		"change-kernel <droplet-id>", "Change a Droplet's kernel", `Changes a Droplet's kernel. This is only available for externally managed kernels. All Droplets created after 17 March 2017 have internally managed kernels by default.
 		
 Use the `+"`"+`doctl compute droplet kernels <droplet-id>`+"`"+` command to retrieve a list of kernels for the Droplet.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddIntFlag(cmdDropletActionChangeKernel, doctl.ArgKernelID, "", 0, "Kernel ID", requiredOpt())
 	AddBoolFlag(cmdDropletActionChangeKernel, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 
@@ -199,7 +199,7 @@ Use the `+"`"+`doctl compute droplet kernels <droplet-id>`+"`"+` command to retr
 		"snapshot <droplet-id>", "Take a Droplet snapshot", `Takes a snapshot of a Droplet. Snapshots are complete disk images that contain all of the data on a Droplet at the time of the snapshot. This can be useful for restoring and rebuilding Droplets.
 		
 We recommend that you power off the Droplet before taking a snapshot to ensure data consistency.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddStringFlag(cmdDropletActionSnapshot, doctl.ArgSnapshotName, "", "", "The snapshot's name", requiredOpt())
 	AddBoolFlag(cmdDropletActionSnapshot, doctl.ArgCommandWait, "", false, "Instruct the terminal to wait for the action to complete before returning access to the user")
 
@@ -16,7 +16,6 @@ package commands
 import (
 	"fmt"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -58,11 +57,11 @@ func Droplet() *Command {
 - The IDs of block storage volumes attached to the Droplet
 	`
 	cmdDropletActions := CmdBuilder(cmd, RunDropletActions, "actions <droplet-id>", "List Droplet actions", `Retrieves a list of previous actions taken on the Droplet, such as reboots, resizes, and snapshots actions.`, Writer,
-		aliasOpt("a"), displayerType(&displayers.Action{}))
+		aliasOpt("a"), displayerType(&displayers.Action{}), fromFileOpt())
 	cmdDropletActions.Example = `The following example retrieves a list of actions taken on a Droplet with the ID ` + "`" + `386734086` + "`" + `. Additionally, the command uses the ` + "`" + `--format` + "`" + ` flag to return only the ID, status, and type of action: doctl compute droplet actions 386734086 --format ID,Status,Type`
 
 	cmdDropletBackups := CmdBuilder(cmd, RunDropletBackups, "backups <droplet-id>", "List Droplet backups", `Lists backup images for a Droplet, including each image's slug and ID.`, Writer,
-		aliasOpt("b"), displayerType(&displayers.Image{}))
+		aliasOpt("b"), displayerType(&displayers.Image{}), fromFileOpt())
 	cmdDropletBackups.Example = `The following example retrieves a list of backups for a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet backups 386734086`
 
 	dropletCreateLongDesc := `Creates a new Droplet on your account. The command requires values for the ` + "`" + `--size` + "`" + `, and ` + "`" + `--image` + "`" + ` flags.
@@ -72,7 +71,7 @@ To retrieve a list of size slugs, use the ` + "`" + `doctl compute size list` +
 If you do not specify a region, the Droplet is created in the default region for your account. If you do not specify any SSH keys, we email a temporary password to your account's email address.`
 
 	cmdDropletCreate := CmdBuilder(cmd, RunDropletCreate, "create <droplet-name>...", "Create a new Droplet", dropletCreateLongDesc, Writer,
-		aliasOpt("c"), displayerType(&displayers.Droplet{}))
+		aliasOpt("c"), displayerType(&displayers.Droplet{}), fromFileOpt())
 	AddStringSliceFlag(cmdDropletCreate, doctl.ArgSSHKeys, "", []string{}, "A list of SSH key IDs or fingerprints to embed in the Droplet's root account upon creation")
 	AddStringFlag(cmdDropletCreate, doctl.ArgUserData, "", "", "A shell script to run on the Droplet's first boot")
 	AddStringFlag(cmdDropletCreate, doctl.ArgUserDataFile, "", "", "The path to a file containing a shell script or Cloud-init YAML file to run on the Droplet's first boot. Example: `path/to/file.yaml`")
@@ -95,18 +94,19 @@ If you do not specify a region, the Droplet is created in the default region for
 	cmdDropletCreate.Example = `The following example creates a Droplet named ` + "`" + `example-droplet` + "`" + ` with a two vCPUs, two GiB of RAM, and 20 GBs of disk space. The Droplet is created in the ` + "`" + `nyc1` + "`" + ` region and is based on the ` + "`" + `ubuntu-20-04-x64` + "`" + ` image. Additionally, the command uses the ` + "`" + `--user-data` + "`" + ` flag to run a Bash script the first time the Droplet boots up: doctl compute droplet create example-droplet --size s-2vcpu-2gb --image ubuntu-20-04-x64 --region nyc1 --user-data $'#!/bin/bash\n touch /root/example.txt; sudo apt update;sudo snap install doctl'`
 
 	cmdRunDropletDelete := CmdBuilder(cmd, RunDropletDelete, "delete <droplet-id|droplet-name>...", "Permanently delete a Droplet", `Permanently deletes a Droplet. This is irreversible.`, Writer,
-		aliasOpt("d", "del", "rm"))
+		aliasOpt("d", "del", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunDropletDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Deletes the Droplet without a confirmation prompt")
 	AddStringFlag(cmdRunDropletDelete, doctl.ArgTagName, "", "", "Tag name")
+	addFanOutFlags(cmdRunDropletDelete)
 	cmdRunDropletDelete.Example = `The following example deletes a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet delete 386734086`
 
 	cmdRunDropletGet := CmdBuilder(cmd, RunDropletGet, "get <droplet-id|droplet-name>", "Retrieve information about a Droplet", `Retrieves information about a Droplet, including:`+dropletDetails, Writer,
-		aliasOpt("g"), displayerType(&displayers.Droplet{}))
+		aliasOpt("g"), displayerType(&displayers.Droplet{}), fromFileOpt())
 	AddStringFlag(cmdRunDropletGet, doctl.ArgTemplate, "", "", "Go template format. Sample values: `{{.ID}}`, `{{.Name}}`, `{{.Memory}}`, `{{.Region.Name}}`, `{{.Image}}`, `{{.Tags}}`")
 	cmdRunDropletGet.Example = `The following example retrieves information about a Droplet with the ID ` + "`" + `386734086` + "`" + `. The command also uses the ` + "`" + `--format` + "`" + ` flag to only return the Droplet's name, ID, and public IPv4 address: doctl compute droplet get 386734086 --format Name,ID,PublicIPv4`
 
 	cmdDropletKernels := CmdBuilder(cmd, RunDropletKernels, "kernels <droplet-id>", "List available Droplet kernels", `Retrieves a list of all kernels available to a Droplet. This command is only available for Droplets with externally managed kernels. All Droplets created after March 2017 have internally managed kernels by default.`, Writer,
-		aliasOpt("k"), displayerType(&displayers.Kernel{}))
+		aliasOpt("k"), displayerType(&displayers.Kernel{}), fromFileOpt())
 	cmdDropletKernels.Example = `The following example retrieves a list of available kernels for a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet kernels 386734086`
 
 	cmdRunDropletList := CmdBuilder(cmd, RunDropletList, "list [GLOB]", "List Droplets on your account", `Retrieves a list of Droplets on your account, including the following information about each:`+dropletDetails, Writer,
@@ -116,22 +116,24 @@ If you do not specify a region, the Droplet is created in the default region for
 	cmdRunDropletList.Example = `The following example retrieves a list of all Droplets in the ` + "`" + `nyc1` + "`" + ` region: doctl compute droplet list --region nyc1`
 
 	cmdDropletNeighbors := CmdBuilder(cmd, RunDropletNeighbors, "neighbors <droplet-id>", "List a Droplet's neighbors on your account", `Lists your Droplets that are on the same physical hardware, including the following details:`+dropletDetails, Writer,
-		aliasOpt("n"), displayerType(&displayers.Droplet{}))
+		aliasOpt("n"), displayerType(&displayers.Droplet{}), fromFileOpt())
 	cmdDropletNeighbors.Example = `The following example retrieves a list of Droplets that are on the same physical hardware as the Droplet with the ID ` + "`" + `386734086` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to return only each Droplet's ID, name and public IPv4 address: doctl compute droplet neighbors 386734086 --format ID,Name,PublicIPv4`
 
 	cmdDropletSnapshots := CmdBuilder(cmd, RunDropletSnapshots, "snapshots <droplet-id>", "List all snapshots for a Droplet", `Retrieves a list of snapshots created from this Droplet.`, Writer,
-		aliasOpt("s"), displayerType(&displayers.Image{}))
+		aliasOpt("s"), displayerType(&displayers.Image{}), fromFileOpt())
 	cmdDropletSnapshots.Example = `The following example retrieves a list of snapshots for a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet snapshots 386734086`
 
-	cmdRunDropletTag := CmdBuilder(cmd, RunDropletTag, "tag <droplet-id|droplet-name>", "Add a tag to a Droplet", "Applies a tag to a Droplet. Specify the tag with the `--tag-name` flag.", Writer)
+	cmdRunDropletTag := CmdBuilder(cmd, RunDropletTag, "tag <droplet-id|droplet-name>...", "Add a tag to a Droplet", "Applies a tag to one or more Droplets. Specify the tag with the `--tag-name` flag.\n\nAll the Droplets are tagged in a single request, so either every Droplet is tagged or none are. For that reason, this command does not take the `--concurrency` and `--continue-on-error` flags of the other commands that act on several resources.", Writer, fromFileOpt())
 	AddStringFlag(cmdRunDropletTag, doctl.ArgTagName, "", "", "the tag name apply to the Droplet. You can use a new or existing tag.",
 		requiredOpt())
 	cmdRunDropletTag.Example = `The following example applies the tag ` + "`" + `frontend` + "`" + ` to a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet tag 386734086 --tag-name frontend`
 
-	cmdRunDropletUntag := CmdBuilder(cmd, RunDropletUntag, "untag <droplet-id|droplet-name>", "Remove a tag from a Droplet", "Removes a tag from a Droplet. Specify the tag with the `--tag-name` flag.", Writer)
+	cmdRunDropletUntag := CmdBuilder(cmd, RunDropletUntag, "untag <droplet-id|droplet-name>...", "Remove a tag from a Droplet", "Removes a tag from one or more Droplets. Specify the tag with the `--tag-name` flag.\n\nEach tag is removed from all the Droplets in a single request, so either every Droplet is untagged or none are. For that reason, this command does not take the `--concurrency` and `--continue-on-error` flags of the other commands that act on several resources.", Writer, fromFileOpt())
 	AddStringSliceFlag(cmdRunDropletUntag, doctl.ArgTagName, "", []string{}, "The tag name to remove from Droplet")
 	cmdRunDropletUntag.Example = `The following example removes the tag ` + "`" + `frontend` + "`" + ` from a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute droplet untag 386734086 --tag-name frontend`
 
+	dropletInventory(cmd)
+
 	cmd.AddCommand(dropletOneClicks())
 
 	return cmd
@@ -392,20 +394,17 @@ func RunDropletUntag(c *CmdConfig) error {
 
 	fn := func(ids []int) error {
 		urr := &godo.UntagResourcesRequest{}
-
 		for _, id := range ids {
-			for _, tagName := range tagNames {
-				r := godo.Resource{
-					ID:   strconv.Itoa(id),
-					Type: godo.DropletResourceType,
-				}
-
-				urr.Resources = append(urr.Resources, r)
+			r := godo.Resource{
+				ID:   strconv.Itoa(id),
+				Type: godo.DropletResourceType,
+			}
+			urr.Resources = append(urr.Resources, r)
+		}
 
-				err := ts.UntagResources(tagName, urr)
-				if err != nil {
-					return err
-				}
+		for _, tagName := range tagNames {
+			if err := ts.UntagResources(tagName, urr); err != nil {
+				return err
 			}
 		}
 
@@ -462,26 +461,6 @@ func extractVolumes(volumeList []string) []godo.DropletCreateVolume {
 	return volumes
 }
 
-func allInt(in []string) ([]int, error) {
-	out := make([]int, 0, len(in))
-	seen := map[string]bool{}
-
-	for _, i := range in {
-		if seen[i] {
-			continue
-		}
-
-		seen[i] = true
-
-		id, err := strconv.Atoi(i)
-		if err != nil {
-			return nil, fmt.Errorf("%s is not an int", i)
-		}
-		out = append(out, id)
-	}
-	return out, nil
-}
-
 // RunDropletDelete destroy a droplet by id.
 func RunDropletDelete(c *CmdConfig) error {
 	ds := c.Droplets()
@@ -530,12 +509,12 @@ func RunDropletDelete(c *CmdConfig) error {
 	if force || AskForConfirmDelete("Droplet", len(c.Args)) == nil {
 
 		fn := func(ids []int) error {
-			for _, id := range ids {
+			return fanOutInts(c, ids, func(id int) error {
 				if err := ds.Delete(id); err != nil {
 					return fmt.Errorf("Unable to delete Droplet %d: %v", id, err)
 				}
-			}
-			return nil
+				return nil
+			})
 		}
 		return matchDroplets(c.Args, ds, fn)
 	}
@@ -544,45 +523,23 @@ func RunDropletDelete(c *CmdConfig) error {
 
 type matchDropletsFn func(ids []int) error
 
-func matchDroplets(ids []string, ds do.DropletsService, fn matchDropletsFn) error {
-	if extractedIDs, err := allInt(ids); err == nil {
-		return fn(extractedIDs)
-	}
-
-	sum, err := buildDropletSummary(ds)
+// matchDroplets resolves Droplet IDs, names or URNs and calls fn with the
+// IDs, each included once.
+func matchDroplets(refs []string, ds do.DropletsService, fn matchDropletsFn) error {
+	ids, err := do.ResolveDropletIDs(ds, refs)
 	if err != nil {
 		return err
 	}
 
-	matchedMap := map[int]bool{}
-	for _, idStr := range ids {
-		count := sum.count[idStr]
-		if count > 1 {
-			return fmt.Errorf("There are %d Droplets with the name %q; please provide a specific Droplet ID. [%s]",
-				count, idStr, strings.Join(sum.byName[idStr], ", "))
-		}
-
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			id, ok := sum.byID[idStr]
-			if !ok {
-				return fmt.Errorf("Droplet with the name %q could not be found.", idStr)
-			}
-
-			matchedMap[id] = true
-			continue
+	seen := map[int]bool{}
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
 		}
-
-		matchedMap[id] = true
-	}
-
-	extractedIDs := make([]int, 0, len(matchedMap))
-	for id := range matchedMap {
-		extractedIDs = append(extractedIDs, id)
 	}
-
-	sort.Ints(extractedIDs)
-	return fn(extractedIDs)
+	return fn(unique)
 }
 
 // RunDropletGet returns a droplet.
@@ -750,32 +707,6 @@ func getDropletIDArg(ns string, args []string) (int, error) {
 	return strconv.Atoi(args[0])
 }
 
-type dropletSummary struct {
-	count  map[string]int
-	byID   map[string]int
-	byName map[string][]string
-}
-
-func buildDropletSummary(ds do.DropletsService) (*dropletSummary, error) {
-	list, err := ds.List()
-	if err != nil {
-		return nil, err
-	}
-
-	var sum dropletSummary
-
-	sum.count = map[string]int{}
-	sum.byID = map[string]int{}
-	sum.byName = map[string][]string{}
-	for _, d := range list {
-		sum.count[d.Name]++
-		sum.byID[d.Name] = d.ID
-		sum.byName[d.Name] = append(sum.byName[d.Name], strconv.Itoa(d.ID))
-	}
-
-	return &sum, nil
-}
-
 // kubernetesOneClicks creates the 1-click command.
 func dropletOneClicks() *Command {
 	cmd := &Command{
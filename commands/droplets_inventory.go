@@ -0,0 +1,407 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"sigs.k8s.io/yaml"
+)
+
+// Formats of the droplet inventory.
+const (
+	inventoryFormatSSHConfig   = "ssh-config"
+	inventoryFormatAnsibleINI  = "ansible-ini"
+	inventoryFormatAnsibleYAML = "ansible-yaml"
+	inventoryFormatJSON        = "json"
+)
+
+// The markers around the hosts written into an SSH config file.
+const (
+	inventoryBlockBegin = "# BEGIN doctl compute droplet inventory"
+	inventoryBlockEnd   = "# END doctl compute droplet inventory"
+)
+
+// dropletInventory creates the droplet inventory command.
+func dropletInventory(parent *Command) *Command {
+	usr, err := user.Current()
+	checkErr(err)
+
+	cmdInventory := CmdBuilder(parent, RunDropletInventory, "inventory", "Generate an SSH config or Ansible inventory from your Droplets",
+		`Generates an inventory of your Droplets for other tools to use. Each Droplet is listed with its public and private IP addresses, the user to log in as, which is root, or core on Fedora CoreOS, unless `+"`"+`--ssh-user`+"`"+` is set, and the SSH key to use.
+
+Droplets are grouped by their tags, regions and VPC networks, in groups named like `+"`"+`tag_web`+"`"+`, `+"`"+`region_nyc1`+"`"+` and `+"`"+`vpc_<vpc-uuid>`+"`"+`.
+
+The `+"`"+`--format`+"`"+` flag selects one of the following formats:
+
+- `+"`"+`ssh-config`+"`"+`: a `+"`"+`Host`+"`"+` entry for each Droplet, for `+"`"+`~/.ssh/config`+"`"+`
+- `+"`"+`ansible-ini`+"`"+`: an Ansible inventory in the INI format
+- `+"`"+`ansible-yaml`+"`"+`: an Ansible inventory in the YAML format
+- `+"`"+`json`+"`"+`: a list of the Droplets and their details
+
+With `+"`"+`--write-ssh-config`+"`"+`, the `+"`"+`Host`+"`"+` entries are written into a block of your SSH config file that doctl manages. The block is first added before the file's first `+"`"+`Host`+"`"+` or `+"`"+`Match`+"`"+` line, so that patterns such as `+"`"+`Host *`+"`"+` do not override its settings. Running the command again replaces the block, and the rest of the file is left as it is.
+
+Droplets with no address to connect to, or whose names contain whitespace, are skipped with a warning.`, Writer)
+	AddStringFlag(cmdInventory, doctl.ArgFormat, "", inventoryFormatSSHConfig, `The format of the inventory; one of "ssh-config", "ansible-ini", "ansible-yaml" or "json"`)
+	AddStringFlag(cmdInventory, doctl.ArgTagName, "", "", "Only include Droplets with this tag")
+	AddStringFlag(cmdInventory, doctl.ArgSSHUser, "", "", "SSH user for all Droplets. Defaults to the user for each Droplet's image")
+	AddStringFlag(cmdInventory, doctl.ArgsSSHKeyPath, "", sshDefaultKeyPath(), "Path to SSH private key")
+	AddIntFlag(cmdInventory, doctl.ArgsSSHPort, "", 22, "The remote port sshd is running on")
+	AddBoolFlag(cmdInventory, doctl.ArgsSSHPrivateIP, "", false, "Connect to the Droplets' private IP addresses")
+	AddBoolFlag(cmdInventory, doctl.ArgInventoryWriteSSHConfig, "", false, "Write the hosts into a managed block of the SSH config file instead of displaying them")
+	AddStringFlag(cmdInventory, doctl.ArgInventorySSHConfigPath, "", filepath.Join(usr.HomeDir, ".ssh", "config"), "The SSH config file to write with --write-ssh-config")
+	cmdInventory.Example = `The following example generates an Ansible inventory of the Droplets tagged ` + "`" + `web` + "`" + `: doctl compute droplet inventory --tag-name web --format ansible-ini > hosts.ini
+
+The following example adds all of your Droplets to ` + "`" + `~/.ssh/config` + "`" + `, so that you can connect to them with ` + "`" + `ssh <droplet-name>` + "`" + `: doctl compute droplet inventory --write-ssh-config`
+
+	return cmdInventory
+}
+
+// inventoryHost is a Droplet in an inventory.
+type inventoryHost struct {
+	Name      string   `json:"name"`
+	ID        int      `json:"id"`
+	Host      string   `json:"host"`
+	PublicIP  string   `json:"public_ip,omitempty"`
+	PrivateIP string   `json:"private_ip,omitempty"`
+	User      string   `json:"user"`
+	Port      int      `json:"port"`
+	KeyPath   string   `json:"key_path"`
+	Region    string   `json:"region,omitempty"`
+	VPCUUID   string   `json:"vpc_uuid,omitempty"`
+	Tags      []string `json:"tags"`
+	Groups    []string `json:"groups"`
+}
+
+// RunDropletInventory generates an inventory of Droplets.
+func RunDropletInventory(c *CmdConfig) error {
+	format, err := c.Doit.GetString(c.NS, doctl.ArgFormat)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case inventoryFormatSSHConfig, inventoryFormatAnsibleINI, inventoryFormatAnsibleYAML, inventoryFormatJSON:
+	default:
+		return fmt.Errorf("invalid inventory format %q, must be one of: ssh-config, ansible-ini, ansible-yaml, json", format)
+	}
+	write, err := c.Doit.GetBool(c.NS, doctl.ArgInventoryWriteSSHConfig)
+	if err != nil {
+		return err
+	}
+	if write && format != inventoryFormatSSHConfig {
+		return fmt.Errorf("--%s can only be used with the %s format", doctl.ArgInventoryWriteSSHConfig, inventoryFormatSSHConfig)
+	}
+
+	tagName, err := c.Doit.GetString(c.NS, doctl.ArgTagName)
+	if err != nil {
+		return err
+	}
+	user, err := c.Doit.GetString(c.NS, doctl.ArgSSHUser)
+	if err != nil {
+		return err
+	}
+	keyPath, err := sshKeyPath(c)
+	if err != nil {
+		return err
+	}
+	port, err := c.Doit.GetInt(c.NS, doctl.ArgsSSHPort)
+	if err != nil {
+		return err
+	}
+	privateIP, err := c.Doit.GetBool(c.NS, doctl.ArgsSSHPrivateIP)
+	if err != nil {
+		return err
+	}
+
+	var droplets do.Droplets
+	if tagName == "" {
+		droplets, err = c.Droplets().List()
+	} else {
+		droplets, err = c.Droplets().ListByTag(tagName)
+	}
+	if err != nil {
+		return err
+	}
+
+	hosts := make([]inventoryHost, 0, len(droplets))
+	for i := range droplets {
+		h, err := newInventoryHost(&droplets[i], user, keyPath, port, privateIP)
+		if err != nil {
+			return err
+		}
+		if h.Host == "" {
+			warn("Skipping Droplet %s, which has no address to connect to", h.Name)
+			continue
+		}
+		if strings.ContainsFunc(h.Name, unicode.IsSpace) {
+			// Host lines and inventory entries are separated by whitespace.
+			warn("Skipping Droplet %q, whose name contains whitespace", h.Name)
+			continue
+		}
+		hosts = append(hosts, h)
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Name < hosts[j].Name })
+
+	if write {
+		path, err := c.Doit.GetString(c.NS, doctl.ArgInventorySSHConfigPath)
+		if err != nil {
+			return err
+		}
+		var block bytes.Buffer
+		writeInventorySSHConfig(&block, hosts)
+		if err := writeManagedBlock(path, block.String()); err != nil {
+			return err
+		}
+		notice("Wrote %d hosts to %s", len(hosts), path)
+		return nil
+	}
+
+	switch format {
+	case inventoryFormatAnsibleINI:
+		writeInventoryAnsibleINI(c.Out, hosts)
+		return nil
+	case inventoryFormatAnsibleYAML:
+		out, err := yaml.Marshal(inventoryAnsible(hosts))
+		if err != nil {
+			return fmt.Errorf("marshaling the inventory as yaml: %v", err)
+		}
+		_, err = c.Out.Write(out)
+		return err
+	case inventoryFormatJSON:
+		e := json.NewEncoder(c.Out)
+		e.SetIndent("", "  ")
+		return e.Encode(hosts)
+	default:
+		writeInventorySSHConfig(c.Out, hosts)
+		return nil
+	}
+}
+
+func newInventoryHost(d *do.Droplet, user, keyPath string, port int, privateIP bool) (inventoryHost, error) {
+	h := inventoryHost{
+		Name:    d.Name,
+		ID:      d.ID,
+		User:    user,
+		Port:    port,
+		KeyPath: keyPath,
+		VPCUUID: d.VPCUUID,
+		Tags:    d.Tags,
+	}
+	if h.User == "" {
+		h.User = defaultSSHUser(d)
+	}
+	if h.Tags == nil {
+		h.Tags = []string{}
+	}
+
+	var err error
+	if h.PublicIP, err = d.PublicIPv4(); err != nil {
+		return inventoryHost{}, err
+	}
+	if h.PrivateIP, err = d.PrivateIPv4(); err != nil {
+		return inventoryHost{}, err
+	}
+	h.Host = h.PublicIP
+	if privateIP {
+		h.Host = h.PrivateIP
+	}
+
+	for _, tag := range d.Tags {
+		h.Groups = append(h.Groups, inventoryGroup("tag", tag))
+	}
+	if d.Region != nil {
+		h.Region = d.Region.Slug
+		h.Groups = append(h.Groups, inventoryGroup("region", d.Region.Slug))
+	}
+	if d.VPCUUID != "" {
+		h.Groups = append(h.Groups, inventoryGroup("vpc", d.VPCUUID))
+	}
+	if h.Groups == nil {
+		h.Groups = []string{}
+	}
+	sort.Strings(h.Groups)
+	return h, nil
+}
+
+// inventoryGroup returns the name of a group, using only the letters, digits
+// and underscores that Ansible allows.
+func inventoryGroup(kind, name string) string {
+	return kind + "_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func writeInventorySSHConfig(w io.Writer, hosts []inventoryHost) {
+	for i, h := range hosts {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "Host %s\n", h.Name)
+		fmt.Fprintf(w, "  HostName %s\n", h.Host)
+		fmt.Fprintf(w, "  User %s\n", h.User)
+		if h.Port != 22 {
+			fmt.Fprintf(w, "  Port %d\n", h.Port)
+		}
+		fmt.Fprintf(w, "  IdentityFile %s\n", h.KeyPath)
+	}
+}
+
+func writeInventoryAnsibleINI(w io.Writer, hosts []inventoryHost) {
+	groups := map[string][]string{}
+	for _, h := range hosts {
+		fmt.Fprintf(w, "%s", h.Name)
+		for _, v := range inventoryAnsibleVars(h) {
+			fmt.Fprintf(w, " %s=%v", v.name, v.value)
+		}
+		fmt.Fprintln(w)
+		for _, g := range h.Groups {
+			groups[g] = append(groups[g], h.Name)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for g := range groups {
+		names = append(names, g)
+	}
+	sort.Strings(names)
+	for _, g := range names {
+		fmt.Fprintf(w, "\n[%s]\n", g)
+		for _, name := range groups[g] {
+			fmt.Fprintln(w, name)
+		}
+	}
+}
+
+type inventoryVar struct {
+	name  string
+	value any
+}
+
+// inventoryAnsibleVars returns the Ansible variables of a host.
+func inventoryAnsibleVars(h inventoryHost) []inventoryVar {
+	vars := []inventoryVar{
+		{"ansible_host", h.Host},
+		{"ansible_user", h.User},
+	}
+	if h.Port != 22 {
+		vars = append(vars, inventoryVar{"ansible_port", h.Port})
+	}
+	vars = append(vars, inventoryVar{"ansible_ssh_private_key_file", h.KeyPath})
+	if h.PublicIP != "" {
+		vars = append(vars, inventoryVar{"public_ip", h.PublicIP})
+	}
+	if h.PrivateIP != "" {
+		vars = append(vars, inventoryVar{"private_ip", h.PrivateIP})
+	}
+	return append(vars, inventoryVar{"droplet_id", h.ID})
+}
+
+// inventoryAnsible returns the hosts as an Ansible inventory, with every
+// host under all and its groups as children of all.
+func inventoryAnsible(hosts []inventoryHost) map[string]any {
+	all := map[string]any{}
+	hostVars := map[string]any{}
+	children := map[string]map[string]any{}
+	for _, h := range hosts {
+		vars := map[string]any{}
+		for _, v := range inventoryAnsibleVars(h) {
+			vars[v.name] = v.value
+		}
+		hostVars[h.Name] = vars
+		for _, g := range h.Groups {
+			if children[g] == nil {
+				children[g] = map[string]any{"hosts": map[string]any{}}
+			}
+			children[g]["hosts"].(map[string]any)[h.Name] = map[string]any{}
+		}
+	}
+	all["hosts"] = hostVars
+	if len(children) > 0 {
+		all["children"] = children
+	}
+	return map[string]any{"all": all}
+}
+
+// writeManagedBlock writes content between the inventory markers in the file
+// at path. An existing block is replaced. Otherwise the block is added before
+// the first Host or Match line, since ssh uses the first value it finds for
+// each setting and a pattern such as Host * earlier in the file would
+// override the block's settings, or to the end of the file if it has none.
+// The file is created if it does not exist.
+func writeManagedBlock(path, content string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	block := inventoryBlockBegin + "\n" + content + inventoryBlockEnd + "\n"
+
+	text := string(existing)
+	begin := strings.Index(text, inventoryBlockBegin+"\n")
+	end := strings.Index(text, inventoryBlockEnd)
+	section := firstSectionLine(text)
+	switch {
+	case begin >= 0 && end > begin:
+		end += len(inventoryBlockEnd)
+		if end < len(text) && text[end] == '\n' {
+			end++
+		}
+		text = text[:begin] + block + text[end:]
+	case begin >= 0 || end >= 0:
+		return fmt.Errorf("%s has an incomplete doctl block; remove the lines between %q and %q and try again", path, inventoryBlockBegin, inventoryBlockEnd)
+	case text == "":
+		text = block
+	case section >= 0:
+		text = text[:section] + block + "\n" + text[section:]
+	default:
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		text += "\n" + block
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(text), 0600)
+}
+
+// firstSectionLine returns the offset of the first Host or Match line in an
+// ssh config file, or -1 if there is none.
+func firstSectionLine(text string) int {
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		fields := strings.FieldsFunc(line, func(r rune) bool { return unicode.IsSpace(r) || r == '=' })
+		if len(fields) > 0 && (strings.EqualFold(fields[0], "Host") || strings.EqualFold(fields[0], "Match")) {
+			return offset
+		}
+		offset += len(line)
+	}
+	return -1
+}
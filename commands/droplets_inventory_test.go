@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testInventoryDroplets = do.Droplets{
+	{Droplet: &godo.Droplet{
+		ID:    2,
+		Name:  "web-2",
+		Image: &godo.Image{Slug: "fedora-coreos"},
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "8.8.4.4", Type: "public"},
+			{IPAddress: "10.10.0.3", Type: "private"},
+		}},
+		Region:  &godo.Region{Slug: "nyc1"},
+		VPCUUID: "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+		Tags:    []string{"web"},
+	}},
+	{Droplet: &godo.Droplet{
+		ID:    1,
+		Name:  "web-1",
+		Image: &godo.Image{Slug: "ubuntu-22-04-x64"},
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "8.8.8.8", Type: "public"},
+			{IPAddress: "10.10.0.2", Type: "private"},
+		}},
+		Region:  &godo.Region{Slug: "nyc1"},
+		VPCUUID: "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+		Tags:    []string{"web", "env:prod"},
+	}},
+}
+
+func setInventoryFlags(config *CmdConfig, format string) {
+	config.Doit.Set(config.NS, doctl.ArgFormat, format)
+	config.Doit.Set(config.NS, doctl.ArgsSSHKeyPath, "/home/sammy/.ssh/id_ed25519")
+	config.Doit.Set(config.NS, doctl.ArgsSSHPort, 22)
+}
+
+func TestDropletInventorySSHConfig(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().ListByTag("web").Return(testInventoryDroplets, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		setInventoryFlags(config, "ssh-config")
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgsSSHPrivateIP, true)
+		config.Doit.Set(config.NS, doctl.ArgsSSHPort, 2222)
+
+		require.NoError(t, RunDropletInventory(config))
+		assert.Equal(t, `Host web-1
+  HostName 10.10.0.2
+  User root
+  Port 2222
+  IdentityFile /home/sammy/.ssh/id_ed25519
+
+Host web-2
+  HostName 10.10.0.3
+  User core
+  Port 2222
+  IdentityFile /home/sammy/.ssh/id_ed25519
+`, buf.String())
+	})
+}
+
+func TestDropletInventoryAnsibleINI(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(testInventoryDroplets, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		setInventoryFlags(config, "ansible-ini")
+
+		require.NoError(t, RunDropletInventory(config))
+		assert.Equal(t, `web-1 ansible_host=8.8.8.8 ansible_user=root ansible_ssh_private_key_file=/home/sammy/.ssh/id_ed25519 public_ip=8.8.8.8 private_ip=10.10.0.2 droplet_id=1
+web-2 ansible_host=8.8.4.4 ansible_user=core ansible_ssh_private_key_file=/home/sammy/.ssh/id_ed25519 public_ip=8.8.4.4 private_ip=10.10.0.3 droplet_id=2
+
+[region_nyc1]
+web-1
+web-2
+
+[tag_env_prod]
+web-1
+
+[tag_web]
+web-1
+web-2
+
+[vpc_5a4981aa_9653_4bd1_bef5_d6bff52042e4]
+web-1
+web-2
+`, buf.String())
+	})
+}
+
+func TestDropletInventoryAnsibleYAML(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(testInventoryDroplets[1:], nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		setInventoryFlags(config, "ansible-yaml")
+		config.Doit.Set(config.NS, doctl.ArgSSHUser, "sammy")
+
+		require.NoError(t, RunDropletInventory(config))
+		assert.Equal(t, `all:
+  children:
+    region_nyc1:
+      hosts:
+        web-1: {}
+    tag_env_prod:
+      hosts:
+        web-1: {}
+    tag_web:
+      hosts:
+        web-1: {}
+    vpc_5a4981aa_9653_4bd1_bef5_d6bff52042e4:
+      hosts:
+        web-1: {}
+  hosts:
+    web-1:
+      ansible_host: 8.8.8.8
+      ansible_ssh_private_key_file: /home/sammy/.ssh/id_ed25519
+      ansible_user: sammy
+      droplet_id: 1
+      private_ip: 10.10.0.2
+      public_ip: 8.8.8.8
+`, buf.String())
+	})
+}
+
+func TestDropletInventoryJSON(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(testInventoryDroplets[1:], nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		setInventoryFlags(config, "json")
+
+		require.NoError(t, RunDropletInventory(config))
+		assert.JSONEq(t, `[{
+  "name": "web-1",
+  "id": 1,
+  "host": "8.8.8.8",
+  "public_ip": "8.8.8.8",
+  "private_ip": "10.10.0.2",
+  "user": "root",
+  "port": 22,
+  "key_path": "/home/sammy/.ssh/id_ed25519",
+  "region": "nyc1",
+  "vpc_uuid": "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+  "tags": ["web", "env:prod"],
+  "groups": ["region_nyc1", "tag_env_prod", "tag_web", "vpc_5a4981aa_9653_4bd1_bef5_d6bff52042e4"]
+}]`, buf.String())
+	})
+}
+
+func TestDropletInventoryInvalidFormat(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		setInventoryFlags(config, "toml")
+		err := RunDropletInventory(config)
+		assert.EqualError(t, err, `invalid inventory format "toml", must be one of: ssh-config, ansible-ini, ansible-yaml, json`)
+
+		setInventoryFlags(config, "json")
+		config.Doit.Set(config.NS, doctl.ArgInventoryWriteSSHConfig, true)
+		err = RunDropletInventory(config)
+		assert.EqualError(t, err, "--write-ssh-config can only be used with the ssh-config format")
+	})
+}
+
+func TestDropletInventoryWriteSSHConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ssh", "config")
+	run := func(droplets do.Droplets) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			tm.droplets.EXPECT().List().Return(droplets, nil)
+
+			var buf bytes.Buffer
+			config.Out = &buf
+			setInventoryFlags(config, "ssh-config")
+			config.Doit.Set(config.NS, doctl.ArgInventoryWriteSSHConfig, true)
+			config.Doit.Set(config.NS, doctl.ArgInventorySSHConfigPath, path)
+
+			require.NoError(t, RunDropletInventory(config))
+			assert.Empty(t, buf.String())
+		})
+	}
+
+	run(testInventoryDroplets[1:])
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// The block is replaced, and the rest of the file is kept.
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, append([]byte("Host *\n  ServerAliveInterval 60\n\n"), append(written, "Host other\n  HostName 192.0.2.1\n"...)...), 0600))
+	run(testInventoryDroplets)
+	run(testInventoryDroplets)
+
+	written, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `Host *
+  ServerAliveInterval 60
+
+# BEGIN doctl compute droplet inventory
+Host web-1
+  HostName 8.8.8.8
+  User root
+  IdentityFile /home/sammy/.ssh/id_ed25519
+
+Host web-2
+  HostName 8.8.4.4
+  User core
+  IdentityFile /home/sammy/.ssh/id_ed25519
+# END doctl compute droplet inventory
+Host other
+  HostName 192.0.2.1
+`, string(written))
+}
+
+func TestWriteManagedBlockInserts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte("# My hosts\nServerAliveInterval 60\n\nHost *\n  User sammy"), 0644))
+
+	// The block goes before Host *, so that its settings are not overridden,
+	// and stays there when it is replaced.
+	require.NoError(t, writeManagedBlock(path, "Host a\n"))
+	require.NoError(t, writeManagedBlock(path, "Host b\n"))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# My hosts\nServerAliveInterval 60\n\n# BEGIN doctl compute droplet inventory\nHost b\n# END doctl compute droplet inventory\n\nHost *\n  User sammy", string(written))
+
+	require.NoError(t, os.WriteFile(path, []byte("  match all\n"), 0644))
+	require.NoError(t, writeManagedBlock(path, "Host b\n"))
+	written, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# BEGIN doctl compute droplet inventory\nHost b\n# END doctl compute droplet inventory\n\n  match all\n", string(written))
+}
+
+func TestWriteManagedBlockAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte("ServerAliveInterval 60"), 0644))
+
+	require.NoError(t, writeManagedBlock(path, "Host a\n"))
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ServerAliveInterval 60\n\n# BEGIN doctl compute droplet inventory\nHost a\n# END doctl compute droplet inventory\n", string(written))
+
+	require.NoError(t, os.WriteFile(path, []byte("# BEGIN doctl compute droplet inventory\nHost a\n"), 0644))
+	assert.ErrorContains(t, writeManagedBlock(path, "Host b\n"), "has an incomplete doctl block")
+}
+
+func TestDropletInventorySkipsDroplets(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		droplets := append(do.Droplets{
+			{Droplet: &godo.Droplet{ID: 3, Name: "no-network", Image: &godo.Image{}, Networks: &godo.Networks{}}},
+			{Droplet: &godo.Droplet{ID: 4, Name: "my web", Image: &godo.Image{}, Networks: &godo.Networks{V4: []godo.NetworkV4{
+				{IPAddress: "192.0.2.4", Type: "public"},
+			}}}},
+		}, testInventoryDroplets...)
+		tm.droplets.EXPECT().List().Return(droplets, nil)
+
+		defer func(w io.Writer) { color.Output = w }(color.Output)
+		var warnings bytes.Buffer
+		color.Output = &warnings
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		setInventoryFlags(config, "ssh-config")
+
+		require.NoError(t, RunDropletInventory(config))
+		assert.Contains(t, warnings.String(), "Skipping Droplet no-network, which has no address to connect to")
+		assert.Contains(t, warnings.String(), `Skipping Droplet "my web", whose name contains whitespace`)
+		assert.NotContains(t, buf.String(), "my web")
+		assert.Contains(t, buf.String(), "Host web-1\n")
+	})
+}
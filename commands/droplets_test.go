@@ -53,7 +53,7 @@ var (
 func TestDropletCommand(t *testing.T) {
 	cmd := Droplet()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "1-click", "actions", "backups", "create", "delete", "get", "kernels", "list", "neighbors", "snapshots", "tag", "untag")
+	assertCommandNames(t, cmd, "1-click", "actions", "backups", "create", "delete", "get", "inventory", "kernels", "list", "neighbors", "snapshots", "tag", "untag")
 }
 
 func TestDropletActionList(t *testing.T) {
@@ -491,6 +491,26 @@ func TestDropletsUntag(t *testing.T) {
 	})
 }
 
+func TestDropletsUntagMultiple(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		urr := &godo.UntagResourcesRequest{
+			Resources: []godo.Resource{
+				{ID: "1", Type: godo.DropletResourceType},
+				{ID: "2", Type: godo.DropletResourceType},
+			},
+		}
+
+		tm.tags.EXPECT().UntagResources("my-tag", urr).Return(nil)
+		tm.tags.EXPECT().UntagResources("other-tag", urr).Return(nil)
+
+		config.Args = []string{"1", "2"}
+		config.Doit.Set(config.NS, doctl.ArgTagName, []string{"my-tag", "other-tag"})
+
+		err := RunDropletUntag(config)
+		assert.NoError(t, err)
+	})
+}
+
 func Test_extractSSHKey(t *testing.T) {
 	cases := []struct {
 		in       []string
@@ -0,0 +1,464 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+)
+
+// Export creates the export command.
+func Export() *Command {
+	cmd := CmdBuilder(nil, RunExport, "export", "Write your resources to a manifest",
+		`Writes the resources on your account to a manifest that `+"`"+`doctl apply`+"`"+` can read, so that you can start managing them declaratively or keep a backup of your setup. The manifest includes:
+
+- Droplets, with their size, image, region, tags, and VPC network
+- Block storage volumes, and the Droplets they are attached to
+- Reserved IPs, and the Droplets they are assigned to
+- Load balancers, with their forwarding rules and health checks
+- Cloud firewalls, with their rules
+- Domains and their DNS records
+- Monitoring alert policies
+
+Fields that DigitalOcean sets, such as IDs, IP addresses, creation times, and statuses, are left out, and each list is sorted by name so that exports of the same resources are identical. Droplets are referred to by name. The API does not return the SSH keys or user data a Droplet was created with, so these are not included.
+
+The manifest is written as YAML, or as JSON if you pass `+"`"+`--output json`+"`"+`. Use the `+"`"+`--project`+"`"+` and `+"`"+`--tag`+"`"+` flags to limit the export to part of your account. Firewalls and alert policies are included when they apply to an exported Droplet or to one of its tags.`, Writer)
+	cmd.GroupID = manageResourcesGroup
+	AddStringFlag(cmd, doctl.ArgProject, "", "", "Only export resources in the project with this ID or name")
+	AddStringFlag(cmd, doctl.ArgTag, "", "", "Only export resources with this tag")
+	cmd.Example = `The following example writes the resources in the project ` + "`" + `production` + "`" + ` to ` + "`" + `resources.yaml` + "`" + `: doctl export --project production > resources.yaml`
+
+	return cmd
+}
+
+// RunExport writes live resources to a manifest.
+func RunExport(c *CmdConfig) error {
+	project, err := c.Doit.GetString(c.NS, doctl.ArgProject)
+	if err != nil {
+		return err
+	}
+	tag, err := c.Doit.GetString(c.NS, doctl.ArgTag)
+	if err != nil {
+		return err
+	}
+
+	scope := &exportScope{tag: tag}
+	if project != "" {
+		projectID, err := do.ResolveProjectID(c.Projects(), project)
+		if err != nil {
+			return err
+		}
+		scope.urns, err = projectURNs(c, projectID)
+		if err != nil {
+			return err
+		}
+	}
+
+	m, err := buildExport(c, scope)
+	if err != nil {
+		return err
+	}
+
+	if viper.GetString("output") == "json" {
+		e := json.NewEncoder(c.Out)
+		e.SetIndent("", "  ")
+		return e.Encode(m)
+	}
+	b, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = c.Out.Write(b)
+	return err
+}
+
+// projectURNs returns the URNs of the resources in a project.
+func projectURNs(c *CmdConfig, projectID string) (map[string]bool, error) {
+	resources, err := c.Projects().ListResources(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	urns := map[string]bool{}
+	for _, r := range resources {
+		urns[r.URN] = true
+		if rest, ok := strings.CutPrefix(r.URN, "do:floatingip:"); ok {
+			urns["do:reservedip:"+rest] = true
+		}
+	}
+	return urns, nil
+}
+
+// exportScope limits an export to the resources in a project, with a tag, or
+// both.
+type exportScope struct {
+	// urns holds the URNs of the resources in the project, or is nil when the
+	// export is not limited to a project.
+	urns map[string]bool
+	tag  string
+}
+
+func (s *exportScope) all() bool {
+	return s.urns == nil && s.tag == ""
+}
+
+func (s *exportScope) includes(urn string, tags []string) bool {
+	if s.urns != nil && !s.urns[urn] {
+		return false
+	}
+	return s.tag == "" || slices.Contains(tags, s.tag)
+}
+
+// exportedDroplets records the Droplets included in an export, so that
+// firewalls, reserved IPs, and alert policies can be matched to them.
+type exportedDroplets struct {
+	names map[int]string
+	ids   map[int]bool
+	tags  map[string]bool
+}
+
+func (e *exportedDroplets) name(id int) string {
+	if name, ok := e.names[id]; ok {
+		return name
+	}
+	return strconv.Itoa(id)
+}
+
+func (e *exportedDroplets) nameList(ids []int) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, e.name(id))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// related reports whether a firewall or alert policy that applies to the
+// given Droplets and tags applies to any exported Droplet.
+func (e *exportedDroplets) related(ids []int, tags []string) bool {
+	for _, id := range ids {
+		if e.ids[id] {
+			return true
+		}
+	}
+	for _, t := range tags {
+		if e.tags[t] {
+			return true
+		}
+	}
+	return false
+}
+
+func buildExport(c *CmdConfig, scope *exportScope) (*manifest, error) {
+	m := &manifest{}
+
+	droplets, err := c.Droplets().List()
+	if err != nil {
+		return nil, err
+	}
+	exported := &exportedDroplets{names: map[int]string{}, ids: map[int]bool{}, tags: map[string]bool{}}
+	for _, d := range droplets {
+		exported.names[d.ID] = d.Name
+		if !scope.includes(d.URN(), d.Tags) {
+			continue
+		}
+		exported.ids[d.ID] = true
+		for _, t := range d.Tags {
+			exported.tags[t] = true
+		}
+		m.Droplets = append(m.Droplets, exportDroplet(d))
+	}
+
+	volumes, err := c.Volumes().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes {
+		if scope.includes(v.URN(), v.Tags) {
+			m.Volumes = append(m.Volumes, exportVolume(v, exported))
+		}
+	}
+
+	ips, err := c.ReservedIPs().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range ips {
+		if scope.includes(r.URN(), nil) || (r.Droplet != nil && exported.ids[r.Droplet.ID]) {
+			m.ReservedIPs = append(m.ReservedIPs, exportReservedIP(r))
+		}
+	}
+
+	lbs, err := c.LoadBalancers().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, lb := range lbs {
+		if scope.includes(lb.URN(), append([]string{lb.Tag}, lb.Tags...)) {
+			m.LoadBalancers = append(m.LoadBalancers, exportLoadBalancer(lb, exported))
+		}
+	}
+
+	firewalls, err := c.Firewalls().List()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range firewalls {
+		if scope.all() || slices.Contains(f.Tags, scope.tag) || exported.related(f.DropletIDs, f.Tags) {
+			m.Firewalls = append(m.Firewalls, exportFirewall(f, exported))
+		}
+	}
+
+	// Domains cannot be tagged, so they are left out of exports by tag.
+	if scope.tag == "" {
+		ds := c.Domains()
+		domains, err := ds.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range domains {
+			if !scope.includes(d.URN(), nil) {
+				continue
+			}
+			records, err := ds.Records(d.Name)
+			if err != nil {
+				return nil, err
+			}
+			m.Domains = append(m.Domains, exportDomain(d, records))
+		}
+	}
+
+	policies, err := c.Monitoring().ListAlertPolicies()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range policies {
+		var ids []int
+		for _, e := range a.Entities {
+			if id, err := strconv.Atoi(e); err == nil {
+				ids = append(ids, id)
+			}
+		}
+		if scope.all() || slices.Contains(a.Tags, scope.tag) || exported.related(ids, a.Tags) {
+			m.AlertPolicies = append(m.AlertPolicies, exportAlertPolicy(a))
+		}
+	}
+
+	m.Tags = manifestTags(m)
+	sortManifest(m)
+	return m, nil
+}
+
+// manifestTags returns every tag the resources in m refer to.
+func manifestTags(m *manifest) []string {
+	set := map[string]bool{}
+	add := func(tags ...string) {
+		for _, t := range tags {
+			if t != "" {
+				set[t] = true
+			}
+		}
+	}
+	for _, d := range m.Droplets {
+		add(d.Tags...)
+	}
+	for _, v := range m.Volumes {
+		add(v.Tags...)
+	}
+	for _, lb := range m.LoadBalancers {
+		add(lb.Tag)
+	}
+	for _, f := range m.Firewalls {
+		add(f.Tags...)
+	}
+	for _, a := range m.AlertPolicies {
+		add(a.Tags...)
+	}
+
+	tags := make([]string, 0, len(set))
+	for t := range set {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func sortManifest(m *manifest) {
+	sort.Slice(m.Droplets, func(i, j int) bool { return m.Droplets[i].Name < m.Droplets[j].Name })
+	sort.Slice(m.Volumes, func(i, j int) bool { return m.Volumes[i].Name < m.Volumes[j].Name })
+	sort.Slice(m.ReservedIPs, func(i, j int) bool { return m.ReservedIPs[i].key() < m.ReservedIPs[j].key() })
+	sort.Slice(m.LoadBalancers, func(i, j int) bool { return m.LoadBalancers[i].Name < m.LoadBalancers[j].Name })
+	sort.Slice(m.Firewalls, func(i, j int) bool { return m.Firewalls[i].Name < m.Firewalls[j].Name })
+	sort.Slice(m.Domains, func(i, j int) bool { return m.Domains[i].Name < m.Domains[j].Name })
+	sort.Slice(m.AlertPolicies, func(i, j int) bool { return m.AlertPolicies[i].Description < m.AlertPolicies[j].Description })
+}
+
+func exportDroplet(d do.Droplet) manifestDroplet {
+	out := manifestDroplet{
+		Name:       d.Name,
+		Size:       d.SizeSlug,
+		Tags:       d.Tags,
+		VPCUUID:    d.VPCUUID,
+		Backups:    slices.Contains(d.Features, "backups"),
+		IPv6:       slices.Contains(d.Features, "ipv6"),
+		Monitoring: slices.Contains(d.Features, "monitoring"),
+	}
+	if d.Region != nil {
+		out.Region = d.Region.Slug
+	}
+	if d.Image != nil {
+		out.Image = d.Image.Slug
+		if out.Image == "" {
+			out.Image = strconv.Itoa(d.Image.ID)
+		}
+	}
+	return out
+}
+
+func exportVolume(v do.Volume, droplets *exportedDroplets) manifestVolume {
+	out := manifestVolume{
+		Name:            v.Name,
+		SizeGigaBytes:   v.SizeGigaBytes,
+		Description:     v.Description,
+		FilesystemType:  v.FilesystemType,
+		FilesystemLabel: v.FilesystemLabel,
+		Tags:            v.Tags,
+	}
+	if v.Region != nil {
+		out.Region = v.Region.Slug
+	}
+	if len(v.DropletIDs) > 0 {
+		out.Droplet = droplets.name(v.DropletIDs[0])
+	}
+	return out
+}
+
+func exportReservedIP(r do.ReservedIP) manifestReservedIP {
+	out := manifestReservedIP{IP: r.IP}
+	if r.Droplet != nil {
+		out.Droplet = r.Droplet.Name
+	} else if r.Region != nil {
+		out.Region = r.Region.Slug
+	}
+	return out
+}
+
+func exportLoadBalancer(lb do.LoadBalancer, droplets *exportedDroplets) manifestLoadBalancer {
+	out := manifestLoadBalancer{
+		Name:                         lb.Name,
+		Size:                         lb.SizeSlug,
+		SizeUnit:                     lb.SizeUnit,
+		Type:                         lb.Type,
+		Network:                      lb.Network,
+		VPCUUID:                      lb.VPCUUID,
+		ForwardingRules:              lb.ForwardingRules,
+		HealthCheck:                  lb.HealthCheck,
+		StickySessions:               lb.StickySessions,
+		Tag:                          lb.Tag,
+		RedirectHttpToHttps:          lb.RedirectHttpToHttps,
+		EnableProxyProtocol:          lb.EnableProxyProtocol,
+		EnableBackendKeepalive:       lb.EnableBackendKeepalive,
+		HTTPIdleTimeoutSeconds:       lb.HTTPIdleTimeoutSeconds,
+		DisableLetsEncryptDNSRecords: lb.DisableLetsEncryptDNSRecords,
+		Firewall:                     lb.Firewall,
+	}
+	if lb.Region != nil {
+		out.Region = lb.Region.Slug
+	}
+	// The size slug and size unit are mutually exclusive in requests.
+	if out.SizeUnit > 0 {
+		out.Size = ""
+	}
+	// A load balancer that targets a tag lists the tagged Droplets, but they
+	// are set by the tag rather than by the request.
+	if lb.Tag == "" && len(lb.DropletIDs) > 0 {
+		out.Droplets = droplets.nameList(lb.DropletIDs)
+	}
+	return out
+}
+
+func exportFirewall(f do.Firewall, droplets *exportedDroplets) manifestFirewall {
+	out := manifestFirewall{
+		Name:          f.Name,
+		InboundRules:  f.InboundRules,
+		OutboundRules: f.OutboundRules,
+		Tags:          f.Tags,
+	}
+	if len(f.DropletIDs) > 0 {
+		out.Droplets = droplets.nameList(f.DropletIDs)
+	}
+	return out
+}
+
+func exportDomain(d do.Domain, records do.DomainRecords) manifestDomain {
+	out := manifestDomain{Name: d.Name}
+	for _, r := range records {
+		if !isManagedRecord(r.Type, r.Data) {
+			continue
+		}
+		out.Records = append(out.Records, exportRecord(r))
+	}
+	sort.Slice(out.Records, func(i, j int) bool { return out.Records[i].key(d.Name) < out.Records[j].key(d.Name) })
+	return out
+}
+
+func exportRecord(r do.DomainRecord) manifestRecord {
+	return manifestRecord{
+		Type:     r.Type,
+		Name:     r.Name,
+		Data:     r.Data,
+		Priority: r.Priority,
+		Port:     r.Port,
+		Weight:   r.Weight,
+		TTL:      r.TTL,
+		Flags:    r.Flags,
+		Tag:      r.Tag,
+	}
+}
+
+func exportAlertPolicy(a do.AlertPolicy) manifestAlertPolicy {
+	enabled := a.Enabled
+	return manifestAlertPolicy{
+		Description: a.Description,
+		Type:        a.Type,
+		Compare:     a.Compare,
+		Value:       a.Value,
+		Window:      a.Window,
+		Entities:    a.Entities,
+		Tags:        a.Tags,
+		Alerts:      a.Alerts,
+		Enabled:     &enabled,
+	}
+}
+
+// isManagedRecord reports whether a DNS record can be managed by a manifest.
+// A domain's SOA record and its NS records for DigitalOcean's name servers
+// are created and maintained by DigitalOcean.
+func isManagedRecord(recordType, data string) bool {
+	switch recordType {
+	case "SOA":
+		return false
+	case "NS":
+		return !strings.HasSuffix(strings.TrimSuffix(data, "."), ".digitalocean.com")
+	}
+	return true
+}
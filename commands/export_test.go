@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCommand(t *testing.T) {
+	cmd := Export()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "export", cmd.Name())
+}
+
+func TestRunExport(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(do.Droplets{
+			{Droplet: &godo.Droplet{
+				ID:       2,
+				Name:     "web-02",
+				SizeSlug: "s-1vcpu-1gb",
+				Region:   &godo.Region{Slug: "nyc1"},
+				Image:    &godo.Image{ID: 7, Slug: "ubuntu-22-04-x64"},
+				Tags:     []string{"web"},
+				Features: []string{"monitoring"},
+				Status:   "active",
+			}},
+			{Droplet: &godo.Droplet{
+				ID:       1,
+				Name:     "db-01",
+				SizeSlug: "s-2vcpu-4gb",
+				Region:   &godo.Region{Slug: "nyc1"},
+				Image:    &godo.Image{ID: 8},
+			}},
+		}, nil)
+		tm.volumes.EXPECT().List().Return([]do.Volume{
+			{Volume: &godo.Volume{ID: "vol-1", Name: "data", Region: &godo.Region{Slug: "nyc1"}, SizeGigaBytes: 100, DropletIDs: []int{1}}},
+		}, nil)
+		tm.reservedIPs.EXPECT().List().Return(do.ReservedIPs{
+			{ReservedIP: &godo.ReservedIP{IP: "198.51.100.9", Region: &godo.Region{Slug: "nyc1"}, Droplet: &godo.Droplet{ID: 2, Name: "web-02"}}},
+		}, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{
+			{LoadBalancer: &godo.LoadBalancer{
+				ID:              "lb-1",
+				Name:            "web",
+				IP:              "203.0.113.1",
+				Status:          "active",
+				Region:          &godo.Region{Slug: "nyc1"},
+				SizeUnit:        1,
+				SizeSlug:        "lb-small",
+				Tag:             "web",
+				DropletIDs:      []int{2},
+				ForwardingRules: []godo.ForwardingRule{{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 80}},
+			}},
+		}, nil)
+		tm.firewalls.EXPECT().List().Return(do.Firewalls{
+			{Firewall: &godo.Firewall{ID: "fw-1", Name: "web", Status: "succeeded", Tags: []string{"web"}, DropletIDs: []int{1}}},
+		}, nil)
+		tm.domains.EXPECT().List().Return(do.Domains{{Domain: &godo.Domain{Name: "example.com", TTL: 1800}}}, nil)
+		tm.domains.EXPECT().Records("example.com").Return(do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "SOA", Name: "@", Data: "1800"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "NS", Name: "@", Data: "ns1.digitalocean.com"}},
+			{DomainRecord: &godo.DomainRecord{ID: 3, Type: "A", Name: "@", Data: "203.0.113.1", TTL: 3600}},
+		}, nil)
+		tm.monitoring.EXPECT().ListAlertPolicies().Return(do.AlertPolicies{
+			{AlertPolicy: &godo.AlertPolicy{
+				UUID:        "ap-1",
+				Type:        godo.DropletCPUUtilizationPercent,
+				Description: "High CPU",
+				Compare:     godo.GreaterThan,
+				Value:       80,
+				Window:      "5m",
+				Tags:        []string{"web"},
+				Alerts:      godo.Alerts{Email: []string{"ops@example.com"}},
+				Enabled:     true,
+			}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+
+		err := RunExport(config)
+		require.NoError(t, err)
+
+		expected := `alert_policies:
+- alerts:
+    email:
+    - ops@example.com
+    slack: null
+  compare: GreaterThan
+  description: High CPU
+  enabled: true
+  tags:
+  - web
+  type: v1/insights/droplet/cpu
+  value: 80
+  window: 5m
+domains:
+- name: example.com
+  records:
+  - data: 203.0.113.1
+    name: '@'
+    ttl: 3600
+    type: A
+droplets:
+- image: "8"
+  name: db-01
+  region: nyc1
+  size: s-2vcpu-4gb
+- image: ubuntu-22-04-x64
+  monitoring: true
+  name: web-02
+  region: nyc1
+  size: s-1vcpu-1gb
+  tags:
+  - web
+firewalls:
+- droplets:
+  - db-01
+  name: web
+  tags:
+  - web
+load_balancers:
+- forwarding_rules:
+  - entry_port: 80
+    entry_protocol: http
+    target_port: 80
+    target_protocol: http
+  name: web
+  region: nyc1
+  size_unit: 1
+  tag: web
+reserved_ips:
+- droplet: web-02
+  ip: 198.51.100.9
+tags:
+- web
+volumes:
+- droplet: db-01
+  name: data
+  region: nyc1
+  size_gigabytes: 100
+`
+		assert.Equal(t, expected, buf.String())
+
+		// The export can be read back as a manifest.
+		_, err = readManifest(strings.NewReader(buf.String()), "-")
+		assert.NoError(t, err)
+	})
+}
+
+func TestRunExportByTag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(do.Droplets{
+			{Droplet: &godo.Droplet{ID: 1, Name: "db-01", Tags: []string{"db"}}},
+			{Droplet: &godo.Droplet{
+				ID:       2,
+				Name:     "web-02",
+				SizeSlug: "s-1vcpu-1gb",
+				Region:   &godo.Region{Slug: "nyc1"},
+				Image:    &godo.Image{Slug: "ubuntu-22-04-x64"},
+				Tags:     []string{"web"},
+			}},
+		}, nil)
+		tm.volumes.EXPECT().List().Return([]do.Volume{}, nil)
+		tm.reservedIPs.EXPECT().List().Return(do.ReservedIPs{}, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{}, nil)
+		tm.firewalls.EXPECT().List().Return(do.Firewalls{
+			{Firewall: &godo.Firewall{ID: "fw-1", Name: "db", DropletIDs: []int{1}}},
+			{Firewall: &godo.Firewall{ID: "fw-2", Name: "web", DropletIDs: []int{2}}},
+		}, nil)
+		tm.monitoring.EXPECT().ListAlertPolicies().Return(do.AlertPolicies{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgTag, "web")
+
+		err := RunExport(config)
+		require.NoError(t, err)
+
+		m, err := readManifest(strings.NewReader(buf.String()), "-")
+		require.NoError(t, err)
+		require.Len(t, m.Droplets, 1)
+		assert.Equal(t, "web-02", m.Droplets[0].Name)
+		require.Len(t, m.Firewalls, 1)
+		assert.Equal(t, "web", m.Firewalls[0].Name)
+		assert.Empty(t, m.Domains)
+	})
+}
+
+func TestRunExportByProject(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.projects.EXPECT().Get("production").Return(nil, &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}})
+		tm.projects.EXPECT().List().Return(do.Projects{
+			{Project: &godo.Project{ID: "c3f6bd8b-5f1e-4b9e-a6c5-1a1b8f8b0e3d", Name: "production"}},
+		}, nil)
+		tm.projects.EXPECT().ListResources("c3f6bd8b-5f1e-4b9e-a6c5-1a1b8f8b0e3d").Return(do.ProjectResources{
+			{ProjectResource: &godo.ProjectResource{URN: "do:droplet:2"}},
+			{ProjectResource: &godo.ProjectResource{URN: "do:floatingip:198.51.100.9"}},
+		}, nil)
+		tm.droplets.EXPECT().List().Return(do.Droplets{
+			{Droplet: &godo.Droplet{ID: 1, Name: "db-01"}},
+			{Droplet: &godo.Droplet{ID: 2, Name: "web-02"}},
+		}, nil)
+		tm.volumes.EXPECT().List().Return([]do.Volume{}, nil)
+		tm.reservedIPs.EXPECT().List().Return(do.ReservedIPs{
+			{ReservedIP: &godo.ReservedIP{IP: "198.51.100.9", Region: &godo.Region{Slug: "nyc1"}}},
+			{ReservedIP: &godo.ReservedIP{IP: "198.51.100.10", Region: &godo.Region{Slug: "nyc1"}}},
+		}, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{}, nil)
+		tm.firewalls.EXPECT().List().Return(do.Firewalls{}, nil)
+		tm.domains.EXPECT().List().Return(do.Domains{{Domain: &godo.Domain{Name: "example.com"}}}, nil)
+		tm.monitoring.EXPECT().ListAlertPolicies().Return(do.AlertPolicies{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgProject, "production")
+
+		err := RunExport(config)
+		require.NoError(t, err)
+
+		expected := `droplets:
+- image: ""
+  name: web-02
+  region: ""
+  size: ""
+reserved_ips:
+- ip: 198.51.100.9
+  region: nyc1
+`
+		assert.Equal(t, expected, buf.String())
+	})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+)
+
+const (
+	itemStatusOK      = "ok"
+	itemStatusFailed  = "failed"
+	itemStatusSkipped = "skipped"
+)
+
+// addFanOutFlags adds the flags shared by commands that act on several
+// resources in one invocation.
+func addFanOutFlags(cmd *Command) {
+	AddIntFlag(cmd, doctl.ArgConcurrency, "", 1, "The maximum number of resources to act on at the same time")
+	AddBoolFlag(cmd, doctl.ArgContinueOnError, "", false, "Keep processing the remaining resources after one of them fails")
+}
+
+// fanOut runs fn once for every item, using at most --concurrency workers.
+// Unless --continue-on-error is set, no new items are started after the
+// first failure. When more than one item is given, a per-item summary is
+// displayed and an error is returned if any of them failed; a single item
+// returns fn's error unchanged.
+func fanOut(c *CmdConfig, items []string, fn func(item string) error) error {
+	switch len(items) {
+	case 0:
+		return nil
+	case 1:
+		return fn(items[0])
+	}
+
+	concurrency, err := c.Doit.GetInt(c.NS, doctl.ArgConcurrency)
+	if err != nil {
+		return err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	continueOnError, err := c.Doit.GetBool(c.NS, doctl.ArgContinueOnError)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		stopped atomic.Bool
+		sem     = make(chan struct{}, concurrency)
+		results = make([]displayers.ItemResult, len(items))
+	)
+
+	for i, item := range items {
+		results[i] = displayers.ItemResult{ID: item, Status: itemStatusSkipped}
+
+		sem <- struct{}{}
+		if stopped.Load() {
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(item); err != nil {
+				results[i].Status = itemStatusFailed
+				results[i].Error = err.Error()
+				if !continueOnError {
+					stopped.Store(true)
+				}
+				return
+			}
+			results[i].Status = itemStatusOK
+		}()
+	}
+	wg.Wait()
+
+	if err := c.Display(&displayers.ItemResults{Results: results}); err != nil {
+		return err
+	}
+
+	var failed, skipped int
+	for _, r := range results {
+		switch r.Status {
+		case itemStatusFailed:
+			failed++
+		case itemStatusSkipped:
+			skipped++
+		}
+	}
+
+	switch {
+	case failed > 0 && skipped > 0:
+		return fmt.Errorf("%d of %d resources failed, %d skipped", failed, len(items), skipped)
+	case failed > 0:
+		return fmt.Errorf("%d of %d resources failed", failed, len(items))
+	}
+
+	return nil
+}
+
+// fanOutInts is like fanOut for resources identified by integer IDs.
+func fanOutInts(c *CmdConfig, ids []int, fn func(id int) error) error {
+	items := make([]string, 0, len(ids))
+	byItem := make(map[string]int, len(ids))
+	for _, id := range ids {
+		item := fmt.Sprint(id)
+		items = append(items, item)
+		byItem[item] = id
+	}
+
+	return fanOut(c, items, func(item string) error {
+		return fn(byItem[item])
+	})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOut(t *testing.T) {
+	t.Run("single item returns the error unchanged", func(t *testing.T) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			var buf bytes.Buffer
+			config.Out = &buf
+
+			err := fanOut(config, []string{"a"}, func(string) error {
+				return errors.New("boom")
+			})
+			assert.EqualError(t, err, "boom")
+			assert.Empty(t, buf.String())
+		})
+	})
+
+	t.Run("stops after the first failure", func(t *testing.T) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			var buf bytes.Buffer
+			config.Out = &buf
+
+			var called []string
+			err := fanOut(config, []string{"a", "b", "c"}, func(item string) error {
+				called = append(called, item)
+				if item == "b" {
+					return errors.New("boom")
+				}
+				return nil
+			})
+			assert.EqualError(t, err, "1 of 3 resources failed, 1 skipped")
+			assert.Equal(t, []string{"a", "b"}, called)
+
+			expected := `ID    Status     Error
+a     ok         
+b     failed     boom
+c     skipped    
+`
+			assert.Equal(t, expected, buf.String())
+		})
+	})
+
+	t.Run("continues on error when requested", func(t *testing.T) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			config.Doit.Set(config.NS, doctl.ArgContinueOnError, true)
+
+			var (
+				mu     sync.Mutex
+				called []string
+			)
+			err := fanOut(config, []string{"a", "b", "c"}, func(item string) error {
+				mu.Lock()
+				called = append(called, item)
+				mu.Unlock()
+				if item != "c" {
+					return errors.New("boom")
+				}
+				return nil
+			})
+			assert.EqualError(t, err, "2 of 3 resources failed")
+			assert.ElementsMatch(t, []string{"a", "b", "c"}, called)
+		})
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+			config.Doit.Set(config.NS, doctl.ArgConcurrency, 2)
+
+			var running, peak atomic.Int32
+			items := []string{"a", "b", "c", "d", "e", "f"}
+			err := fanOut(config, items, func(string) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, peak.Load(), int32(2))
+		})
+	})
+}
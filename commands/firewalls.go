@@ -16,6 +16,9 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -23,8 +26,10 @@ import (
 	"github.com/digitalocean/doctl/commands/displayers"
 	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/godo"
-
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
 )
 
 // Firewall creates the firewall command.
@@ -70,7 +75,7 @@ Use a quoted string of space-separated values for multiple rules.`
 	dropletIDRulesTxt := "A comma-separated list of Droplet IDs to place behind the cloud firewall, for example: `386734086,391669331`"
 	tagNameRulesTxt := "A comma-separated list of existing tags, for example: `frontend,backend`. Droplets with these tags will be placed behind the cloud firewall"
 
-	cmdFirewallGet := CmdBuilder(cmd, RunFirewallGet, "get <id>", "Retrieve information about a cloud firewall", `Retrieves information about an existing cloud firewall, including:`+fwDetail, Writer, aliasOpt("g"), displayerType(&displayers.Firewall{}))
+	cmdFirewallGet := CmdBuilder(cmd, RunFirewallGet, "get <firewall-id|name>", "Retrieve information about a cloud firewall", `Retrieves information about an existing cloud firewall, including:`+fwDetail, Writer, aliasOpt("g"), displayerType(&displayers.Firewall{}), fromFileOpt())
 	cmdFirewallGet.Example = `The following example retrieves information about the cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmdFirewallCreate := CmdBuilder(cmd, RunFirewallCreate, "create", "Create a new cloud firewall", `Creates a cloud firewall. This command must contain at least one inbound or outbound access rule.`, Writer, aliasOpt("c"), displayerType(&displayers.Firewall{}))
@@ -81,7 +86,7 @@ Use a quoted string of space-separated values for multiple rules.`
 	AddStringSliceFlag(cmdFirewallCreate, doctl.ArgTagNames, "", []string{}, tagNameRulesTxt)
 	cmdFirewallCreate.Example = `The following example creates a cloud firewall named ` + "`" + `example-firewall` + "`" + ` that contains an inbound rule and an outbound rule and applies them to the specified Droplet: doctl compute firewall create --name "example-firewall" --inbound-rules "protocol:tcp,ports:22,droplet_id:386734086" --outbound-rules "protocol:tcp,ports:22,address:0.0.0.0/0" --droplet-ids "386734086,391669331"`
 
-	cmdFirewallUpdate := CmdBuilder(cmd, RunFirewallUpdate, "update <id>", "Update a cloud firewall's configuration", `Updates the configuration of an existing cloud firewall. The request should contain a full representation of the firewall, including existing attributes. Any attributes that are not provided are reset to their default values.`, Writer, aliasOpt("u"), displayerType(&displayers.Firewall{}))
+	cmdFirewallUpdate := CmdBuilder(cmd, RunFirewallUpdate, "update <firewall-id|name>", "Update a cloud firewall's configuration", `Updates the configuration of an existing cloud firewall. The request should contain a full representation of the firewall, including existing attributes. Any attributes that are not provided are reset to their default values.`, Writer, aliasOpt("u"), displayerType(&displayers.Firewall{}), fromFileOpt())
 	AddStringFlag(cmdFirewallUpdate, doctl.ArgFirewallName, "", "", "The firewall's name", requiredOpt())
 	AddStringFlag(cmdFirewallUpdate, doctl.ArgInboundRules, "", "", inboundRulesTxt)
 	AddStringFlag(cmdFirewallUpdate, doctl.ArgOutboundRules, "", "", outboundRulesTxt)
@@ -92,39 +97,95 @@ Use a quoted string of space-separated values for multiple rules.`
 	cmdFirewallList := CmdBuilder(cmd, RunFirewallList, "list", "List the cloud firewalls on your account", `Retrieves a list of cloud firewalls on your account.`, Writer, aliasOpt("ls"), displayerType(&displayers.Firewall{}))
 	cmdFirewallList.Example = `The following example lists all cloud firewalls on your account and uses the ` + "`" + `--format` + "`" + ` flag to return only the ID, name and inbound rules for each firewall: doctl compute firewall list --format ID,Name,InboundRules`
 
-	cmdirewallListByDroplet := CmdBuilder(cmd, RunFirewallListByDroplet, "list-by-droplet <droplet_id>", "List firewalls by Droplet", `Lists the cloud firewalls assigned to a Droplet.`, Writer, displayerType(&displayers.Firewall{}))
+	cmdirewallListByDroplet := CmdBuilder(cmd, RunFirewallListByDroplet, "list-by-droplet <droplet_id>", "List firewalls by Droplet", `Lists the cloud firewalls assigned to a Droplet.`, Writer, displayerType(&displayers.Firewall{}), fromFileOpt())
 	cmdirewallListByDroplet.Example = `The following example lists all cloud firewalls assigned to the Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute firewall list-by-droplet 386734086`
 
-	cmdRunRecordDelete := CmdBuilder(cmd, RunFirewallDelete, "delete <id>...", "Permanently delete a cloud firewall", `Permanently deletes a cloud firewall. This is irreversible, but does not delete any Droplets assigned to the cloud firewall.`, Writer, aliasOpt("d", "rm"))
+	cmdRunRecordDelete := CmdBuilder(cmd, RunFirewallDelete, "delete <firewall-id|name>...", "Permanently delete a cloud firewall", `Permanently deletes a cloud firewall. This is irreversible, but does not delete any Droplets assigned to the cloud firewall.`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunRecordDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Deletes the firewall without a confirmation prompt")
+	addFanOutFlags(cmdRunRecordDelete)
 	cmdRunRecordDelete.Example = `The following example deletes a cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
-	cmdAddDroplets := CmdBuilder(cmd, RunFirewallAddDroplets, "add-droplets <id>", "Add Droplets to a cloud firewall", `Assigns Droplets to a cloud firewall on your account.`, Writer)
+	cmdAddDroplets := CmdBuilder(cmd, RunFirewallAddDroplets, "add-droplets <firewall-id|name>", "Add Droplets to a cloud firewall", `Assigns Droplets to a cloud firewall on your account.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdAddDroplets, doctl.ArgDropletIDs, "", []string{}, dropletIDRulesTxt)
 	cmdAddDroplets.Example = `The following example assigns two Droplets to the cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall add-droplets f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --droplet-ids "386734086,391669331"`
 
-	cmdRemoveDroplets := CmdBuilder(cmd, RunFirewallRemoveDroplets, "remove-droplets <id>", "Remove Droplets from a cloud firewall", `Removes Droplets from a cloud firewall.`, Writer)
+	cmdRemoveDroplets := CmdBuilder(cmd, RunFirewallRemoveDroplets, "remove-droplets <firewall-id|name>", "Remove Droplets from a cloud firewall", `Removes Droplets from a cloud firewall.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdRemoveDroplets, doctl.ArgDropletIDs, "", []string{}, dropletIDRulesTxt)
 	cmdRemoveDroplets.Example = `The following example removes two Droplets from a cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall remove-droplets f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --droplet-ids "386734086,391669331"`
 
-	cmdAddTags := CmdBuilder(cmd, RunFirewallAddTags, "add-tags <id>", "Add tags to a cloud firewall", `Add tags to a cloud firewall. This adds all assets using that tag to the firewall.`, Writer)
+	cmdAddTags := CmdBuilder(cmd, RunFirewallAddTags, "add-tags <firewall-id|name>", "Add tags to a cloud firewall", `Add tags to a cloud firewall. This adds all assets using that tag to the firewall.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdAddTags, doctl.ArgTagNames, "", []string{}, tagNameRulesTxt)
 	cmdAddTags.Example = `The following example adds two tags to a cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall add-tags f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --tag-names "frontend,backend"`
 
-	cmdRemoveTags := CmdBuilder(cmd, RunFirewallRemoveTags, "remove-tags <id>", "Remove tags from a cloud firewall", `Removes tags from a cloud firewall. This removes all assets using that tag from the firewall.`, Writer)
+	cmdRemoveTags := CmdBuilder(cmd, RunFirewallRemoveTags, "remove-tags <firewall-id|name>", "Remove tags from a cloud firewall", `Removes tags from a cloud firewall. This removes all assets using that tag from the firewall.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdRemoveTags, doctl.ArgTagNames, "", []string{}, tagNameRulesTxt)
 	cmdRemoveTags.Example = `The following example removes two tags from a cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall remove-tags f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --tag-names "frontend,backend"`
 
-	cmdAddRules := CmdBuilder(cmd, RunFirewallAddRules, "add-rules <id>", "Add inbound or outbound rules to a cloud firewall", `Add inbound or outbound rules to a cloud firewall.`, Writer)
+	cmdAddRules := CmdBuilder(cmd, RunFirewallAddRules, "add-rules <firewall-id|name>", "Add inbound or outbound rules to a cloud firewall", `Add inbound or outbound rules to a cloud firewall.`, Writer, fromFileOpt())
 	AddStringFlag(cmdAddRules, doctl.ArgInboundRules, "", "", inboundRulesTxt)
 	AddStringFlag(cmdAddRules, doctl.ArgOutboundRules, "", "", outboundRulesTxt)
 	cmdAddRules.Example = `The following example adds an inbound rule and an outbound rule to a cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall add-rules f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --inbound-rules "protocol:tcp,ports:22,droplet_id:386734086" --outbound-rules "protocol:tcp,ports:22,address:0.0.0.0/0"`
 
-	cmdRemoveRules := CmdBuilder(cmd, RunFirewallRemoveRules, "remove-rules <id>", "Remove inbound or outbound rules from a cloud firewall", `Remove inbound or outbound rules from a cloud firewall.`, Writer)
+	cmdRemoveRules := CmdBuilder(cmd, RunFirewallRemoveRules, "remove-rules <firewall-id|name>", "Remove inbound or outbound rules from a cloud firewall", `Remove inbound or outbound rules from a cloud firewall.`, Writer, fromFileOpt())
 	AddStringFlag(cmdRemoveRules, doctl.ArgInboundRules, "", "", inboundRulesTxt)
 	AddStringFlag(cmdRemoveRules, doctl.ArgOutboundRules, "", "", outboundRulesTxt)
 	cmdRemoveRules.Example = `The following example removes an inbound rule and an outbound rule from a cloud firewall with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute firewall remove-rules f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --inbound-rules "protocol:tcp,ports:22,droplet_id:386734086" --outbound-rules "protocol:tcp,ports:22,address:0.0.0.0/0"`
 
+	cmdSync := CmdBuilder(cmd, RunFirewallSync, "sync <firewall-id|name>", "Make a cloud firewall's rules match a file",
+		`Replaces a cloud firewall's inbound and outbound rules with the rules in a file, making only the changes needed. The rules that are added and removed are shown before the changes are made. The firewall's Droplets and tags are not changed.
+
+The file is YAML or JSON, in the format written by `+"`"+`doctl compute firewall export`+"`"+`:
+
+    inbound_rules:
+      - protocol: tcp
+        ports: "22"
+        sources:
+          addresses: ["192.0.2.0/24"]
+          tags: ["bastion"]
+      - protocol: tcp
+        ports: "443"
+        sources:
+          load_balancer_uids: ["4de7ac8b-495b-4884-9a69-1050c6793cd6"]
+    outbound_rules:
+      - protocol: tcp
+        ports: all
+        destinations:
+          addresses: ["0.0.0.0/0", "::/0"]
+
+Sources and destinations may list `+"`"+`addresses`+"`"+`, `+"`"+`tags`+"`"+`, `+"`"+`droplet_ids`+"`"+`, `+"`"+`load_balancer_uids`+"`"+`, and `+"`"+`kubernetes_ids`+"`"+`.`, Writer, fromFileOpt())
+	AddStringFlag(cmdSync, doctl.ArgRulesFile, "f", "", "The path to the rules file, or `-` to read it from standard input", requiredOpt())
+	AddBoolFlag(cmdSync, doctl.ArgPlan, "", false, "Show the rules that would be added and removed without changing the firewall")
+	cmdSync.Example = `The following example makes the rules of the cloud firewall named ` + "`" + `web` + "`" + ` match ` + "`" + `rules.yaml` + "`" + `: doctl compute firewall sync web -f rules.yaml`
+
+	cmdExport := CmdBuilder(cmd, RunFirewallExport, "export <firewall-id|name>", "Write a cloud firewall's rules to a file",
+		`Writes a cloud firewall's inbound and outbound rules in the format read by `+"`"+`doctl compute firewall sync`+"`"+`. The rules are written as YAML, or as JSON if you pass `+"`"+`--output json`+"`"+`.`, Writer, fromFileOpt())
+	cmdExport.Example = `The following example writes the rules of the cloud firewall named ` + "`" + `web` + "`" + ` to ` + "`" + `rules.yaml` + "`" + `: doctl compute firewall export web > rules.yaml`
+
+	cmdCheck := CmdBuilder(cmd, RunFirewallCheck, "check", "Check whether cloud firewalls allow traffic to a Droplet",
+		`Evaluates the cloud firewalls that apply to a Droplet, by its ID or by its tags, and reports whether they allow traffic from an address, a tag or another Droplet to reach it on a port. Each result names the firewall and rule that allows the traffic.
+
+The `+"`"+`--from`+"`"+` flag accepts an address or CIDR block, a Droplet ID or name, or a tag. A tag is checked for each Droplet that has it. Prefix the value with `+"`"+`droplet:`+"`"+` or `+"`"+`tag:`+"`"+` when a Droplet and a tag have the same name. When the traffic comes from a Droplet, the outbound rules of its firewalls are checked as well.
+
+A Droplet that no firewall applies to accepts all traffic. The command exits with an error when the traffic is denied.`, Writer, displayerType(&displayers.FirewallChecks{}))
+	AddStringFlag(cmdCheck, doctl.ArgFirewallCheckDroplet, "", "", "The ID or name of the Droplet that receives the traffic", requiredOpt())
+	AddStringFlag(cmdCheck, doctl.ArgFirewallCheckFrom, "", "", "The address, CIDR block, tag, or Droplet ID or name that sends the traffic", requiredOpt())
+	AddIntFlag(cmdCheck, doctl.ArgFirewallCheckPort, "", 0, "The port that the traffic is sent to. Not used for ICMP.")
+	AddStringFlag(cmdCheck, doctl.ArgFirewallCheckProtocol, "", "tcp", "The protocol of the traffic: `tcp`, `udp`, or `icmp`")
+	cmdCheck.Example = `The following example checks whether 203.0.113.5 can reach the Droplet named ` + "`" + `web-3` + "`" + ` on TCP port 5432: doctl compute firewall check --droplet web-3 --from 203.0.113.5 --port 5432`
+
+	cmdAudit := CmdBuilder(cmd, RunFirewallAudit, "audit", "Report risky cloud firewall configurations",
+		`Audits the cloud firewalls and Droplets on your account and reports:
+
+- `+"`"+`open-port`+"`"+` (high): inbound rules that open a sensitive port to `+"`"+`0.0.0.0/0`+"`"+` or `+"`"+`::/0`+"`"+`. The sensitive ports are 22, 3306, 5432, 6379, 9200, and 27017, plus any given with `+"`"+`--sensitive-ports`+"`"+`.
+- `+"`"+`unprotected-droplet`+"`"+` (medium): Droplets that no firewall applies to.
+- `+"`"+`unused-firewall`+"`"+` (low): firewalls that apply to no Droplets.
+- `+"`"+`duplicate-rule`+"`"+` and `+"`"+`overlapping-rule`+"`"+` (low): rules that repeat or are covered by another rule of the same firewall.
+
+The command exits with an error when it finds a problem of the severity given by `+"`"+`--fail-on`+"`"+` or higher, so it can be run as a CI check.`, Writer, displayerType(&displayers.FirewallFindings{}))
+	AddStringSliceFlag(cmdAudit, doctl.ArgFirewallAuditPorts, "", []string{}, "Additional ports to treat as sensitive, such as `8080,9090`")
+	AddStringFlag(cmdAudit, doctl.ArgFirewallAuditFailOn, "", "low", "The lowest severity that makes the command fail: `high`, `medium`, `low`, or `never`")
+	cmdAudit.Example = `The following example fails only when a sensitive port, including 8080, is open to the internet: doctl compute firewall audit --sensitive-ports 8080 --fail-on high`
+
 	return cmd
 }
 
@@ -134,9 +195,11 @@ func RunFirewallGet(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	id := c.Args[0]
-
 	fs := c.Firewalls()
+	id, err := do.ResolveFirewallID(fs, c.Args[0])
+	if err != nil {
+		return err
+	}
 	f, err := fs.Get(id)
 	if err != nil {
 		return err
@@ -168,7 +231,10 @@ func RunFirewallUpdate(c *CmdConfig) error {
 	if len(c.Args) == 0 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	fID := c.Args[0]
+	fID, err := do.ResolveFirewallID(c.Firewalls(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	r := new(godo.FirewallRequest)
 	if err := buildFirewallRequestFromArgs(c, r); err != nil {
@@ -231,16 +297,16 @@ func RunFirewallDelete(c *CmdConfig) error {
 
 	fs := c.Firewalls()
 	if force || AskForConfirmDelete("firewall", len(c.Args)) == nil {
-		for _, id := range c.Args {
-			if err := fs.Delete(id); err != nil {
+		return fanOut(c, c.Args, func(ref string) error {
+			id, err := do.ResolveFirewallID(fs, ref)
+			if err != nil {
 				return err
 			}
-		}
-	} else {
-		return errOperationAborted
+			return fs.Delete(id)
+		})
 	}
 
-	return nil
+	return errOperationAborted
 }
 
 // RunFirewallAddDroplets adds droplets to a Firewall.
@@ -249,7 +315,10 @@ func RunFirewallAddDroplets(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	fID := c.Args[0]
+	fID, err := do.ResolveFirewallID(c.Firewalls(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	dropletIDsList, err := c.Doit.GetStringSlice(c.NS, doctl.ArgDropletIDs)
 	if err != nil {
@@ -270,7 +339,10 @@ func RunFirewallRemoveDroplets(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	fID := c.Args[0]
+	fID, err := do.ResolveFirewallID(c.Firewalls(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	dropletIDsList, err := c.Doit.GetStringSlice(c.NS, doctl.ArgDropletIDs)
 	if err != nil {
@@ -291,7 +363,10 @@ func RunFirewallAddTags(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	fID := c.Args[0]
+	fID, err := do.ResolveFirewallID(c.Firewalls(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	tagList, err := c.Doit.GetStringSlice(c.NS, doctl.ArgTagNames)
 	if err != nil {
@@ -307,7 +382,10 @@ func RunFirewallRemoveTags(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	fID := c.Args[0]
+	fID, err := do.ResolveFirewallID(c.Firewalls(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	tagList, err := c.Doit.GetStringSlice(c.NS, doctl.ArgTagNames)
 	if err != nil {
@@ -323,7 +401,10 @@ func RunFirewallAddRules(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	fID := c.Args[0]
+	fID, err := do.ResolveFirewallID(c.Firewalls(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	rr := new(godo.FirewallRulesRequest)
 	if err := buildFirewallRulesRequestFromArgs(c, rr); err != nil {
@@ -339,7 +420,10 @@ func RunFirewallRemoveRules(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	fID := c.Args[0]
+	fID, err := do.ResolveFirewallID(c.Firewalls(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	rr := new(godo.FirewallRulesRequest)
 	if err := buildFirewallRulesRequestFromArgs(c, rr); err != nil {
@@ -349,6 +433,117 @@ func RunFirewallRemoveRules(c *CmdConfig) error {
 	return c.Firewalls().RemoveRules(fID, rr)
 }
 
+// firewallRules is the format of the files read by firewall sync and written
+// by firewall export.
+type firewallRules struct {
+	InboundRules  []godo.InboundRule  `json:"inbound_rules,omitempty"`
+	OutboundRules []godo.OutboundRule `json:"outbound_rules,omitempty"`
+}
+
+// RunFirewallSync makes a Firewall's rules match a rules file.
+func RunFirewallSync(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+	fs := c.Firewalls()
+	fID, err := do.ResolveFirewallID(fs, c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	path, err := c.Doit.GetString(c.NS, doctl.ArgRulesFile)
+	if err != nil {
+		return err
+	}
+	planOnly, err := c.Doit.GetBool(c.NS, doctl.ArgPlan)
+	if err != nil {
+		return err
+	}
+
+	b, err := readInput(os.Stdin, path)
+	if err != nil {
+		return fmt.Errorf("reading rules file: %w", err)
+	}
+	var want firewallRules
+	if err := yaml.UnmarshalStrict(b, &want); err != nil {
+		return fmt.Errorf("parsing rules file: %w", err)
+	}
+
+	f, err := fs.Get(fID)
+	if err != nil {
+		return err
+	}
+
+	added, removed := diffFirewallRules(
+		firewallRuleAtoms(f.InboundRules, f.OutboundRules),
+		firewallRuleAtoms(want.InboundRules, want.OutboundRules),
+	)
+	for _, a := range added {
+		fmt.Fprintln(c.Out, color.GreenString("+ %s", a))
+	}
+	for _, a := range removed {
+		fmt.Fprintln(c.Out, color.RedString("- %s", a))
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Fprintln(c.Out, "No changes. The firewall's rules match the file.")
+		return nil
+	}
+	fmt.Fprintf(c.Out, "\n%d to add, %d to remove.\n", len(added), len(removed))
+	if planOnly {
+		return nil
+	}
+
+	// Rules are added before they are removed, so that traffic which the
+	// file still allows is never blocked in between.
+	if len(added) > 0 {
+		if err := fs.AddRules(fID, groupFirewallRuleAtoms(added)); err != nil {
+			return err
+		}
+	}
+	if len(removed) > 0 {
+		if err := fs.RemoveRules(fID, groupFirewallRuleAtoms(removed)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunFirewallExport writes a Firewall's rules in the format read by sync.
+func RunFirewallExport(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+	fs := c.Firewalls()
+	fID, err := do.ResolveFirewallID(fs, c.Args[0])
+	if err != nil {
+		return err
+	}
+
+	f, err := fs.Get(fID)
+	if err != nil {
+		return err
+	}
+
+	// Rules are written in the same canonical form that sync compares, so
+	// that exporting and syncing a firewall makes no changes.
+	rules := groupFirewallRuleAtoms(firewallRuleAtoms(f.InboundRules, f.OutboundRules))
+	out := firewallRules{InboundRules: rules.InboundRules, OutboundRules: rules.OutboundRules}
+
+	if viper.GetString("output") == "json" {
+		e := json.NewEncoder(c.Out)
+		e.SetIndent("", "  ")
+		return e.Encode(out)
+	}
+	b, err := yaml.Marshal(out)
+	if err != nil {
+		return err
+	}
+	_, err = c.Out.Write(b)
+	return err
+}
+
 func buildFirewallRequestFromArgs(c *CmdConfig, r *godo.FirewallRequest) error {
 	name, err := c.Doit.GetString(c.NS, doctl.ArgFirewallName)
 	if err != nil {
@@ -512,3 +707,167 @@ func extractRule(ruleStr string, sd string) (map[string]any, error) {
 
 	return rule, nil
 }
+
+// firewallRuleAtom is a firewall rule narrowed to a single source or
+// destination. Comparing atoms rather than whole rules finds the smallest set
+// of changes between two rule lists.
+type firewallRuleAtom struct {
+	Direction string
+	Protocol  string
+	Ports     string
+	// Kind is the type of source or destination, named as in the
+	// --inbound-rules and --outbound-rules flags, such as address or tag.
+	Kind  string
+	Value string
+}
+
+// String formats the atom like the --inbound-rules and --outbound-rules
+// flags.
+func (a firewallRuleAtom) String() string {
+	rule := "protocol:" + a.Protocol
+	if a.Ports != "" {
+		rule += ",ports:" + a.Ports
+	}
+	return fmt.Sprintf("%s %s,%s:%s", a.Direction, rule, a.Kind, a.Value)
+}
+
+func (a firewallRuleAtom) less(b firewallRuleAtom) bool {
+	ka := []string{a.Direction, a.Protocol, a.Ports, a.Kind, a.Value}
+	kb := []string{b.Direction, b.Protocol, b.Ports, b.Kind, b.Value}
+	return slices.Compare(ka, kb) < 0
+}
+
+// normalizeFirewallPorts gives equivalent port specifications one form: ICMP
+// rules have no ports, and an empty port or 0 means all ports.
+func normalizeFirewallPorts(protocol, ports string) string {
+	switch {
+	case protocol == "icmp":
+		return ""
+	case ports == "" || ports == "0":
+		return "all"
+	}
+	return ports
+}
+
+func appendFirewallRuleAtoms(atoms []firewallRuleAtom, direction, protocol, ports string, s *godo.Sources) []firewallRuleAtom {
+	if s == nil {
+		return atoms
+	}
+	protocol = strings.ToLower(protocol)
+	base := firewallRuleAtom{Direction: direction, Protocol: protocol, Ports: normalizeFirewallPorts(protocol, ports)}
+	add := func(kind string, values ...string) {
+		for _, v := range values {
+			a := base
+			a.Kind, a.Value = kind, v
+			atoms = append(atoms, a)
+		}
+	}
+	add("address", s.Addresses...)
+	add("tag", s.Tags...)
+	for _, id := range s.DropletIDs {
+		add("droplet_id", strconv.Itoa(id))
+	}
+	add("load_balancer_uid", s.LoadBalancerUIDs...)
+	add("kubernetes_id", s.KubernetesIDs...)
+	return atoms
+}
+
+// firewallRuleAtoms splits rules into atoms, without duplicates and in a
+// stable order.
+func firewallRuleAtoms(inbound []godo.InboundRule, outbound []godo.OutboundRule) []firewallRuleAtom {
+	atoms := splitFirewallRules(inbound, outbound)
+	sort.Slice(atoms, func(i, j int) bool { return atoms[i].less(atoms[j]) })
+	return slices.Compact(atoms)
+}
+
+// splitFirewallRules splits rules into atoms in the order of the rules,
+// keeping duplicates.
+func splitFirewallRules(inbound []godo.InboundRule, outbound []godo.OutboundRule) []firewallRuleAtom {
+	var atoms []firewallRuleAtom
+	for _, r := range inbound {
+		atoms = appendFirewallRuleAtoms(atoms, "inbound", r.Protocol, r.PortRange, r.Sources)
+	}
+	for _, r := range outbound {
+		d := r.Destinations
+		if d == nil {
+			continue
+		}
+		atoms = appendFirewallRuleAtoms(atoms, "outbound", r.Protocol, r.PortRange, &godo.Sources{
+			Addresses:        d.Addresses,
+			Tags:             d.Tags,
+			DropletIDs:       d.DropletIDs,
+			LoadBalancerUIDs: d.LoadBalancerUIDs,
+			KubernetesIDs:    d.KubernetesIDs,
+		})
+	}
+	return atoms
+}
+
+// diffFirewallRules returns the atoms in want but not in have, and those in
+// have but not in want.
+func diffFirewallRules(have, want []firewallRuleAtom) (added, removed []firewallRuleAtom) {
+	haveSet := map[firewallRuleAtom]bool{}
+	for _, a := range have {
+		haveSet[a] = true
+	}
+	wantSet := map[firewallRuleAtom]bool{}
+	for _, a := range want {
+		wantSet[a] = true
+		if !haveSet[a] {
+			added = append(added, a)
+		}
+	}
+	for _, a := range have {
+		if !wantSet[a] {
+			removed = append(removed, a)
+		}
+	}
+	return added, removed
+}
+
+// groupFirewallRuleAtoms joins atoms that share a direction, protocol, and
+// ports back into rules.
+func groupFirewallRuleAtoms(atoms []firewallRuleAtom) *godo.FirewallRulesRequest {
+	type ruleKey struct{ direction, protocol, ports string }
+	var keys []ruleKey
+	targets := map[ruleKey]*godo.Sources{}
+	for _, a := range atoms {
+		k := ruleKey{a.Direction, a.Protocol, a.Ports}
+		s, ok := targets[k]
+		if !ok {
+			s = &godo.Sources{}
+			targets[k] = s
+			keys = append(keys, k)
+		}
+		switch a.Kind {
+		case "address":
+			s.Addresses = append(s.Addresses, a.Value)
+		case "tag":
+			s.Tags = append(s.Tags, a.Value)
+		case "droplet_id":
+			id, _ := strconv.Atoi(a.Value)
+			s.DropletIDs = append(s.DropletIDs, id)
+		case "load_balancer_uid":
+			s.LoadBalancerUIDs = append(s.LoadBalancerUIDs, a.Value)
+		case "kubernetes_id":
+			s.KubernetesIDs = append(s.KubernetesIDs, a.Value)
+		}
+	}
+
+	rr := &godo.FirewallRulesRequest{}
+	for _, k := range keys {
+		s := targets[k]
+		if k.direction == "inbound" {
+			rr.InboundRules = append(rr.InboundRules, godo.InboundRule{Protocol: k.protocol, PortRange: k.ports, Sources: s})
+			continue
+		}
+		rr.OutboundRules = append(rr.OutboundRules, godo.OutboundRule{Protocol: k.protocol, PortRange: k.ports, Destinations: &godo.Destinations{
+			Addresses:        s.Addresses,
+			Tags:             s.Tags,
+			DropletIDs:       s.DropletIDs,
+			LoadBalancerUIDs: s.LoadBalancerUIDs,
+			KubernetesIDs:    s.KubernetesIDs,
+		}})
+	}
+	return rr
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+)
+
+// defaultSensitivePorts are the ports that firewall audit reports when they
+// are open to the whole internet: SSH, MySQL, PostgreSQL, Redis,
+// Elasticsearch and MongoDB.
+var defaultSensitivePorts = []int{22, 3306, 5432, 6379, 9200, 27017}
+
+// firewallAuditSeverities are the severities of audit findings, from lowest
+// to highest.
+var firewallAuditSeverities = []string{"low", "medium", "high"}
+
+// RunFirewallAudit reports rules that open sensitive ports to the internet,
+// Droplets that no firewall applies to, firewalls that apply to nothing, and
+// rules that repeat or overlap other rules.
+func RunFirewallAudit(c *CmdConfig) error {
+	extraPorts, err := c.Doit.GetStringSlice(c.NS, doctl.ArgFirewallAuditPorts)
+	if err != nil {
+		return err
+	}
+	failOn, err := c.Doit.GetString(c.NS, doctl.ArgFirewallAuditFailOn)
+	if err != nil {
+		return err
+	}
+
+	ports := slices.Clone(defaultSensitivePorts)
+	for _, p := range extraPorts {
+		port, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("--%s %q is not a valid port", doctl.ArgFirewallAuditPorts, p)
+		}
+		ports = append(ports, port)
+	}
+	slices.Sort(ports)
+	ports = slices.Compact(ports)
+
+	failRank := slices.Index(firewallAuditSeverities, failOn)
+	if failRank < 0 && failOn != "never" {
+		return fmt.Errorf("--%s %q is not valid; valid values are high, medium, low and never", doctl.ArgFirewallAuditFailOn, failOn)
+	}
+
+	fws, err := c.Firewalls().List()
+	if err != nil {
+		return err
+	}
+	droplets, err := c.Droplets().List()
+	if err != nil {
+		return err
+	}
+
+	var findings []displayers.FirewallFinding
+	for _, fw := range fws {
+		findings = append(findings, auditFirewallRules(fw, ports)...)
+	}
+	findings = append(findings, auditFirewallCoverage(fws, droplets)...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		return slices.Index(firewallAuditSeverities, findings[i].Severity) > slices.Index(firewallAuditSeverities, findings[j].Severity)
+	})
+
+	if err := c.Display(&displayers.FirewallFindings{Findings: findings}); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, f := range findings {
+		if failRank >= 0 && slices.Index(firewallAuditSeverities, f.Severity) >= failRank {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("the audit found %d problems of severity %s or higher", failed, failOn)
+	}
+	return nil
+}
+
+// auditFirewallRules finds the rules of a firewall that open sensitive ports
+// to every address, and rules that repeat or are covered by another rule.
+func auditFirewallRules(fw do.Firewall, sensitivePorts []int) []displayers.FirewallFinding {
+	var findings []displayers.FirewallFinding
+	add := func(severity, check, detail string) {
+		findings = append(findings, displayers.FirewallFinding{
+			Severity: severity,
+			Check:    check,
+			Resource: "firewall " + fw.Name,
+			Detail:   detail,
+		})
+	}
+
+	atoms := firewallRuleAtoms(fw.InboundRules, fw.OutboundRules)
+	for _, a := range atoms {
+		if a.Direction != "inbound" || a.Kind != "address" || a.Protocol == "icmp" || !isAnyAddress(a.Value) {
+			continue
+		}
+		var open []string
+		for _, port := range sensitivePorts {
+			if firewallPortsInclude(a.Ports, port) {
+				open = append(open, strconv.Itoa(port))
+			}
+		}
+		if len(open) > 0 {
+			add("high", "open-port", fmt.Sprintf("%s opens port %s to the internet", a, strings.Join(open, ", ")))
+		}
+	}
+
+	seen := map[firewallRuleAtom]bool{}
+	for _, a := range splitFirewallRules(fw.InboundRules, fw.OutboundRules) {
+		if seen[a] {
+			add("low", "duplicate-rule", fmt.Sprintf("%s is listed more than once", a))
+			continue
+		}
+		seen[a] = true
+	}
+
+	for i, a := range atoms {
+		for j, b := range atoms {
+			if i != j && firewallAtomCovers(b, a) {
+				add("low", "overlapping-rule", fmt.Sprintf("%s is already allowed by %s", a, b))
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// auditFirewallCoverage finds Droplets that no firewall applies to, and
+// firewalls that apply to no Droplets.
+func auditFirewallCoverage(fws do.Firewalls, droplets do.Droplets) []displayers.FirewallFinding {
+	var findings []displayers.FirewallFinding
+
+	coveredIDs := map[int]bool{}
+	firewallTags := map[string]bool{}
+	for _, fw := range fws {
+		for _, id := range fw.DropletIDs {
+			coveredIDs[id] = true
+		}
+		for _, tag := range fw.Tags {
+			firewallTags[tag] = true
+		}
+	}
+	dropletTags := map[string]bool{}
+	for _, d := range droplets {
+		covered := coveredIDs[d.ID]
+		for _, tag := range d.Tags {
+			dropletTags[tag] = true
+			covered = covered || firewallTags[tag]
+		}
+		if !covered {
+			findings = append(findings, displayers.FirewallFinding{
+				Severity: "medium",
+				Check:    "unprotected-droplet",
+				Resource: "droplet " + d.Name,
+				Detail:   fmt.Sprintf("no firewall applies to Droplet %d", d.ID),
+			})
+		}
+	}
+
+	for _, fw := range fws {
+		if len(fw.DropletIDs) > 0 || slices.ContainsFunc(fw.Tags, func(tag string) bool { return dropletTags[tag] }) {
+			continue
+		}
+		detail := "the firewall applies to no Droplets or tags"
+		if len(fw.Tags) > 0 {
+			detail = fmt.Sprintf("no Droplets have the tags %s", strings.Join(fw.Tags, ", "))
+		}
+		findings = append(findings, displayers.FirewallFinding{
+			Severity: "low",
+			Check:    "unused-firewall",
+			Resource: "firewall " + fw.Name,
+			Detail:   detail,
+		})
+	}
+	return findings
+}
+
+// firewallAtomCovers reports whether rule atom b allows all the traffic that
+// a different atom a allows.
+func firewallAtomCovers(b, a firewallRuleAtom) bool {
+	if a == b || a.Direction != b.Direction || a.Protocol != b.Protocol {
+		return false
+	}
+	aLo, aHi, aOK := parseFirewallPorts(a.Ports)
+	bLo, bHi, bOK := parseFirewallPorts(b.Ports)
+	if !aOK || !bOK || bLo > aLo || aHi > bHi {
+		return false
+	}
+	if a.Kind != b.Kind {
+		return false
+	}
+	if a.Kind != "address" {
+		return a.Value == b.Value
+	}
+	network, err := parseFirewallAddress(a.Value)
+	return err == nil && firewallEndpoint{network: network}.matches(b)
+}
+
+func isAnyAddress(s string) bool {
+	network, err := parseFirewallAddress(s)
+	if err != nil {
+		return false
+	}
+	ones, _ := network.Mask.Size()
+	return ones == 0
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAuditFirewalls = do.Firewalls{
+	{Firewall: &godo.Firewall{
+		ID:   "fw-web",
+		Name: "web",
+		Tags: []string{"web"},
+		InboundRules: []godo.InboundRule{
+			{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0", "::/0"}}},
+			{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0", "203.0.113.0/24"}}},
+			{Protocol: "TCP", PortRange: "443", Sources: &godo.Sources{Addresses: []string{"::/0"}}},
+			{Protocol: "tcp", PortRange: "8000-9000", Sources: &godo.Sources{Addresses: []string{"::/0"}}},
+		},
+	}},
+	{Firewall: &godo.Firewall{
+		ID:   "fw-old",
+		Name: "old",
+		Tags: []string{"legacy"},
+	}},
+}
+
+var testAuditDroplets = do.Droplets{
+	{Droplet: &godo.Droplet{ID: 1, Name: "web-1", Tags: []string{"web"}}},
+	{Droplet: &godo.Droplet{ID: 2, Name: "scratch"}},
+}
+
+func TestFirewallAudit(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.firewalls.EXPECT().List().Return(testAuditFirewalls, nil)
+		tm.droplets.EXPECT().List().Return(testAuditDroplets, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFirewallAuditPorts, []string{"8080"})
+		config.Doit.Set(config.NS, doctl.ArgFirewallAuditFailOn, "high")
+
+		err := RunFirewallAudit(config)
+		assert.EqualError(t, err, "the audit found 2 problems of severity high or higher")
+
+		out := buf.String()
+		assert.Regexp(t, `high\s+open-port\s+firewall web\s+inbound protocol:tcp,ports:22,address:0.0.0.0/0 opens port 22 to the internet`, out)
+		assert.Regexp(t, `high\s+open-port\s+firewall web\s+inbound protocol:tcp,ports:8000-9000,address:::/0 opens port 8080 to the internet`, out)
+		assert.Regexp(t, `medium\s+unprotected-droplet\s+droplet scratch\s+no firewall applies to Droplet 2`, out)
+		assert.Regexp(t, `low\s+unused-firewall\s+firewall old\s+no Droplets have the tags legacy`, out)
+		assert.Regexp(t, `low\s+duplicate-rule\s+firewall web\s+inbound protocol:tcp,ports:443,address:::/0 is listed more than once`, out)
+		assert.Regexp(t, `low\s+overlapping-rule\s+firewall web\s+inbound protocol:tcp,ports:22,address:203.0.113.0/24 is already allowed by inbound protocol:tcp,ports:22,address:0.0.0.0/0`, out)
+		assert.NotContains(t, out, "ports:443,address:0.0.0.0/0 opens")
+	})
+}
+
+func TestFirewallAuditFailOnNever(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.firewalls.EXPECT().List().Return(testAuditFirewalls, nil)
+		tm.droplets.EXPECT().List().Return(testAuditDroplets, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgFirewallAuditFailOn, "never")
+
+		err := RunFirewallAudit(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestFirewallAuditInvalidFlags(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgFirewallAuditPorts, []string{"http"})
+		config.Doit.Set(config.NS, doctl.ArgFirewallAuditFailOn, "low")
+
+		err := RunFirewallAudit(config)
+		assert.EqualError(t, err, `--sensitive-ports "http" is not a valid port`)
+
+		config.Doit.Set(config.NS, doctl.ArgFirewallAuditPorts, []string{})
+		config.Doit.Set(config.NS, doctl.ArgFirewallAuditFailOn, "critical")
+		err = RunFirewallAudit(config)
+		assert.EqualError(t, err, `--fail-on "critical" is not valid; valid values are high, medium, low and never`)
+	})
+}
+
+func TestAuditFirewallCoverage(t *testing.T) {
+	findings := auditFirewallCoverage(testAuditFirewalls, testAuditDroplets)
+	require.Len(t, findings, 2)
+	assert.Equal(t, displayers.FirewallFinding{
+		Severity: "medium",
+		Check:    "unprotected-droplet",
+		Resource: "droplet scratch",
+		Detail:   "no firewall applies to Droplet 2",
+	}, findings[0])
+	assert.Equal(t, "unused-firewall", findings[1].Check)
+}
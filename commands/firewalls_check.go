@@ -0,0 +1,335 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+)
+
+const (
+	firewallAllow = "allow"
+	firewallDeny  = "deny"
+)
+
+// firewallEndpoint is one end of the traffic that firewall check evaluates:
+// either a Droplet, or a range of addresses outside of DigitalOcean.
+type firewallEndpoint struct {
+	droplet *do.Droplet
+	network *net.IPNet
+}
+
+func (e firewallEndpoint) String() string {
+	if e.droplet != nil {
+		return e.droplet.Name
+	}
+	return e.network.String()
+}
+
+// matches reports whether the source or destination of a rule atom covers
+// the endpoint.
+func (e firewallEndpoint) matches(a firewallRuleAtom) bool {
+	switch a.Kind {
+	case "address":
+		rule, err := parseFirewallAddress(a.Value)
+		if err != nil {
+			return false
+		}
+		if e.network != nil {
+			ruleOnes, ruleBits := rule.Mask.Size()
+			ones, bits := e.network.Mask.Size()
+			return ruleBits == bits && ruleOnes <= ones && rule.Contains(e.network.IP)
+		}
+		for _, ip := range dropletIPs(e.droplet) {
+			if rule.Contains(ip) {
+				return true
+			}
+		}
+	case "tag":
+		return e.droplet != nil && slices.Contains(e.droplet.Tags, a.Value)
+	case "droplet_id":
+		return e.droplet != nil && strconv.Itoa(e.droplet.ID) == a.Value
+	case "kubernetes_id":
+		// Kubernetes nodes are tagged with the ID of their cluster.
+		return e.droplet != nil && slices.Contains(e.droplet.Tags, "k8s:"+a.Value)
+	}
+	return false
+}
+
+// RunFirewallCheck reports whether the cloud firewalls let traffic from an
+// address, tag or Droplet reach a Droplet on a port, and which rule decides
+// it.
+func RunFirewallCheck(c *CmdConfig) error {
+	dropletRef, err := c.Doit.GetString(c.NS, doctl.ArgFirewallCheckDroplet)
+	if err != nil {
+		return err
+	}
+	from, err := c.Doit.GetString(c.NS, doctl.ArgFirewallCheckFrom)
+	if err != nil {
+		return err
+	}
+	port, err := c.Doit.GetInt(c.NS, doctl.ArgFirewallCheckPort)
+	if err != nil {
+		return err
+	}
+	protocol, err := c.Doit.GetString(c.NS, doctl.ArgFirewallCheckProtocol)
+	if err != nil {
+		return err
+	}
+
+	protocol = strings.ToLower(protocol)
+	service := fmt.Sprintf("%s/%d", protocol, port)
+	switch protocol {
+	case "tcp", "udp":
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("--%s must be between 1 and 65535", doctl.ArgFirewallCheckPort)
+		}
+	case "icmp":
+		service = protocol
+	default:
+		return fmt.Errorf("--%s %q is not valid; valid values are tcp, udp and icmp", doctl.ArgFirewallCheckProtocol, protocol)
+	}
+
+	ds := c.Droplets()
+	id, err := do.ResolveDropletID(ds, dropletRef)
+	if err != nil {
+		return err
+	}
+	target, err := ds.Get(id)
+	if err != nil {
+		return err
+	}
+	sources, err := resolveFirewallEndpoints(c, from)
+	if err != nil {
+		return err
+	}
+
+	all, err := c.Firewalls().List()
+	if err != nil {
+		return err
+	}
+	targetFirewalls, err := dropletFirewalls(c, all, target)
+	if err != nil {
+		return err
+	}
+
+	var (
+		checks []displayers.FirewallCheck
+		denied int
+	)
+	for _, src := range sources {
+		allowed := true
+		if src.droplet != nil {
+			srcFirewalls, err := dropletFirewalls(c, all, src.droplet)
+			if err != nil {
+				return err
+			}
+			check := evaluateFirewalls(srcFirewalls, "outbound", protocol, port, firewallEndpoint{droplet: target})
+			check.From, check.To, check.Droplet = src.String(), target.Name, src.droplet.Name
+			checks = append(checks, check)
+			allowed = check.Result == firewallAllow
+		}
+
+		check := evaluateFirewalls(targetFirewalls, "inbound", protocol, port, src)
+		check.From, check.To, check.Droplet = src.String(), target.Name, target.Name
+		checks = append(checks, check)
+		if !allowed || check.Result != firewallAllow {
+			denied++
+		}
+	}
+
+	if err := c.Display(&displayers.FirewallChecks{Checks: checks}); err != nil {
+		return err
+	}
+	switch {
+	case denied == 0:
+		return nil
+	case len(sources) == 1:
+		return fmt.Errorf("%s from %s to %s is denied", service, sources[0], target.Name)
+	default:
+		return fmt.Errorf("%s to %s is denied from %d of %d Droplets", service, target.Name, denied, len(sources))
+	}
+}
+
+// evaluateFirewalls finds the first rule of the firewalls that allows
+// traffic in direction with peer. Traffic is allowed when no firewall
+// applies, and denied when none of the rules match.
+func evaluateFirewalls(fws do.Firewalls, direction, protocol string, port int, peer firewallEndpoint) displayers.FirewallCheck {
+	check := displayers.FirewallCheck{Direction: direction}
+	if len(fws) == 0 {
+		check.Result, check.Firewall, check.Rule = firewallAllow, "(none)", "no firewall applies, so all traffic is allowed"
+		return check
+	}
+
+	names := make([]string, 0, len(fws))
+	for _, fw := range fws {
+		names = append(names, fw.Name)
+		for _, a := range firewallRuleAtoms(fw.InboundRules, fw.OutboundRules) {
+			// ICMP has no ports, so any ICMP rule matches.
+			if a.Direction == direction && a.Protocol == protocol && (protocol == "icmp" || firewallPortsInclude(a.Ports, port)) && peer.matches(a) {
+				check.Result, check.Firewall, check.Rule = firewallAllow, fw.Name, a.String()
+				return check
+			}
+		}
+	}
+	check.Result, check.Firewall, check.Rule = firewallDeny, strings.Join(names, ","), "no rule allows the traffic"
+	return check
+}
+
+// resolveFirewallEndpoints resolves the --from flag of firewall check, which
+// is an address or CIDR block, a Droplet ID or name, or a tag. A tag
+// resolves to every Droplet with the tag. The prefixes droplet: and tag:
+// remove the ambiguity between a Droplet name and a tag.
+func resolveFirewallEndpoints(c *CmdConfig, ref string) ([]firewallEndpoint, error) {
+	ds := c.Droplets()
+	if tag, ok := strings.CutPrefix(ref, "tag:"); ok {
+		return taggedFirewallEndpoints(ds, tag)
+	}
+	dropletRef, isDroplet := strings.CutPrefix(ref, "droplet:")
+	if !isDroplet {
+		if network, err := parseFirewallAddress(ref); err == nil {
+			return []firewallEndpoint{{network: network}}, nil
+		}
+	}
+
+	id, err := do.ResolveDropletID(ds, dropletRef)
+	var notFound *do.NameNotFoundError
+	if !isDroplet && errors.As(err, &notFound) {
+		endpoints, err := taggedFirewallEndpoints(ds, ref)
+		if err == nil && len(endpoints) == 0 {
+			return nil, fmt.Errorf("no Droplet or tag matches --%s %q", doctl.ArgFirewallCheckFrom, ref)
+		}
+		return endpoints, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	d, err := ds.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return []firewallEndpoint{{droplet: d}}, nil
+}
+
+func taggedFirewallEndpoints(ds do.DropletsService, tag string) ([]firewallEndpoint, error) {
+	droplets, err := ds.ListByTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]firewallEndpoint, 0, len(droplets))
+	for i := range droplets {
+		endpoints = append(endpoints, firewallEndpoint{droplet: &droplets[i]})
+	}
+	return endpoints, nil
+}
+
+// dropletFirewalls returns the firewalls that apply to a Droplet, either by
+// its ID or by one of its tags.
+func dropletFirewalls(c *CmdConfig, all do.Firewalls, d *do.Droplet) (do.Firewalls, error) {
+	byDroplet, err := c.Firewalls().ListByDroplet(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fws do.Firewalls
+	seen := map[string]bool{}
+	add := func(fw do.Firewall) {
+		if !seen[fw.ID] {
+			seen[fw.ID] = true
+			fws = append(fws, fw)
+		}
+	}
+	for _, fw := range byDroplet {
+		add(fw)
+	}
+	for _, fw := range all {
+		for _, tag := range fw.Tags {
+			if slices.Contains(d.Tags, tag) {
+				add(fw)
+				break
+			}
+		}
+	}
+	return fws, nil
+}
+
+// firewallPortsInclude reports whether a normalized port specification, such
+// as all, 22 or 8000-9000, includes port.
+func firewallPortsInclude(ports string, port int) bool {
+	lo, hi, ok := parseFirewallPorts(ports)
+	return ok && lo <= port && port <= hi
+}
+
+// parseFirewallPorts returns the first and last port of a normalized port
+// specification. All ports, and the ports of ICMP rules, are 1-65535.
+func parseFirewallPorts(ports string) (lo, hi int, ok bool) {
+	if ports == "" || ports == "all" {
+		return 1, 65535, true
+	}
+	low, high, isRange := strings.Cut(ports, "-")
+	lo, err := strconv.Atoi(low)
+	if err != nil {
+		return 0, 0, false
+	}
+	hi = lo
+	if isRange {
+		if hi, err = strconv.Atoi(high); err != nil {
+			return 0, 0, false
+		}
+	}
+	return lo, hi, true
+}
+
+// parseFirewallAddress parses an address or CIDR block as used in firewall
+// rules. A single address is a block of one address.
+func parseFirewallAddress(s string) (*net.IPNet, error) {
+	if _, network, err := net.ParseCIDR(s); err == nil {
+		return network, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an address or CIDR block", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// dropletIPs returns the public and private addresses of a Droplet.
+func dropletIPs(d *do.Droplet) []net.IP {
+	if d.Networks == nil {
+		return nil
+	}
+	var ips []net.IP
+	for _, n := range d.Networks.V4 {
+		if ip := net.ParseIP(n.IPAddress); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	for _, n := range d.Networks.V6 {
+		if ip := net.ParseIP(n.IPAddress); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testCheckDB = do.Droplet{Droplet: &godo.Droplet{
+		ID:   10,
+		Name: "db-1",
+		Tags: []string{"db"},
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "198.51.100.10", Type: "public"},
+			{IPAddress: "10.10.0.10", Type: "private"},
+		}},
+	}}
+	testCheckWeb = do.Droplet{Droplet: &godo.Droplet{
+		ID:   20,
+		Name: "web-3",
+		Tags: []string{"web"},
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "198.51.100.20", Type: "public"},
+			{IPAddress: "10.10.0.20", Type: "private"},
+		}},
+	}}
+
+	testCheckFirewalls = do.Firewalls{
+		{Firewall: &godo.Firewall{
+			ID:   "fw-db",
+			Name: "db",
+			Tags: []string{"db"},
+			InboundRules: []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "5432", Sources: &godo.Sources{Tags: []string{"web"}, Addresses: []string{"203.0.113.0/24"}}},
+				{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0"}}},
+			},
+			OutboundRules: []godo.OutboundRule{
+				{Protocol: "tcp", PortRange: "all", Destinations: &godo.Destinations{Addresses: []string{"0.0.0.0/0"}}},
+			},
+		}},
+		{Firewall: &godo.Firewall{
+			ID:   "fw-web",
+			Name: "web",
+			Tags: []string{"web"},
+			InboundRules: []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0", "::/0"}}},
+			},
+			OutboundRules: []godo.OutboundRule{
+				{Protocol: "tcp", PortRange: "5000-6000", Destinations: &godo.Destinations{Tags: []string{"db"}}},
+			},
+		}},
+	}
+)
+
+func TestFirewallCheckFromAddress(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().Get(10).Return(&testCheckDB, nil)
+		tm.firewalls.EXPECT().List().Return(testCheckFirewalls, nil)
+		tm.firewalls.EXPECT().ListByDroplet(10).Return(do.Firewalls{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckDroplet, "10")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckFrom, "203.0.113.5")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckPort, 5432)
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckProtocol, "tcp")
+
+		err := RunFirewallCheck(config)
+		require.NoError(t, err)
+		assert.Regexp(t, `203.0.113.5/32\s+db-1\s+inbound\s+db-1\s+allow\s+db\s+inbound protocol:tcp,ports:5432,address:203.0.113.0/24`, buf.String())
+	})
+}
+
+func TestFirewallCheckDenied(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().Get(10).Return(&testCheckDB, nil)
+		tm.firewalls.EXPECT().List().Return(testCheckFirewalls, nil)
+		tm.firewalls.EXPECT().ListByDroplet(10).Return(do.Firewalls{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckDroplet, "10")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckFrom, "192.0.2.0/24")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckPort, 5432)
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckProtocol, "tcp")
+
+		err := RunFirewallCheck(config)
+		assert.EqualError(t, err, "tcp/5432 from 192.0.2.0/24 to db-1 is denied")
+		assert.Regexp(t, `inbound\s+db-1\s+deny\s+db\s+no rule allows the traffic`, buf.String())
+	})
+}
+
+func TestFirewallCheckICMP(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		fws := do.Firewalls{{Firewall: &godo.Firewall{
+			ID:   "fw-ping",
+			Name: "ping",
+			Tags: []string{"db"},
+			InboundRules: []godo.InboundRule{
+				{Protocol: "icmp", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0"}}},
+			},
+		}}}
+		tm.droplets.EXPECT().Get(10).Return(&testCheckDB, nil)
+		tm.firewalls.EXPECT().List().Return(fws, nil)
+		tm.firewalls.EXPECT().ListByDroplet(10).Return(do.Firewalls{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckDroplet, "10")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckFrom, "192.0.2.1")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckProtocol, "icmp")
+
+		err := RunFirewallCheck(config)
+		require.NoError(t, err)
+		assert.Regexp(t, `inbound\s+db-1\s+allow\s+ping\s+inbound protocol:icmp`, buf.String())
+	})
+}
+
+func TestFirewallCheckFromTag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		otherWeb := do.Droplet{Droplet: &godo.Droplet{ID: 21, Name: "web-4", Tags: []string{"web", "canary"}}}
+
+		tm.droplets.EXPECT().List().Return(do.Droplets{testCheckDB, testCheckWeb, otherWeb}, nil).Times(2)
+		tm.droplets.EXPECT().Get(10).Return(&testCheckDB, nil)
+		tm.droplets.EXPECT().ListByTag("web").Return(do.Droplets{testCheckWeb, otherWeb}, nil)
+		tm.firewalls.EXPECT().List().Return(testCheckFirewalls, nil)
+		tm.firewalls.EXPECT().ListByDroplet(10).Return(do.Firewalls{}, nil)
+		tm.firewalls.EXPECT().ListByDroplet(20).Return(do.Firewalls{}, nil)
+		// web-4 is also in a firewall by ID. The rules of all its firewalls
+		// apply, so the web firewall still allows the traffic.
+		tm.firewalls.EXPECT().ListByDroplet(21).Return(do.Firewalls{
+			{Firewall: &godo.Firewall{ID: "fw-canary", Name: "canary", DropletIDs: []int{21}}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckDroplet, "db-1")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckFrom, "web")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckPort, 5432)
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckProtocol, "tcp")
+
+		err := RunFirewallCheck(config)
+		require.NoError(t, err)
+
+		out := buf.String()
+		assert.Regexp(t, `web-3\s+db-1\s+outbound\s+web-3\s+allow\s+web\s+outbound protocol:tcp,ports:5000-6000,tag:db`, out)
+		assert.Regexp(t, `web-3\s+db-1\s+inbound\s+db-1\s+allow\s+db\s+inbound protocol:tcp,ports:5432,tag:web`, out)
+		assert.Regexp(t, `web-4\s+db-1\s+outbound\s+web-4\s+allow\s+web\s`, out)
+	})
+}
+
+func TestFirewallCheckNoFirewall(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		loose := do.Droplet{Droplet: &godo.Droplet{ID: 30, Name: "loose"}}
+		tm.droplets.EXPECT().Get(30).Return(&loose, nil)
+		tm.firewalls.EXPECT().List().Return(testCheckFirewalls, nil)
+		tm.firewalls.EXPECT().ListByDroplet(30).Return(do.Firewalls{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckDroplet, "30")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckFrom, "::/0")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckProtocol, "icmp")
+
+		err := RunFirewallCheck(config)
+		require.NoError(t, err)
+		assert.Regexp(t, `allow\s+\(none\)\s+no firewall applies, so all traffic is allowed`, buf.String())
+	})
+}
+
+func TestFirewallCheckInvalidFlags(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckDroplet, "10")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckFrom, "203.0.113.5")
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckProtocol, "tcp")
+
+		err := RunFirewallCheck(config)
+		assert.EqualError(t, err, "--port must be between 1 and 65535")
+
+		config.Doit.Set(config.NS, doctl.ArgFirewallCheckProtocol, "sctp")
+		err = RunFirewallCheck(config)
+		assert.EqualError(t, err, `--protocol "sctp" is not valid; valid values are tcp, udp and icmp`)
+	})
+}
+
+func TestFirewallPortsInclude(t *testing.T) {
+	assert.True(t, firewallPortsInclude("all", 5432))
+	assert.True(t, firewallPortsInclude("5432", 5432))
+	assert.True(t, firewallPortsInclude("5000-6000", 5432))
+	assert.False(t, firewallPortsInclude("22", 5432))
+	assert.False(t, firewallPortsInclude("6000-7000", 5432))
+}
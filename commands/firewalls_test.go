@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -26,7 +29,7 @@ var (
 func TestFirewallCommand(t *testing.T) {
 	cmd := Firewall()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "get", "create", "update", "list", "list-by-droplet", "delete", "add-droplets", "remove-droplets", "add-tags", "remove-tags", "add-rules", "remove-rules")
+	assertCommandNames(t, cmd, "get", "create", "update", "list", "list-by-droplet", "delete", "add-droplets", "remove-droplets", "add-tags", "remove-tags", "add-rules", "remove-rules", "sync", "export", "check", "audit")
 }
 
 func TestFirewallGet(t *testing.T) {
@@ -294,3 +297,119 @@ func TestFirewallRemoveRules(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestFirewallSync(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		fID := "ab06e011-6dd1-4034-9293-201f71aba299"
+		tm.firewalls.EXPECT().Get(fID).Return(&do.Firewall{Firewall: &godo.Firewall{
+			ID: fID,
+			InboundRules: []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0", "192.0.2.0/24"}}},
+				{Protocol: "icmp", PortRange: "0", Sources: &godo.Sources{Tags: []string{"web"}}},
+			},
+			OutboundRules: []godo.OutboundRule{
+				{Protocol: "tcp", PortRange: "0", Destinations: &godo.Destinations{Addresses: []string{"0.0.0.0/0"}}},
+			},
+		}}, nil)
+		tm.firewalls.EXPECT().AddRules(fID, &godo.FirewallRulesRequest{
+			InboundRules: []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{LoadBalancerUIDs: []string{"lb-1"}}},
+			},
+		}).Return(nil)
+		tm.firewalls.EXPECT().RemoveRules(fID, &godo.FirewallRulesRequest{
+			InboundRules: []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0"}}},
+			},
+		}).Return(nil)
+
+		path := filepath.Join(t.TempDir(), "rules.yaml")
+		err := os.WriteFile(path, []byte(`
+inbound_rules:
+  - protocol: tcp
+    ports: "22"
+    sources:
+      addresses: ["192.0.2.0/24"]
+  - protocol: tcp
+    ports: "443"
+    sources:
+      load_balancer_uids: ["lb-1"]
+  - protocol: icmp
+    sources:
+      tags: ["web"]
+outbound_rules:
+  - protocol: tcp
+    ports: all
+    destinations:
+      addresses: ["0.0.0.0/0"]
+`), 0600)
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, fID)
+		config.Doit.Set(config.NS, doctl.ArgRulesFile, path)
+
+		err = RunFirewallSync(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "+ inbound protocol:tcp,ports:443,load_balancer_uid:lb-1\n"+
+			"- inbound protocol:tcp,ports:22,address:0.0.0.0/0\n"+
+			"\n1 to add, 1 to remove.\n", buf.String())
+	})
+}
+
+func TestFirewallSyncPlan(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		fID := "ab06e011-6dd1-4034-9293-201f71aba299"
+		tm.firewalls.EXPECT().Get(fID).Return(&do.Firewall{Firewall: &godo.Firewall{ID: fID}}, nil)
+
+		path := filepath.Join(t.TempDir(), "rules.yaml")
+		err := os.WriteFile(path, []byte("inbound_rules:\n  - {protocol: udp, ports: \"53\", sources: {droplet_ids: [5]}}\n"), 0600)
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, fID)
+		config.Doit.Set(config.NS, doctl.ArgRulesFile, path)
+		config.Doit.Set(config.NS, doctl.ArgPlan, true)
+
+		err = RunFirewallSync(config)
+		assert.NoError(t, err)
+		assert.Equal(t, "+ inbound protocol:udp,ports:53,droplet_id:5\n\n1 to add, 0 to remove.\n", buf.String())
+	})
+}
+
+func TestFirewallExport(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		fID := "ab06e011-6dd1-4034-9293-201f71aba299"
+		tm.firewalls.EXPECT().Get(fID).Return(&do.Firewall{Firewall: &godo.Firewall{
+			ID: fID,
+			InboundRules: []godo.InboundRule{
+				{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Tags: []string{"bastion"}, Addresses: []string{"192.0.2.0/24"}}},
+			},
+			OutboundRules: []godo.OutboundRule{
+				{Protocol: "icmp", PortRange: "0", Destinations: &godo.Destinations{Addresses: []string{"0.0.0.0/0"}}},
+			},
+		}}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, fID)
+
+		err := RunFirewallExport(config)
+		assert.NoError(t, err)
+		assert.Equal(t, `inbound_rules:
+- ports: "22"
+  protocol: tcp
+  sources:
+    addresses:
+    - 192.0.2.0/24
+    tags:
+    - bastion
+outbound_rules:
+- destinations:
+    addresses:
+    - 0.0.0.0/0
+  protocol: icmp
+`, buf.String())
+	})
+}
@@ -44,7 +44,7 @@ func Functions() *Command {
 
 	get := CmdBuilder(cmd, RunFunctionsGet, "get <functionName>", "Retrieve the metadata or code of a deployed function",
 		`Retrieves the code or metadata of a deployed function.`,
-		Writer)
+		Writer, fromFileOpt())
 	AddBoolFlag(get, "url", "r", false, "Retrieves function URL")
 	AddBoolFlag(get, "code", "", false, "Retrieves the functions code. This does not work if the function is saved as a zip file.")
 	AddStringFlag(get, "save-env", "E", "", "Saves the function's environment variables to a local file as key-value pairs")
@@ -56,7 +56,7 @@ func Functions() *Command {
 	invoke := CmdBuilder(cmd, RunFunctionsInvoke, "invoke <functionName>", "Invokes a function",
 		`Invokes a function in your functions namespace.
 You can provide inputs and inspect outputs.`,
-		Writer)
+		Writer, fromFileOpt())
 	AddBoolFlag(invoke, "web", "", false, "Invokes the function as a web function and displays the result in your browser")
 	AddStringSliceFlag(invoke, "param", "p", []string{}, "Key-value pairs of input parameters. For example, if your function takes two parameters called `name` and `place`, you would provide them as `name:John,place:NY`.")
 	AddStringFlag(invoke, "param-file", "P", "", "A path to a file containing parameter values in JSON format, such as `path/to/file.json`.")
@@ -66,7 +66,7 @@ You can provide inputs and inspect outputs.`,
 
 	list := CmdBuilder(cmd, RunFunctionsList, "list [<packageName>]", "Lists the functions in your functions namespace",
 		`Lists the functions in your functions namespace.`,
-		Writer, aliasOpt("ls"), displayerType(&displayers.Functions{}))
+		Writer, aliasOpt("ls"), displayerType(&displayers.Functions{}), fromFileOpt())
 	AddStringFlag(list, "limit", "l", "", "Returns the specified number of functions in the result, starting with the most recently updated function.")
 	AddStringFlag(list, "skip", "s", "", "Excludes the specified number of functions from the result, starting with the most recently updated function. For example, if you specify `2`, the most recently updated function and the function updated before that are excluded from the result.")
 	AddBoolFlag(list, "count", "", false, "Returns only the total number of functions in the namespace")
@@ -75,20 +75,21 @@ Currently, there are five types of images: snapshots, backups, custom images, di
 	cmdImagesListUser.Example = `The following example lists all user-created images on your account and uses the ` + "`" + `--format` + "`" + ` flag to return only the ID, name, distribution, and slug for each image: doctl compute image list-user --format ID,Name,Distribution,Slug`
 
 	cmdImagesGet := CmdBuilder(cmd, RunImagesGet, "get <image-id|image-slug>", "Retrieve information about an image", `Returns the following information about the specified image:`+imageDetail, Writer,
-		displayerType(&displayers.Image{}))
+		displayerType(&displayers.Image{}), fromFileOpt())
 	cmdImagesGet.Example = `The following example retrieves information about an image with the ID ` + "`" + `386734086` + "`" + `: doctl compute image get 386734086`
 
 	cmdImagesUpdate := CmdBuilder(cmd, RunImagesUpdate, "update <image-id>", "Update an image's metadata", `Updates an image's metadata, including its name, description, and distribution.`, Writer,
-		displayerType(&displayers.Image{}))
+		displayerType(&displayers.Image{}), fromFileOpt())
 	AddStringFlag(cmdImagesUpdate, doctl.ArgImageName, "", "", "The name of the image to update", requiredOpt())
 	cmdImagesUpdate.Example = `The following example updates the name of an image with the ID ` + "`" + `386734086` + "`" + ` to ` + "`" + `New Image Name` + "`" + `: doctl compute image update 386734086 --name "Example Image Name"`
 
-	cmdRunImagesDelete := CmdBuilder(cmd, RunImagesDelete, "delete <image-id>", "Permanently delete an image from your account", `Permanently deletes an image from your account. This is irreversible.`, Writer,
-		aliasOpt("rm"))
+	cmdRunImagesDelete := CmdBuilder(cmd, RunImagesDelete, "delete <image-id>...", "Permanently delete an image from your account", `Permanently deletes one or more images from your account. This is irreversible.`, Writer,
+		aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdRunImagesDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Force image delete")
+	addFanOutFlags(cmdRunImagesDelete)
 	cmdRunImagesDelete.Example = `The following example deletes an image with the ID ` + "`" + `386734086` + "`" + `: doctl compute image delete 386734086`
 
-	cmdRunImagesCreate := CmdBuilder(cmd, RunImagesCreate, "create <image-name>", "Create custom image", `Creates an image in your DigitalOcean account. Specify a URL to download the image from and the region to store the image in. You can add additional metadata to the image using the optional flags.`, Writer)
+	cmdRunImagesCreate := CmdBuilder(cmd, RunImagesCreate, "create <image-name>", "Create custom image", `Creates an image in your DigitalOcean account. Specify a URL to download the image from and the region to store the image in. You can add additional metadata to the image using the optional flags.`, Writer, fromFileOpt())
 	AddStringFlag(cmdRunImagesCreate, doctl.ArgImageExternalURL, "", "", "The URL to retrieve the image from", requiredOpt())
 	AddStringFlag(cmdRunImagesCreate, doctl.ArgRegionSlug, "", "", "The slug of the region you want to store the image in. For a list of region slugs, use the `doctl compute region list` command.", requiredOpt())
 	AddStringFlag(cmdRunImagesCreate, doctl.ArgImageDistro, "", "Unknown", "A custom image distribution slug to apply to the image")
@@ -253,22 +254,19 @@ func RunImagesDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("image", len(c.Args)) == nil {
-
+		ids := make([]int, 0, len(c.Args))
 		for _, el := range c.Args {
 			id, err := strconv.Atoi(el)
 			if err != nil {
 				return err
 			}
-			if err := is.Delete(id); err != nil {
-				return err
-			}
+			ids = append(ids, id)
 		}
 
-	} else {
-		return errOperationAborted
+		return fanOutInts(c, ids, is.Delete)
 	}
 
-	return nil
+	return errOperationAborted
 }
 
 // RunImagesCreate creates a new custom image.
@@ -49,7 +49,7 @@ func Invoices() *Command {
 
 Use the ` + "`" + `doctl invoice list` + "`" + ` command to find the UUID of the invoice to retrieve.`
 	cmdInvoicesGet := CmdBuilder(cmd, RunInvoicesGet, "get <invoice-uuid>", "Retrieve a list of all the items on an invoice",
-		getInvoiceDesc, Writer, aliasOpt("g"), displayerType(&displayers.Invoice{}))
+		getInvoiceDesc, Writer, aliasOpt("g"), displayerType(&displayers.Invoice{}), fromFileOpt())
 	cmdInvoicesGet.Example = `The following example retrieves details about an invoice with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl invoice get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	listInvoiceDesc := "Lists all of the invoices on your account including the UUID, amount in USD, and time period for each."
@@ -73,7 +73,7 @@ Use the ` + "`" + `doctl invoice list` + "`" + ` command to find the UUID of the
 Use the ` + "`" + `doctl invoice list` + "`" + ` command to find the UUID of the invoice to retrieve.`
 
 	cmdInvoicesSummary := CmdBuilder(cmd, RunInvoicesSummary, "summary <invoice-uuid>", "Get a summary of an invoice",
-		invoiceSummaryDesc, Writer, aliasOpt("s"), displayerType(&displayers.Invoice{}))
+		invoiceSummaryDesc, Writer, aliasOpt("s"), displayerType(&displayers.Invoice{}), fromFileOpt())
 	cmdInvoicesSummary.Example = `The following example retrieves a summary of an invoice with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl invoice summary f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	pdfInvoiceDesc := `This command downloads a PDF summary of a specific invoice to the provided location.
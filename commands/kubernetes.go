@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blang/semver"
@@ -74,11 +75,11 @@ func defaultGetCurrentAuthContextFn() string {
 }
 
 func errNoClusterByName(name string) error {
-	return fmt.Errorf("no cluster goes by the name %q", name)
+	return &do.NameNotFoundError{Kind: "Kubernetes cluster", Name: name}
 }
 
 func errAmbiguousClusterName(name string, ids []string) error {
-	return fmt.Errorf("many clusters go by the name %q, they have the following IDs: %v", name, ids)
+	return &do.AmbiguousNameError{Kind: "Kubernetes cluster", Name: name, IDs: ids}
 }
 
 func errNoPoolByName(name string) error {
@@ -249,7 +250,7 @@ Retrieves the following details about a Kubernetes cluster: `+clusterDetails+`
 - When the Kubernetes cluster was created, in ISO8601 combined date and time format
 - When the Kubernetes cluster was last updated, in ISO8601 combined date and time format
 `+nodePoolDetails,
-		Writer, aliasOpt("g"), displayerType(&displayers.KubernetesClusters{}))
+		Writer, aliasOpt("g"), displayerType(&displayers.KubernetesClusters{}), fromFileOpt())
 	cmdKubernetesClusterGet.Example = `The following example retrieve details about a Kubernetes cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster get example-cluster`
 
 	KubernetesClusterList := CmdBuilder(cmd, k8sCmdService.RunKubernetesClusterList, "list", "Retrieve the list of Kubernetes clusters for your account", `
@@ -260,7 +261,7 @@ Retrieves the following details about all Kubernetes clusters that are on your a
 	cmdKubernetesClusterGetUpgrades := CmdBuilder(cmd, k8sCmdService.RunKubernetesClusterGetUpgrades, "get-upgrades <id|name>",
 		"Retrieve a list of available Kubernetes version upgrades", `
 Retrieves a list of slugs representing Kubernetes upgrade versions you can use to upgrade the cluster. To upgrade your cluster, use the `+"`"+`doctl kubernetes cluster upgrade`+"`"+` command.
-`, Writer, aliasOpt("gu"))
+`, Writer, aliasOpt("gu"), fromFileOpt())
 	cmdKubernetesClusterGetUpgrades.Example = `The following example retrieves a list of available Kubernetes version upgrades for a cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster get-upgrades example-cluster`
 
 	cmdKubeClusterCreate := CmdBuilder(cmd, k8sCmdService.RunKubernetesClusterCreate(defaultKubernetesNodeSize,
@@ -270,7 +271,7 @@ Creates a Kubernetes cluster given the specified options and using the specified
 If no configuration flags are used, a three-node cluster with a single node pool is created in the `+"`"+`nyc1`+"`"+` region, using the latest Kubernetes version.
 
 After creating a cluster, a configuration context is added to kubectl and made active so that you can begin managing your new cluster immediately.`,
-		Writer, aliasOpt("c"))
+		Writer, aliasOpt("c"), fromFileOpt())
 	AddStringFlag(cmdKubeClusterCreate, doctl.ArgRegionSlug, "", defaultKubernetesRegion,
 		"A `slug` indicating which region to create the cluster in. Use the `doctl kubernetes options regions` command for a list of options", requiredOpt())
 	AddStringFlag(cmdKubeClusterCreate, doctl.ArgClusterVersionSlug, "", "latest",
@@ -322,7 +323,7 @@ Format: `+"`"+`"name=your-name;size=size_slug;count=5;tag=tag1;tag=tag2;label=ke
 
 	cmdKubeClusterUpdate := CmdBuilder(cmd, k8sCmdService.RunKubernetesClusterUpdate, "update <id|name>",
 		"Update a Kubernetes cluster's configuration", `
-Updates the configuration values for a Kubernetes cluster. The cluster must be referred to by its name or ID. Use the `+"`"+`doctl kubernetes cluster list`+"`"+` command to get a list of clusters on your account.`, Writer, aliasOpt("u"))
+Updates the configuration values for a Kubernetes cluster. The cluster must be referred to by its name or ID. Use the `+"`"+`doctl kubernetes cluster list`+"`"+` command to get a list of clusters on your account.`, Writer, aliasOpt("u"), fromFileOpt())
 	AddStringFlag(cmdKubeClusterUpdate, doctl.ArgClusterName, "", "",
 		"Specifies a new cluster name")
 	AddStringSliceFlag(cmdKubeClusterUpdate, doctl.ArgTag, "", nil,
@@ -348,7 +349,7 @@ Updates the configuration values for a Kubernetes cluster. The cluster must be r
 	cmdKubeClusterUpgrade := CmdBuilder(cmd, k8sCmdService.RunKubernetesClusterUpgrade,
 		"upgrade <id|name>", "Upgrades a cluster to a new Kubernetes version", `
 
-Upgrades a Kubernetes cluster. By default, this upgrades the cluster to the latest available release, but you can also specify any version listed for your cluster by using `+"`"+`doctl k8s get-upgrades`+"`"+`.`, Writer)
+Upgrades a Kubernetes cluster. By default, this upgrades the cluster to the latest available release, but you can also specify any version listed for your cluster by using `+"`"+`doctl k8s get-upgrades`+"`"+`.`, Writer, fromFileOpt())
 	AddStringFlag(cmdKubeClusterUpgrade, doctl.ArgClusterVersionSlug, "", "latest",
 		`The Kubernetes version to upgrade to. Use the `+"`"+`doctl k8s get-upgrades <cluster>`+"`"+` command for a list of available versions.
 The special value `+"`"+`latest`+"`"+` selects the most recent patch version for your cluster's minor version.
@@ -358,19 +359,20 @@ For example, if a cluster is on 1.12.1 and upgrades are available to 1.12.3 and
 	cmdKubeClusterDelete := CmdBuilder(cmd, k8sCmdService.RunKubernetesClusterDelete,
 		"delete <id|name>...", "Delete Kubernetes clusters ", `
 Deletes the specified Kubernetes clusters and the Droplets associated with them. To delete all other DigitalOcean resources created during the operation of the clusters, such as load balancers, volumes or volume snapshots, use the `+"`"+`--dangerous`+"`"+` flag.
-`, Writer, aliasOpt("d", "rm"))
+`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdKubeClusterDelete, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Deletes the cluster without a confirmation prompt")
 	AddBoolFlag(cmdKubeClusterDelete, doctl.ArgClusterUpdateKubeconfig, "", true,
 		"Remove the deleted cluster from your kubeconfig")
 	AddBoolFlag(cmdKubeClusterDelete, doctl.ArgDangerous, "", false,
 		"Deletes the cluster's associated resources like load balancers, volumes and volume snapshots")
+	addFanOutFlags(cmdKubeClusterDelete)
 	cmdKubeClusterDelete.Example = `The following example deletes a cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster delete example-cluster`
 
 	cmdKubeClusterDeleteSelective := CmdBuilder(cmd, k8sCmdService.RunKubernetesClusterDeleteSelective,
 		"delete-selective <id|name>", "Delete a Kubernetes cluster and selectively delete resources associated with it", `
 Deletes the specified Kubernetes cluster and Droplets associated with it. It also deletes the specified associated resources. Associated resources can be load balancers, volumes and volume snapshots.
-`, Writer, aliasOpt("ds"))
+`, Writer, aliasOpt("ds"), fromFileOpt())
 	AddBoolFlag(cmdKubeClusterDeleteSelective, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Deletes the cluster without a confirmation prompt")
 	AddBoolFlag(cmdKubeClusterDeleteSelective, doctl.ArgClusterUpdateKubeconfig, "", true,
@@ -388,7 +390,7 @@ Retrieves the following details for a Kubernetes cluster:
 - Volume IDs for volumes created by the DigitalOcean CSI driver
 - Volume snapshot IDs for volume snapshots created by the DigitalOcean CSI driver
 - Load balancer IDs for load balancers managed by the Kubernetes cluster`,
-		Writer, aliasOpt("ar"), displayerType(&displayers.KubernetesAssociatedResources{}))
+		Writer, aliasOpt("ar"), displayerType(&displayers.KubernetesAssociatedResources{}), fromFileOpt())
 	cmdKubeClusterListAssociatedResources.Example = `The following example retrieves the associated resources for a cluster named ` + "`" + `example-cluster` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to return only the associated volumes: doctl kubernetes cluster list-associated-resources example-cluster --format Volumes`
 
 	return cmd
@@ -407,18 +409,18 @@ func kubernetesKubeconfig() *Command {
 	k8sCmdService := kubernetesCommandService()
 
 	cmdShowConfig := CmdBuilder(cmd, k8sCmdService.RunKubernetesKubeconfigShow, "show <cluster-id|cluster-name>", "Show a Kubernetes cluster's kubeconfig YAML", `
-Returns the raw YAML for the specified cluster's kubeconfig.`, Writer, aliasOpt("p", "g"))
+Returns the raw YAML for the specified cluster's kubeconfig.`, Writer, aliasOpt("p", "g"), fromFileOpt())
 	AddIntFlag(cmdShowConfig, doctl.ArgKubeConfigExpirySeconds, "", 0,
 		"The length of time the cluster credentials are valid for, in seconds. By default, the credentials expire after seven days.")
 	cmdShowConfig.Example = `The following example shows the kubeconfig YAML for a cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster kubeconfig show example-cluster`
 
 	execCredDesc := "INTERNAL: This hidden command is for printing a cluster's exec credential"
-	cmdExecCredential := CmdBuilder(cmd, k8sCmdService.RunKubernetesKubeconfigExecCredential, "exec-credential <cluster-id>", execCredDesc, execCredDesc, Writer, hiddenCmd())
+	cmdExecCredential := CmdBuilder(cmd, k8sCmdService.RunKubernetesKubeconfigExecCredential, "exec-credential <cluster-id>", execCredDesc, execCredDesc, Writer, hiddenCmd(), fromFileOpt())
 	AddStringFlag(cmdExecCredential, doctl.ArgVersion, "", "", "")
 
 	cmdSaveConfig := CmdBuilder(cmd, k8sCmdService.RunKubernetesKubeconfigSave, "save <cluster-id|cluster-name>", "Save a cluster's credentials to your local kubeconfig", `
 Adds the credentials for the specified cluster to your local kubeconfig. After this, your kubectl installation can directly manage the specified cluster.
-		`, Writer, aliasOpt("s"))
+		`, Writer, aliasOpt("s"), fromFileOpt())
 	AddBoolFlag(cmdSaveConfig, doctl.ArgSetCurrentContext, "", true, "Sets the current kubectl context to that of the newest cluster in your account")
 	AddIntFlag(cmdSaveConfig, doctl.ArgKubeConfigExpirySeconds, "", 0,
 		"The length of time the cluster credentials are valid for, in seconds. By default, the credentials are automatically renewed as needed.")
@@ -427,7 +429,7 @@ Adds the credentials for the specified cluster to your local kubeconfig. After t
 
 	cmdKubeKubeconfigRemove := CmdBuilder(cmd, k8sCmdService.RunKubernetesKubeconfigRemove, "remove <cluster-id|cluster-name>", "Remove a cluster's credentials from your local kubeconfig", `
 This command removes the specified cluster's credentials from your local kubeconfig. After running this command, you cannot use `+"`"+`kubectl`+"`"+` to interact with your cluster.
-`, Writer, aliasOpt("d", "rm"))
+`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	cmdKubeKubeconfigRemove.Example = `The following example removes the credentials for a cluster named ` + "`" + `example-cluster` + "`" + ` from your local kubeconfig: doctl kubernetes cluster kubeconfig remove example-cluster`
 
 	return cmd
@@ -461,7 +463,7 @@ Retrieves information about the specified node pool in the specified cluster, in
 
 Specifying `+"`"+`--output=json`+"`"+` when calling this command returns additional information about the individual nodes in the response, such as their IDs, status, creation time, and update time.
 `, Writer, aliasOpt("g"),
-		displayerType(&displayers.KubernetesNodePools{}))
+		displayerType(&displayers.KubernetesNodePools{}), fromFileOpt())
 	cmdKubeNodePoolGet.Example = `The following example retrieves information about a node pool named ` + "`" + `example-pool` + "`" + ` in a cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster node-pool get example-cluster example-pool`
 
 	cmdKubeNodePoolList := CmdBuilder(cmd, k8sCmdService.RunKubernetesNodePoolList, "list <cluster-id|cluster-name>",
@@ -476,14 +478,14 @@ Retrieves information about the specified cluster's node pools, including:
 
 Specifying `+"`"+`--output=json`+"`"+` when calling this command returns additional information about the individual nodes in the response, such as their IDs, status, creation time, and update time.
 		`, Writer, aliasOpt("ls"),
-		displayerType(&displayers.KubernetesNodePools{}))
+		displayerType(&displayers.KubernetesNodePools{}), fromFileOpt())
 	cmdKubeNodePoolList.Example = `The following example retrieves information about all node pools in a cluster named ` + "`" + `example-cluster` + "`" + ` and uses the ` + "`" + `--format` + "`" + ` flag to only return the ID, name, and nodes for each pool: doctl kubernetes cluster node-pool list example-cluster --format ID,Name,Nodes`
 
 	cmdKubeNodePoolCreate := CmdBuilder(cmd, k8sCmdService.RunKubernetesNodePoolCreate,
 		"create <cluster-id|cluster-name>", "Create a new node pool for a cluster", `
 Creates a new node pool for the specified cluster. The command requires values for the `+"`"+`--name`+"`"+`, `+"`"+`--size`+"`"+`, and `+"`"+`--count`+"`"+` flags to create a node pool. You can also specify that you'd like to enable autoscaling and set minimum and maximum node poll sizes.
 		`,
-		Writer, aliasOpt("c"))
+		Writer, aliasOpt("c"), fromFileOpt())
 	AddStringFlag(cmdKubeNodePoolCreate, doctl.ArgNodePoolName, "", "",
 		"The name of the node pool", requiredOpt())
 	AddStringFlag(cmdKubeNodePoolCreate, doctl.ArgSizeSlug, "", "",
@@ -506,7 +508,7 @@ Creates a new node pool for the specified cluster. The command requires values f
 
 	cmdKubeNodePoolUpdate := CmdBuilder(cmd, k8sCmdService.RunKubernetesNodePoolUpdate,
 		"update <cluster-id|cluster-name> <pool-id|pool-name>",
-		"Update an existing node pool in a cluster", "Updates a node pool in a cluster. You can update any value for which there is a flag.", Writer, aliasOpt("u"))
+		"Update an existing node pool in a cluster", "Updates a node pool in a cluster. You can update any value for which there is a flag.", Writer, aliasOpt("u"), fromFileOpt())
 	AddStringFlag(cmdKubeNodePoolUpdate, doctl.ArgNodePoolName, "", "", "The name of the node pool")
 	AddIntFlag(cmdKubeNodePoolUpdate, doctl.ArgNodePoolCount, "", 0,
 		"The number of nodes in the node pool")
@@ -526,27 +528,27 @@ Creates a new node pool for the specified cluster. The command requires values f
 
 	recycleDesc := "DEPRECATED: Use `replace-node`. Recycle nodes in a node pool"
 	cmdKubeNodePoolRecycle := CmdBuilder(cmd, k8sCmdService.RunKubernetesNodePoolRecycle,
-		"recycle <cluster-id|cluster-name> <pool-id|pool-name>", recycleDesc, recycleDesc, Writer, aliasOpt("r"), hiddenCmd())
+		"recycle <cluster-id|cluster-name> <pool-id|pool-name>", recycleDesc, recycleDesc, Writer, aliasOpt("r"), hiddenCmd(), fromFileOpt())
 	AddStringFlag(cmdKubeNodePoolRecycle, doctl.ArgNodePoolNodeIDs, "", "",
 		"ID or name of the nodes in the node pool to recycle")
 
 	cmdKubeNodePoolDelete := CmdBuilder(cmd, k8sCmdService.RunKubernetesNodePoolDelete,
 		"delete <cluster-id|cluster-name> <pool-id|pool-name>",
-		"Delete a node pool", `Deletes a node pool in a cluster, which also removes all the nodes inside that pool. You cannot reverse this action.`, Writer, aliasOpt("d", "rm"))
+		"Delete a node pool", `Deletes a node pool in a cluster, which also removes all the nodes inside that pool. You cannot reverse this action.`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdKubeNodePoolDelete, doctl.ArgForce, doctl.ArgShortForce,
 		false, "Deletes node pool without a confirmation prompt")
 	cmdKubeNodePoolDelete.Example = `The following example deletes a node pool named ` + "`" + `example-pool` + "`" + ` in a cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster node-pool delete example-cluster example-pool`
 
 	cmdKubeNodeDelete := CmdBuilder(cmd, k8sCmdService.RunKubernetesNodeDelete, "delete-node <cluster-id|cluster-name> <pool-id|pool-name> <node-id>", "Delete a node", `
 Deletes a node in the specified node pool. By default, this deletion happens gracefully and Kubernetes drains the node of any pods before deleting it.
-		`, Writer)
+		`, Writer, fromFileOpt())
 	AddBoolFlag(cmdKubeNodeDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Deletes the node without a confirmation prompt")
 	AddBoolFlag(cmdKubeNodeDelete, "skip-drain", "", false, "Skips draining the node before deletion")
 	cmdKubeNodeDelete.Example = `The following example deletes a node named ` + "`" + `example-node` + "`" + ` in a node pool named ` + "`" + `example-pool` + "`" + `: doctl kubernetes cluster node-pool delete-node example-cluster example-pool example-node`
 
 	cmdKubeNodeReplace := CmdBuilder(cmd, k8sCmdService.RunKubernetesNodeReplace, "replace-node <cluster-id|cluster-name> <pool-id|pool-name> <node-id>", "Replace node with a new one", `
 Deletes the specified node in the specified node pool, and then creates a new node in its place. This is useful if you suspect a node has entered an undesired state. By default, the deletion happens gracefully and Kubernetes drains the node of any pods before deleting it.
-		`, Writer)
+		`, Writer, fromFileOpt())
 	AddBoolFlag(cmdKubeNodeReplace, doctl.ArgForce, doctl.ArgShortForce, false, "Replaces node without confirmation prompt")
 	AddBoolFlag(cmdKubeNodeReplace, "skip-drain", "", false, "Skips draining the node before replacement")
 	cmdKubeNodeReplace.Example = `The following example replaces a node named ` + "`" + `example-node` + "`" + ` in a node pool named ` + "`" + `example-pool` + "`" + `: doctl kubernetes cluster node-pool replace-node example-cluster example-pool example-node`
@@ -569,13 +571,13 @@ func kubernetesRegistryIntegration() *Command {
 	cmdKubeRegistryAdd := CmdBuilder(cmd, k8sCmdService.RunKubernetesRegistryAdd,
 		"add <cluster-id|cluster-name> <cluster-id|cluster-name>", "Add container registry support to Kubernetes clusters", `
 Adds container registry support to the specified Kubernetes cluster(s).`,
-		Writer, aliasOpt("a"))
+		Writer, aliasOpt("a"), fromFileOpt())
 	cmdKubeRegistryAdd.Example = `The following example adds container registry support to a cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster registry add example-cluster`
 
 	cmdKubeRegistryRemove := CmdBuilder(cmd, k8sCmdService.RunKubernetesRegistryRemove,
 		"remove <cluster-id|cluster-name> <cluster-id|cluster-name>", "Remove container registry support from Kubernetes clusters", `
 Removes container registry support from the specified Kubernetes cluster(s).`,
-		Writer, aliasOpt("rm"))
+		Writer, aliasOpt("rm"), fromFileOpt())
 	cmdKubeRegistryRemove.Example = `The following example removes container registry support from a cluster named ` + "`" + `example-cluster` + "`" + `: doctl kubernetes cluster registry remove example-cluster`
 
 	return cmd
@@ -594,7 +596,7 @@ func kubernetesOneClicks() *Command {
 	cmdKubernetesOneClickList := CmdBuilder(cmd, RunKubernetesOneClickList, "list", "Retrieve a list of Kubernetes 1-Click applications", "Retrieves a list of Kubernetes 1-Click applications you can install on a Kubernetes cluster.", Writer,
 		aliasOpt("ls"), displayerType(&displayers.OneClick{}))
 	cmdKubernetesOneClickList.Example = `The following example lists all available 1-click apps for Kubernetes: doctl kubernetes 1-click list`
-	cmdKubeOneClickInstall := CmdBuilder(cmd, RunKubernetesOneClickInstall, "install <cluster-id>", "Install 1-click apps on a Kubernetes cluster", "Installs 1-click applications on a Kubernetes cluster. Use the `--1-click` flag to specify one or multiple pieces of software to install on the cluster.", Writer, aliasOpt("in"), displayerType(&displayers.OneClick{}))
+	cmdKubeOneClickInstall := CmdBuilder(cmd, RunKubernetesOneClickInstall, "install <cluster-id>", "Install 1-click apps on a Kubernetes cluster", "Installs 1-click applications on a Kubernetes cluster. Use the `--1-click` flag to specify one or multiple pieces of software to install on the cluster.", Writer, aliasOpt("in"), displayerType(&displayers.OneClick{}), fromFileOpt())
 	AddStringSliceFlag(cmdKubeOneClickInstall, doctl.ArgOneClicks, "", nil, "The 1-clicks application to install on the cluster. Multiple 1-clicks can be added simultaneously, for example: `--1-clicks moon,loki,netdata`")
 	cmdKubeOneClickInstall.Example = `The following example installs Loki and Netdata on a Kubernetes cluster with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl kubernetes 1-click install f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --1-clicks loki,netdata`
 
@@ -967,18 +969,20 @@ func (s *KubernetesCommandService) RunKubernetesClusterDelete(c *CmdConfig) erro
 
 	kube := c.Kubernetes()
 
-	for _, cluster := range c.Args {
+	if !force && AskForConfirmDelete("Kubernetes cluster", len(c.Args)) != nil {
+		return fmt.Errorf("Operation aborted")
+	}
+
+	// clusters may be deleted concurrently, but the local kubeconfig
+	// must only be rewritten by one of them at a time.
+	var kubeconfigMu sync.Mutex
+
+	return fanOut(c, c.Args, func(cluster string) error {
 		clusterID, err := clusterIDize(c, cluster)
 		if err != nil {
 			return err
 		}
 
-		if force || AskForConfirmDelete("Kubernetes cluster", 1) == nil {
-			// continue
-		} else {
-			return fmt.Errorf("Operation aborted")
-		}
-
 		var kubeconfig []byte
 		if update {
 			// get the cluster's kubeconfig before issuing the delete, so that we can
@@ -999,14 +1003,17 @@ func (s *KubernetesCommandService) RunKubernetesClusterDelete(c *CmdConfig) erro
 		}
 
 		if kubeconfig != nil {
+			kubeconfigMu.Lock()
+			defer kubeconfigMu.Unlock()
+
 			notice("Cluster deleted, removing credentials")
 			if err := removeFromKubeconfig(kubeconfig); err != nil {
 				warn("Cluster was deleted, but we couldn't remove it from your local kubeconfig. Try doing it manually.")
 			}
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 func (s *KubernetesCommandService) RunKubernetesClusterDeleteSelective(c *CmdConfig) error {
@@ -2237,7 +2244,7 @@ func clusterByIDorName(kube do.KubernetesService, idOrName string) (*do.Kubernet
 // use this as opposed to `clusterByIDorName` if you just care about getting
 // a cluster ID and don't need the cluster object itself
 func clusterIDize(c *CmdConfig, idOrName string) (string, error) {
-	return iDize(c, idOrName, "cluster", "")
+	return do.ResolveKubernetesClusterID(c.Kubernetes(), idOrName)
 }
 
 // iDize attempts to make a resource ID/name string be a resource ID.
@@ -2284,17 +2291,6 @@ func iDize(c *CmdConfig, resourceIDOrName string, resType string, regionSlug str
 				ids = append(ids, id)
 			}
 		}
-	case "cluster":
-		clusters, err := c.Kubernetes().List()
-		if err != nil {
-			return "", err
-		}
-		for _, c := range clusters {
-			if c.Name == resourceIDOrName {
-				id := c.ID
-				ids = append(ids, id)
-			}
-		}
 	}
 
 	switch {
@@ -15,7 +15,10 @@ package commands
 
 import (
 	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strconv"
@@ -27,6 +30,8 @@ import (
 	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/godo"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -51,13 +56,17 @@ With the load-balancer command, you can list, create, or delete load balancers,
 	}
 
 	forwardingRulesTxt := "A comma-separated list of key-value pairs representing forwarding rules, which define how traffic is routed, e.g.: `entry_protocol:tcp,entry_port:3306,target_protocol:tcp,target_port:3306`."
-	CmdBuilder(cmd, RunLoadBalancerGet, "get <id>", "Retrieve a load balancer", "Use this command to retrieve information about a load balancer instance, including:\n\n"+lbDetail, Writer,
-		aliasOpt("g"), displayerType(&displayers.LoadBalancer{}))
+	specTxt := "Path to a load balancer spec in YAML or JSON format. Set to `-` to read from stdin. The spec replaces the flags that configure the load balancer."
+	cmdLoadBalancerGet := CmdBuilder(cmd, RunLoadBalancerGet, "get <load-balancer-id|name>", "Retrieve a load balancer", "Use this command to retrieve information about a load balancer instance, including:\n\n"+lbDetail+"\n\nUse the `--spec` flag to print the load balancer's configuration as a spec instead. The spec can be edited and passed to `doctl compute load-balancer update --spec`.", Writer,
+		aliasOpt("g"), displayerType(&displayers.LoadBalancer{}), fromFileOpt())
+	AddBoolFlag(cmdLoadBalancerGet, doctl.ArgLoadBalancerSpec, "", false, "Prints the load balancer's configuration as a spec, in YAML format or in JSON format with `--output json`")
+	cmdLoadBalancerGet.Example = `The following example saves the spec of a load balancer with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` to a file: doctl compute load-balancer get f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --spec > lb.yaml`
 
 	cmdLoadBalancerCreate := CmdBuilder(cmd, RunLoadBalancerCreate, "create",
-		"Create a new load balancer", "Use this command to create a new load balancer on your account. Valid forwarding rules are:\n"+forwardingDetail, Writer, aliasOpt("c"))
+		"Create a new load balancer", "Use this command to create a new load balancer on your account. Configure the load balancer with flags, or with a spec file passed to the `--spec` flag. A spec is a structured document with the same fields as the load balancer API, such as `forwarding_rules`, `health_check`, `sticky_sessions`, `firewall`, `target_load_balancer_ids`, and `domains`. Use `doctl compute load-balancer get --spec` to see the spec of an existing load balancer. Valid forwarding rules are:\n"+forwardingDetail, Writer, aliasOpt("c"))
+	AddStringFlag(cmdLoadBalancerCreate, doctl.ArgLoadBalancerSpec, "", "", specTxt)
 	AddStringFlag(cmdLoadBalancerCreate, doctl.ArgLoadBalancerName, "", "",
-		"The load balancer's name", requiredOpt())
+		"The load balancer's name. Required unless `--spec` is used.")
 	AddStringFlag(cmdLoadBalancerCreate, doctl.ArgRegionSlug, "", "",
 		"The load balancer's region, e.g.: `nyc1`")
 	AddStringFlag(cmdLoadBalancerCreate, doctl.ArgSizeSlug, "", "",
@@ -109,8 +118,9 @@ With the load-balancer command, you can list, create, or delete load balancers,
 	cmdLoadBalancerCreate.Flags().MarkHidden(doctl.ArgLoadBalancerType)
 	cmdLoadBalancerCreate.Flags().MarkHidden(doctl.ArgLoadBalancerNetwork)
 
-	cmdRecordUpdate := CmdBuilder(cmd, RunLoadBalancerUpdate, "update <id>",
-		"Update a load balancer's configuration", `Use this command to update the configuration of a specified load balancer. Using all applicable flags, the command should contain a full representation of the load balancer including existing attributes, such as the load balancer's name, region, forwarding rules, and Droplet IDs. Any attribute that is not provided is reset to its default value.`, Writer, aliasOpt("u"))
+	cmdRecordUpdate := CmdBuilder(cmd, RunLoadBalancerUpdate, "update <load-balancer-id|name>",
+		"Update a load balancer's configuration", `Use this command to update the configuration of a specified load balancer. Using all applicable flags, or a spec file passed to the `+"`"+`--spec`+"`"+` flag, the command should contain a full representation of the load balancer including existing attributes, such as the load balancer's name, region, forwarding rules, and Droplet IDs. Any attribute that is not provided is reset to its default value. Use `+"`"+`doctl compute load-balancer get --spec`+"`"+` to get the current spec of the load balancer to edit.`, Writer, aliasOpt("u"), fromFileOpt())
+	AddStringFlag(cmdRecordUpdate, doctl.ArgLoadBalancerSpec, "", "", specTxt)
 	AddStringFlag(cmdRecordUpdate, doctl.ArgLoadBalancerName, "", "",
 		"The load balancer's name")
 	AddStringFlag(cmdRecordUpdate, doctl.ArgRegionSlug, "", "",
@@ -160,35 +170,46 @@ With the load-balancer command, you can list, create, or delete load balancers,
 	CmdBuilder(cmd, RunLoadBalancerList, "list", "List load balancers", "Use this command to get a list of the load balancers on your account, including the following information for each:\n\n"+lbDetail, Writer,
 		aliasOpt("ls"), displayerType(&displayers.LoadBalancer{}))
 
-	cmdRunRecordDelete := CmdBuilder(cmd, RunLoadBalancerDelete, "delete <id>",
-		"Permanently delete a load balancer", `Use this command to permanently delete the specified load balancer. This is irreversible.`, Writer, aliasOpt("d", "rm"))
+	cmdRunRecordDelete := CmdBuilder(cmd, RunLoadBalancerDelete, "delete <load-balancer-id|name>",
+		"Permanently delete a load balancer", `Use this command to permanently delete the specified load balancer. This is irreversible.`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunRecordDelete, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Delete the load balancer without a confirmation prompt")
 
-	cmdAddDroplets := CmdBuilder(cmd, RunLoadBalancerAddDroplets, "add-droplets <id>",
-		"Add Droplets to a load balancer", `Use this command to add Droplets to a load balancer.`, Writer)
+	cmdAddDroplets := CmdBuilder(cmd, RunLoadBalancerAddDroplets, "add-droplets <load-balancer-id|name>",
+		"Add Droplets to a load balancer", `Use this command to add Droplets to a load balancer.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdAddDroplets, doctl.ArgDropletIDs, "", []string{},
 		"A comma-separated list of IDs of Droplet to add to the load balancer, example value: `12,33`")
 
 	cmdRemoveDroplets := CmdBuilder(cmd, RunLoadBalancerRemoveDroplets,
-		"remove-droplets <id>", "Remove Droplets from a load balancer", `Use this command to remove Droplets from a load balancer. This command does not destroy any Droplets.`, Writer)
+		"remove-droplets <load-balancer-id|name>", "Remove Droplets from a load balancer", `Use this command to remove Droplets from a load balancer. This command does not destroy any Droplets.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdRemoveDroplets, doctl.ArgDropletIDs, "", []string{},
 		"A comma-separated list of IDs of Droplets to remove from the load balancer, example value: `12,33`")
 
 	cmdAddForwardingRules := CmdBuilder(cmd, RunLoadBalancerAddForwardingRules,
-		"add-forwarding-rules <id>", "Add forwarding rules to a load balancer", "Use this command to add forwarding rules to a load balancer, specified with the `--forwarding-rules` flag. Valid rules include:\n"+forwardingDetail, Writer)
+		"add-forwarding-rules <load-balancer-id|name>", "Add forwarding rules to a load balancer", "Use this command to add forwarding rules to a load balancer, specified with the `--forwarding-rules` flag. Valid rules include:\n"+forwardingDetail, Writer, fromFileOpt())
 	AddStringFlag(cmdAddForwardingRules, doctl.ArgForwardingRules, "", "", forwardingRulesTxt)
 
 	cmdRemoveForwardingRules := CmdBuilder(cmd, RunLoadBalancerRemoveForwardingRules,
-		"remove-forwarding-rules <id>", "Remove forwarding rules from a load balancer", "Use this command to remove forwarding rules from a load balancer, specified with the `--forwarding-rules` flag. Valid rules include:\n"+forwardingDetail, Writer)
+		"remove-forwarding-rules <load-balancer-id|name>", "Remove forwarding rules from a load balancer", "Use this command to remove forwarding rules from a load balancer, specified with the `--forwarding-rules` flag. Valid rules include:\n"+forwardingDetail, Writer, fromFileOpt())
 	AddStringFlag(cmdRemoveForwardingRules, doctl.ArgForwardingRules, "", "", forwardingRulesTxt)
 
-	cmdRunCachePurge := CmdBuilder(cmd, RunLoadBalancerPurgeCache, "purge-cache <id>",
-		"Purges CDN cache for a global load balancer", `Use this command to purge the CDN cache for specified global load balancer.`, Writer)
+	cmdRunCachePurge := CmdBuilder(cmd, RunLoadBalancerPurgeCache, "purge-cache <load-balancer-id|name>",
+		"Purges CDN cache for a global load balancer", `Use this command to purge the CDN cache for specified global load balancer.`, Writer, fromFileOpt())
 	AddBoolFlag(cmdRunCachePurge, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Purge the global load balancer CDN cache without a confirmation prompt "+
 			"(NOTE: this is a closed beta feature, contact DigitalOcean support to review its public availability.)")
 
+	cmdSwap := CmdBuilder(cmd, RunLoadBalancerSwap, "swap <load-balancer-id|name>", "Move traffic to a different group of Droplets", `Use this command for blue/green deployments. It moves a load balancer's traffic from one group of Droplets to another, identified by tag.
+
+The command adds the Droplets with the tag passed to `+"`"+`--to-tag`+"`"+` to the load balancer, and waits until the load balancer's health checks report all of them healthy. It then removes the old Droplets, which are the Droplets with the tag passed to `+"`"+`--from-tag`+"`"+`, or all the load balancer's other Droplets if the flag is not set. If the load balancer targets a tag, the old tag is used and the load balancer targets the new tag when the swap is done.
+
+If the load balancer is not active or any new Droplet fails its health checks within the timeout, the new Droplets are removed and the load balancer is left as it was. New Droplets that have no health check results yet are waited for, and if they still have none at the timeout, the swap is rolled back in the same way.`, Writer,
+		displayerType(&displayers.LoadBalancer{}), fromFileOpt())
+	AddStringFlag(cmdSwap, doctl.ArgSwapToTag, "", "", "The tag of the Droplets to move traffic to", requiredOpt())
+	AddStringFlag(cmdSwap, doctl.ArgSwapFromTag, "", "", "The tag of the Droplets to move traffic from")
+	AddDurationFlag(cmdSwap, doctl.ArgTimeout, "", 5*time.Minute, "How long to wait for the new Droplets to become healthy before rolling back")
+	cmdSwap.Example = `The following example moves the traffic of the load balancer ` + "`" + `web` + "`" + ` from the Droplets tagged ` + "`" + `blue` + "`" + ` to the Droplets tagged ` + "`" + `green` + "`" + `: doctl compute load-balancer swap web --from-tag blue --to-tag green`
+
 	return cmd
 }
 
@@ -198,14 +219,24 @@ func RunLoadBalancerGet(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	id := c.Args[0]
-
 	lbs := c.LoadBalancers()
+	id, err := do.ResolveLoadBalancerID(lbs, c.Args[0])
+	if err != nil {
+		return err
+	}
 	lb, err := lbs.Get(id)
 	if err != nil {
 		return err
 	}
 
+	spec, err := c.Doit.GetBool(c.NS, doctl.ArgLoadBalancerSpec)
+	if err != nil {
+		return err
+	}
+	if spec {
+		return writeLoadBalancerSpec(c.Out, loadBalancerSpec(lb.LoadBalancer))
+	}
+
 	item := &displayers.LoadBalancer{LoadBalancers: do.LoadBalancers{*lb}}
 	return c.Display(item)
 }
@@ -224,10 +255,13 @@ func RunLoadBalancerList(c *CmdConfig) error {
 
 // RunLoadBalancerCreate creates a new load balancer with a given configuration.
 func RunLoadBalancerCreate(c *CmdConfig) error {
-	r := new(godo.LoadBalancerRequest)
-	if err := buildRequestFromArgs(c, r); err != nil {
+	r, err := loadBalancerRequest(c)
+	if err != nil {
 		return err
 	}
+	if r.Name == "" {
+		return fmt.Errorf("--%s is required unless --%s is used", doctl.ArgLoadBalancerName, doctl.ArgLoadBalancerSpec)
+	}
 
 	lbs := c.LoadBalancers()
 	lb, err := lbs.Create(r)
@@ -266,10 +300,13 @@ func RunLoadBalancerUpdate(c *CmdConfig) error {
 	if len(c.Args) == 0 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	lbID := c.Args[0]
+	lbID, err := do.ResolveLoadBalancerID(c.LoadBalancers(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
-	r := new(godo.LoadBalancerRequest)
-	if err := buildRequestFromArgs(c, r); err != nil {
+	r, err := loadBalancerRequest(c)
+	if err != nil {
 		return err
 	}
 
@@ -289,7 +326,10 @@ func RunLoadBalancerDelete(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	lbID := c.Args[0]
+	lbID, err := do.ResolveLoadBalancerID(c.LoadBalancers(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
 	if err != nil {
@@ -314,7 +354,10 @@ func RunLoadBalancerAddDroplets(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	lbID := c.Args[0]
+	lbID, err := do.ResolveLoadBalancerID(c.LoadBalancers(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	dropletIDsList, err := c.Doit.GetStringSlice(c.NS, doctl.ArgDropletIDs)
 	if err != nil {
@@ -335,7 +378,10 @@ func RunLoadBalancerRemoveDroplets(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	lbID := c.Args[0]
+	lbID, err := do.ResolveLoadBalancerID(c.LoadBalancers(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	dropletIDsList, err := c.Doit.GetStringSlice(c.NS, doctl.ArgDropletIDs)
 	if err != nil {
@@ -356,7 +402,10 @@ func RunLoadBalancerAddForwardingRules(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	lbID := c.Args[0]
+	lbID, err := do.ResolveLoadBalancerID(c.LoadBalancers(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	fra, err := c.Doit.GetString(c.NS, doctl.ArgForwardingRules)
 	if err != nil {
@@ -377,7 +426,10 @@ func RunLoadBalancerRemoveForwardingRules(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	lbID := c.Args[0]
+	lbID, err := do.ResolveLoadBalancerID(c.LoadBalancers(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	fra, err := c.Doit.GetString(c.NS, doctl.ArgForwardingRules)
 	if err != nil {
@@ -398,7 +450,10 @@ func RunLoadBalancerPurgeCache(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	lbID := c.Args[0]
+	lbID, err := do.ResolveLoadBalancerID(c.LoadBalancers(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
 	if err != nil {
@@ -514,6 +569,111 @@ func fillStructFromStringSliceArgs(obj any, s string, delimiter string) error {
 	return nil
 }
 
+// loadBalancerConfigFlags are the flags that a load balancer spec replaces.
+var loadBalancerConfigFlags = []string{
+	doctl.ArgLoadBalancerName, doctl.ArgRegionSlug, doctl.ArgSizeSlug, doctl.ArgSizeUnit,
+	doctl.ArgLoadBalancerType, doctl.ArgVPCUUID, doctl.ArgLoadBalancerAlgorithm,
+	doctl.ArgRedirectHTTPToHTTPS, doctl.ArgEnableProxyProtocol, doctl.ArgEnableBackendKeepalive,
+	doctl.ArgDisableLetsEncryptDNSRecords, doctl.ArgTagName, doctl.ArgDropletIDs,
+	doctl.ArgStickySessions, doctl.ArgHealthCheck, doctl.ArgForwardingRules,
+	doctl.ArgHTTPIdleTimeoutSeconds, doctl.ArgAllowList, doctl.ArgDenyList,
+	doctl.ArgLoadBalancerDomains, doctl.ArgGlobalLoadBalancerSettings,
+	doctl.ArgGlobalLoadBalancerCDNSettings, doctl.ArgTargetLoadBalancerIDs, doctl.ArgLoadBalancerNetwork,
+}
+
+// loadBalancerRequest builds a create or update request from the spec file
+// passed to --spec, or from flags if there is none.
+func loadBalancerRequest(c *CmdConfig) (*godo.LoadBalancerRequest, error) {
+	specPath, err := c.Doit.GetString(c.NS, doctl.ArgLoadBalancerSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if specPath == "" {
+		r := new(godo.LoadBalancerRequest)
+		if err := buildRequestFromArgs(c, r); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+
+	for _, flag := range loadBalancerConfigFlags {
+		if c.Doit.IsSet(flag) {
+			return nil, fmt.Errorf("--%s cannot be used with --%s; set the field in the spec instead", flag, doctl.ArgLoadBalancerSpec)
+		}
+	}
+
+	r, err := readLoadBalancerSpec(os.Stdin, specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, err := c.Doit.GetString(c.NS, doctl.ArgProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if projectID != "" {
+		r.ProjectID = projectID
+	}
+	return r, nil
+}
+
+// readLoadBalancerSpec reads a load balancer spec from path, or from stdin
+// if path is "-". Unknown fields are an error.
+func readLoadBalancerSpec(stdin io.Reader, path string) (*godo.LoadBalancerRequest, error) {
+	b, err := readInput(stdin, path)
+	if err != nil {
+		return nil, fmt.Errorf("reading load balancer spec: %w", err)
+	}
+
+	var r godo.LoadBalancerRequest
+	if err := yaml.UnmarshalStrict(b, &r); err != nil {
+		return nil, fmt.Errorf("parsing load balancer spec: %w", err)
+	}
+	if r.Name == "" {
+		return nil, errors.New("parsing load balancer spec: name is required")
+	}
+	return &r, nil
+}
+
+// loadBalancerSpec returns the configuration of lb as a request that
+// recreates it. Fields that are only reported by the API, and fields that
+// conflict with others that are set, are left out.
+func loadBalancerSpec(lb *godo.LoadBalancer) *godo.LoadBalancerRequest {
+	r := lb.AsRequest()
+	r.Algorithm = ""
+	r.ValidateOnly = false
+	r.ProjectID = ""
+	if r.SizeUnit != 0 {
+		r.SizeSlug = ""
+	}
+	if r.Tag != "" {
+		r.DropletIDs = nil
+	}
+	for _, d := range r.Domains {
+		d.Status = ""
+		d.VerificationErrorReasons = nil
+		d.SSLValidationErrorReasons = nil
+	}
+	return r
+}
+
+// writeLoadBalancerSpec writes a spec as YAML, or as JSON if the output
+// format is json.
+func writeLoadBalancerSpec(out io.Writer, r *godo.LoadBalancerRequest) error {
+	if viper.GetString("output") == "json" {
+		e := json.NewEncoder(out)
+		e.SetIndent("", "  ")
+		return e.Encode(r)
+	}
+	b, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(b)
+	return err
+}
+
 func buildRequestFromArgs(c *CmdConfig, r *godo.LoadBalancerRequest) error {
 	name, err := c.Doit.GetString(c.NS, doctl.ArgLoadBalancerName)
 	if err != nil {
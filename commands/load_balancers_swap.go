@@ -0,0 +1,267 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+)
+
+// lbSwapPollInterval is how often swap checks the health of the new
+// Droplets.
+var lbSwapPollInterval = 10 * time.Second
+
+// RunLoadBalancerSwap moves a load balancer's traffic from one group of
+// Droplets to another, and rolls back if the new Droplets are not healthy.
+func RunLoadBalancerSwap(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+	toTag, err := c.Doit.GetString(c.NS, doctl.ArgSwapToTag)
+	if err != nil {
+		return err
+	}
+	fromTag, err := c.Doit.GetString(c.NS, doctl.ArgSwapFromTag)
+	if err != nil {
+		return err
+	}
+	timeout, err := c.Doit.GetDuration(c.NS, doctl.ArgTimeout)
+	if err != nil {
+		return err
+	}
+	if toTag == fromTag {
+		return fmt.Errorf("--%s and --%s must be different", doctl.ArgSwapToTag, doctl.ArgSwapFromTag)
+	}
+
+	lbs := c.LoadBalancers()
+	lbID, err := do.ResolveLoadBalancerID(lbs, c.Args[0])
+	if err != nil {
+		return err
+	}
+	lb, err := lbs.Get(lbID)
+	if err != nil {
+		return err
+	}
+
+	newIDs, err := taggedDropletIDs(c, toTag)
+	if err != nil {
+		return err
+	}
+	if len(newIDs) == 0 {
+		return fmt.Errorf("no Droplets have the tag %q", toTag)
+	}
+
+	if lb.Tag != "" {
+		err = swapLoadBalancerTag(c, lb, fromTag, toTag, newIDs, timeout)
+	} else {
+		err = swapLoadBalancerDroplets(c, lb, fromTag, newIDs, timeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	lb, err = lbs.Get(lbID)
+	if err != nil {
+		return err
+	}
+	return c.Display(&displayers.LoadBalancer{LoadBalancers: do.LoadBalancers{*lb}})
+}
+
+// swapLoadBalancerTag swaps a load balancer that targets a tag. The load
+// balancer targets the Droplets of both tags by ID while the new Droplets
+// are checked, then targets the new tag.
+func swapLoadBalancerTag(c *CmdConfig, lb *do.LoadBalancer, fromTag, toTag string, newIDs []int, timeout time.Duration) error {
+	if lb.Tag == toTag {
+		return fmt.Errorf("load balancer %s already targets the tag %q", lb.Name, toTag)
+	}
+	if fromTag != "" && fromTag != lb.Tag {
+		return fmt.Errorf("load balancer %s targets the tag %q, not %q", lb.Name, lb.Tag, fromTag)
+	}
+	fromTag = lb.Tag
+
+	oldIDs, err := taggedDropletIDs(c, fromTag)
+	if err != nil {
+		return err
+	}
+
+	lbs := c.LoadBalancers()
+	r := loadBalancerSpec(lb.LoadBalancer)
+	r.Tag = ""
+	r.DropletIDs = unionIDs(oldIDs, newIDs)
+	if _, err := lbs.Update(lb.ID, r); err != nil {
+		return err
+	}
+	notice("Added %d Droplets with the tag %q to load balancer %s, waiting for them to become healthy", len(newIDs), toTag, lb.Name)
+
+	r.DropletIDs = nil
+	if err := waitForHealthyDroplets(lbs, lb.ID, newIDs, timeout); err != nil {
+		r.Tag = fromTag
+		if _, rerr := lbs.Update(lb.ID, r); rerr != nil {
+			return fmt.Errorf("%w; rolling back to the tag %q failed: %v", err, fromTag, rerr)
+		}
+		return fmt.Errorf("%w; load balancer %s was rolled back to the tag %q", err, lb.Name, fromTag)
+	}
+
+	r.Tag = toTag
+	if _, err := lbs.Update(lb.ID, r); err != nil {
+		return err
+	}
+	notice("Load balancer %s now targets the tag %q", lb.Name, toTag)
+	return nil
+}
+
+// swapLoadBalancerDroplets swaps a load balancer that targets Droplets by
+// ID, by adding the new Droplets and then removing the old ones.
+func swapLoadBalancerDroplets(c *CmdConfig, lb *do.LoadBalancer, fromTag string, newIDs []int, timeout time.Duration) error {
+	var oldIDs []int
+	if fromTag != "" {
+		tagged, err := taggedDropletIDs(c, fromTag)
+		if err != nil {
+			return err
+		}
+		oldIDs = intersectIDs(lb.DropletIDs, tagged)
+		if len(oldIDs) == 0 {
+			return fmt.Errorf("load balancer %s has no Droplets with the tag %q", lb.Name, fromTag)
+		}
+	} else {
+		oldIDs = differenceIDs(lb.DropletIDs, newIDs)
+	}
+	oldIDs = differenceIDs(oldIDs, newIDs)
+	added := differenceIDs(newIDs, lb.DropletIDs)
+
+	lbs := c.LoadBalancers()
+	if len(added) > 0 {
+		if err := lbs.AddDroplets(lb.ID, added...); err != nil {
+			return err
+		}
+	}
+	notice("Added Droplets %s to load balancer %s, waiting for them to become healthy", joinDropletIDs(added), lb.Name)
+
+	if err := waitForHealthyDroplets(lbs, lb.ID, newIDs, timeout); err != nil {
+		if len(added) == 0 {
+			return err
+		}
+		if rerr := lbs.RemoveDroplets(lb.ID, added...); rerr != nil {
+			return fmt.Errorf("%w; removing the new Droplets failed: %v", err, rerr)
+		}
+		return fmt.Errorf("%w; the new Droplets were removed from load balancer %s", err, lb.Name)
+	}
+
+	if len(oldIDs) > 0 {
+		if err := lbs.RemoveDroplets(lb.ID, oldIDs...); err != nil {
+			return err
+		}
+	}
+	notice("Removed Droplets %s from load balancer %s", joinDropletIDs(oldIDs), lb.Name)
+	return nil
+}
+
+// waitForHealthyDroplets waits until the load balancer is active and its
+// health checks report every Droplet in ids as healthy, or until timeout.
+// A Droplet with no health check results is pending rather than unhealthy,
+// since results only appear a while after it is added, but one that is still
+// pending at the timeout fails the wait like an unhealthy one.
+func waitForHealthyDroplets(lbs do.LoadBalancersService, lbID string, ids []int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		var unhealthy, pending []int
+		lb, err := lbs.Get(lbID)
+		if err == nil && lb.Status != "active" {
+			err = fmt.Errorf("load balancer %s did not become active within %s; its status is %q", lb.Name, timeout, lb.Status)
+		}
+		if err == nil {
+			var health map[int]bool
+			health, err = lbs.DropletHealth(lbID)
+			for _, id := range ids {
+				if healthy, ok := health[id]; !ok {
+					pending = append(pending, id)
+				} else if !healthy {
+					unhealthy = append(unhealthy, id)
+				}
+			}
+		}
+		if err == nil {
+			if len(unhealthy) == 0 && len(pending) == 0 {
+				return nil
+			}
+			if len(unhealthy) > 0 {
+				err = fmt.Errorf("Droplets %s did not become healthy within %s", joinDropletIDs(unhealthy), timeout)
+			} else {
+				err = fmt.Errorf("Droplets %s did not report health check results within %s", joinDropletIDs(pending), timeout)
+			}
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(lbSwapPollInterval)
+	}
+}
+
+func taggedDropletIDs(c *CmdConfig, tag string) ([]int, error) {
+	droplets, err := c.Droplets().ListByTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(droplets))
+	for _, d := range droplets {
+		ids = append(ids, d.ID)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func unionIDs(a, b []int) []int {
+	ids := slices.Concat(a, b)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+func intersectIDs(a, b []int) []int {
+	var ids []int
+	for _, id := range a {
+		if slices.Contains(b, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func differenceIDs(a, b []int) []int {
+	var ids []int
+	for _, id := range a {
+		if !slices.Contains(b, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func joinDropletIDs(ids []int) string {
+	if len(ids) == 0 {
+		return "(none)"
+	}
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testSwapLBID = "cde2c0d6-41e3-479e-ba60-ad971227232c"
+
+func testSwapDroplets(ids ...int) do.Droplets {
+	var droplets do.Droplets
+	for _, id := range ids {
+		droplets = append(droplets, do.Droplet{Droplet: &godo.Droplet{ID: id}})
+	}
+	return droplets
+}
+
+func withSwapPollInterval(t *testing.T) {
+	d := lbSwapPollInterval
+	lbSwapPollInterval = time.Millisecond
+	t.Cleanup(func() { lbSwapPollInterval = d })
+}
+
+func TestLoadBalancerSwapDroplets(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withSwapPollInterval(t)
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{ID: testSwapLBID, Name: "web", Status: "active", DropletIDs: []int{1, 2, 9}}}
+
+		gomock.InOrder(
+			tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil),
+			tm.droplets.EXPECT().ListByTag("green").Return(testSwapDroplets(3, 4), nil),
+			tm.droplets.EXPECT().ListByTag("blue").Return(testSwapDroplets(1, 2), nil),
+			tm.loadBalancers.EXPECT().AddDroplets(testSwapLBID, 3, 4).Return(nil),
+			tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil),
+			tm.loadBalancers.EXPECT().DropletHealth(testSwapLBID).Return(map[int]bool{1: true, 2: true, 3: true}, nil),
+			tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil),
+			tm.loadBalancers.EXPECT().DropletHealth(testSwapLBID).Return(map[int]bool{1: true, 2: true, 3: true, 4: true}, nil),
+			tm.loadBalancers.EXPECT().RemoveDroplets(testSwapLBID, 1, 2).Return(nil),
+			tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil),
+		)
+
+		config.Args = append(config.Args, testSwapLBID)
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "green")
+		config.Doit.Set(config.NS, doctl.ArgSwapFromTag, "blue")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, time.Minute)
+
+		err := RunLoadBalancerSwap(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoadBalancerSwapDropletsRollback(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withSwapPollInterval(t)
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{ID: testSwapLBID, Name: "web", Status: "active", DropletIDs: []int{1, 2}}}
+
+		tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil).MinTimes(2)
+		tm.droplets.EXPECT().ListByTag("green").Return(testSwapDroplets(3), nil)
+		tm.loadBalancers.EXPECT().AddDroplets(testSwapLBID, 3).Return(nil)
+		tm.loadBalancers.EXPECT().DropletHealth(testSwapLBID).Return(map[int]bool{1: true, 2: true, 3: false}, nil).MinTimes(1)
+		tm.loadBalancers.EXPECT().RemoveDroplets(testSwapLBID, 3).Return(nil)
+
+		config.Args = append(config.Args, testSwapLBID)
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "green")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 5*time.Millisecond)
+
+		err := RunLoadBalancerSwap(config)
+		assert.EqualError(t, err, "Droplets 3 did not become healthy within 5ms; the new Droplets were removed from load balancer web")
+	})
+}
+
+func TestLoadBalancerSwapDropletsPending(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withSwapPollInterval(t)
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{ID: testSwapLBID, Name: "web", Status: "active", DropletIDs: []int{1, 2}}}
+
+		// Droplet 3 never has health check results, so the swap is rolled
+		// back.
+		tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil).MinTimes(2)
+		tm.droplets.EXPECT().ListByTag("green").Return(testSwapDroplets(3), nil)
+		tm.loadBalancers.EXPECT().AddDroplets(testSwapLBID, 3).Return(nil)
+		tm.loadBalancers.EXPECT().DropletHealth(testSwapLBID).Return(map[int]bool{1: true, 2: true}, nil).MinTimes(1)
+		tm.loadBalancers.EXPECT().RemoveDroplets(testSwapLBID, 3).Return(nil)
+
+		config.Args = append(config.Args, testSwapLBID)
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "green")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 5*time.Millisecond)
+
+		err := RunLoadBalancerSwap(config)
+		assert.EqualError(t, err, "Droplets 3 did not report health check results within 5ms; the new Droplets were removed from load balancer web")
+	})
+}
+
+func TestLoadBalancerSwapDropletsInactive(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withSwapPollInterval(t)
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{ID: testSwapLBID, Name: "web", Status: "errored", DropletIDs: []int{1, 2}}}
+
+		tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil).MinTimes(2)
+		tm.droplets.EXPECT().ListByTag("green").Return(testSwapDroplets(3), nil)
+		tm.loadBalancers.EXPECT().AddDroplets(testSwapLBID, 3).Return(nil)
+		tm.loadBalancers.EXPECT().RemoveDroplets(testSwapLBID, 3).Return(nil)
+
+		config.Args = append(config.Args, testSwapLBID)
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "green")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 5*time.Millisecond)
+
+		err := RunLoadBalancerSwap(config)
+		assert.EqualError(t, err, `load balancer web did not become active within 5ms; its status is "errored"; the new Droplets were removed from load balancer web`)
+	})
+}
+
+func TestLoadBalancerSwapTag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withSwapPollInterval(t)
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{
+			ID:              testSwapLBID,
+			Name:            "web",
+			Status:          "active",
+			Region:          &godo.Region{Slug: "nyc1"},
+			SizeUnit:        1,
+			Tag:             "blue",
+			ForwardingRules: []godo.ForwardingRule{{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 80}},
+		}}
+		req := func(tag string, ids ...int) *godo.LoadBalancerRequest {
+			return &godo.LoadBalancerRequest{
+				Name:            "web",
+				Region:          "nyc1",
+				SizeUnit:        1,
+				Tag:             tag,
+				DropletIDs:      ids,
+				ForwardingRules: lb.ForwardingRules,
+			}
+		}
+
+		gomock.InOrder(
+			tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil),
+			tm.droplets.EXPECT().ListByTag("green").Return(testSwapDroplets(3), nil),
+			tm.droplets.EXPECT().ListByTag("blue").Return(testSwapDroplets(1), nil),
+			tm.loadBalancers.EXPECT().Update(testSwapLBID, req("", 1, 3)).Return(lb, nil),
+			tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil),
+			tm.loadBalancers.EXPECT().DropletHealth(testSwapLBID).Return(map[int]bool{1: true, 3: true}, nil),
+			tm.loadBalancers.EXPECT().Update(testSwapLBID, req("green")).Return(lb, nil),
+			tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil),
+		)
+
+		config.Args = append(config.Args, testSwapLBID)
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "green")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, time.Minute)
+
+		err := RunLoadBalancerSwap(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoadBalancerSwapTagRollback(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		withSwapPollInterval(t)
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{ID: testSwapLBID, Name: "web", Status: "active", Tag: "blue"}}
+
+		tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil).MinTimes(2)
+		tm.droplets.EXPECT().ListByTag("green").Return(testSwapDroplets(3), nil)
+		tm.droplets.EXPECT().ListByTag("blue").Return(testSwapDroplets(1), nil)
+		tm.loadBalancers.EXPECT().Update(testSwapLBID, &godo.LoadBalancerRequest{Name: "web", DropletIDs: []int{1, 3}}).Return(lb, nil)
+		tm.loadBalancers.EXPECT().DropletHealth(testSwapLBID).Return(map[int]bool{1: true, 3: false}, nil).MinTimes(1)
+		tm.loadBalancers.EXPECT().Update(testSwapLBID, &godo.LoadBalancerRequest{Name: "web", Tag: "blue"}).Return(lb, nil)
+
+		config.Args = append(config.Args, testSwapLBID)
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "green")
+		config.Doit.Set(config.NS, doctl.ArgTimeout, 5*time.Millisecond)
+
+		err := RunLoadBalancerSwap(config)
+		assert.EqualError(t, err, `Droplets 3 did not become healthy within 5ms; load balancer web was rolled back to the tag "blue"`)
+	})
+}
+
+func TestLoadBalancerSwapErrors(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{ID: testSwapLBID, Name: "web", Tag: "blue"}}
+		tm.loadBalancers.EXPECT().Get(testSwapLBID).Return(lb, nil).Times(2)
+		tm.droplets.EXPECT().ListByTag("green").Return(do.Droplets{}, nil)
+		tm.droplets.EXPECT().ListByTag("blue").Return(testSwapDroplets(1), nil)
+
+		config.Args = append(config.Args, testSwapLBID)
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "green")
+		err := RunLoadBalancerSwap(config)
+		assert.EqualError(t, err, `no Droplets have the tag "green"`)
+
+		config.Doit.Set(config.NS, doctl.ArgSwapToTag, "blue")
+		config.Doit.Set(config.NS, doctl.ArgSwapFromTag, "red")
+		err = RunLoadBalancerSwap(config)
+		require.EqualError(t, err, `load balancer web already targets the tag "blue"`)
+	})
+}
@@ -1,6 +1,10 @@
 package commands
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/digitalocean/doctl"
@@ -8,6 +12,7 @@ import (
 	"github.com/digitalocean/godo"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -30,7 +35,7 @@ var (
 func TestLoadBalancerCommand(t *testing.T) {
 	cmd := LoadBalancer()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "get", "list", "create", "update", "delete", "add-droplets", "remove-droplets", "add-forwarding-rules", "remove-forwarding-rules", "purge-cache")
+	assertCommandNames(t, cmd, "get", "list", "create", "update", "delete", "add-droplets", "remove-droplets", "add-forwarding-rules", "remove-forwarding-rules", "purge-cache", "swap")
 }
 
 func TestLoadBalancerGet(t *testing.T) {
@@ -600,3 +605,161 @@ func TestLoadBalancerRemoveForwardingRulesNoID(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+const testLoadBalancerSpec = `name: web
+region: nyc1
+size_unit: 2
+forwarding_rules:
+- entry_protocol: https
+  entry_port: 443
+  target_protocol: http
+  target_port: 80
+  certificate_id: cert-1
+health_check:
+  protocol: http
+  port: 80
+  path: /health
+  check_interval_seconds: 10
+  response_timeout_seconds: 5
+  healthy_threshold: 3
+  unhealthy_threshold: 3
+sticky_sessions:
+  type: cookies
+  cookie_name: lb
+  cookie_ttl_seconds: 300
+firewall:
+  allow:
+  - cidr:203.0.113.0/24
+tag: web
+domains:
+- name: example.com
+  is_managed: true
+`
+
+func TestLoadBalancerCreateWithSpec(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := filepath.Join(t.TempDir(), "lb.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(testLoadBalancerSpec), 0600))
+
+		r := &godo.LoadBalancerRequest{
+			Name:     "web",
+			Region:   "nyc1",
+			SizeUnit: 2,
+			ForwardingRules: []godo.ForwardingRule{
+				{EntryProtocol: "https", EntryPort: 443, TargetProtocol: "http", TargetPort: 80, CertificateID: "cert-1"},
+			},
+			HealthCheck: &godo.HealthCheck{
+				Protocol: "http", Port: 80, Path: "/health",
+				CheckIntervalSeconds: 10, ResponseTimeoutSeconds: 5, HealthyThreshold: 3, UnhealthyThreshold: 3,
+			},
+			StickySessions: &godo.StickySessions{Type: "cookies", CookieName: "lb", CookieTtlSeconds: 300},
+			Firewall:       &godo.LBFirewall{Allow: []string{"cidr:203.0.113.0/24"}},
+			Tag:            "web",
+			Domains:        []*godo.LBDomain{{Name: "example.com", IsManaged: true}},
+			ProjectID:      "project-1",
+		}
+		tm.loadBalancers.EXPECT().Create(r).Return(&testLoadBalancer, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgLoadBalancerSpec, path)
+		config.Doit.Set(config.NS, doctl.ArgProjectID, "project-1")
+
+		err := RunLoadBalancerCreate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoadBalancerCreateWithSpecErrors(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := filepath.Join(t.TempDir(), "lb.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("name: web\nforwarding_rule: []\n"), 0600))
+
+		config.Doit.Set(config.NS, doctl.ArgLoadBalancerSpec, path)
+		err := RunLoadBalancerCreate(config)
+		assert.ErrorContains(t, err, `parsing load balancer spec: error unmarshaling JSON: while decoding JSON: json: unknown field "forwarding_rule"`)
+
+		config.Doit.Set(config.NS, doctl.ArgRegionSlug, "nyc1")
+		err = RunLoadBalancerCreate(config)
+		assert.EqualError(t, err, "--region cannot be used with --spec; set the field in the spec instead")
+	})
+}
+
+func TestLoadBalancerCreateNoName(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		err := RunLoadBalancerCreate(config)
+		assert.EqualError(t, err, "--name is required unless --spec is used")
+	})
+}
+
+func TestLoadBalancerUpdateWithSpec(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		lbID := "cde2c0d6-41e3-479e-ba60-ad971227232c"
+		r := &godo.LoadBalancerRequest{
+			Name:            "web",
+			Region:          "nyc1",
+			ForwardingRules: []godo.ForwardingRule{{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 80}},
+			DropletIDs:      []int{1, 2},
+		}
+		tm.loadBalancers.EXPECT().Update(lbID, r).Return(&testLoadBalancer, nil)
+
+		path := filepath.Join(t.TempDir(), "lb.json")
+		spec := `{"name": "web", "region": "nyc1", "droplet_ids": [1, 2], "forwarding_rules": [{"entry_protocol": "http", "entry_port": 80, "target_protocol": "http", "target_port": 80}]}`
+		require.NoError(t, os.WriteFile(path, []byte(spec), 0600))
+
+		config.Args = append(config.Args, lbID)
+		config.Doit.Set(config.NS, doctl.ArgLoadBalancerSpec, path)
+
+		err := RunLoadBalancerUpdate(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoadBalancerGetSpec(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		lbID := "cde2c0d6-41e3-479e-ba60-ad971227232c"
+		lb := &do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{
+			ID:         lbID,
+			Name:       "web",
+			IP:         "203.0.113.1",
+			Status:     "active",
+			Algorithm:  "round_robin",
+			Region:     &godo.Region{Slug: "nyc1", Name: "New York 1"},
+			SizeSlug:   "lb-small",
+			SizeUnit:   1,
+			Tag:        "web",
+			DropletIDs: []int{1, 2},
+			ForwardingRules: []godo.ForwardingRule{
+				{EntryProtocol: "http", EntryPort: 80, TargetProtocol: "http", TargetPort: 80},
+			},
+			Domains: []*godo.LBDomain{{Name: "example.com", IsManaged: true, Status: "ACTIVE"}},
+		}}
+		tm.loadBalancers.EXPECT().Get(lbID).Return(lb, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, lbID)
+		config.Doit.Set(config.NS, doctl.ArgLoadBalancerSpec, true)
+
+		err := RunLoadBalancerGet(config)
+		require.NoError(t, err)
+
+		expected := `domains:
+- is_managed: true
+  name: example.com
+forwarding_rules:
+- entry_port: 80
+  entry_protocol: http
+  target_port: 80
+  target_protocol: http
+name: web
+region: nyc1
+size_unit: 1
+tag: web
+`
+		assert.Equal(t, expected, buf.String())
+
+		// The spec can be read back.
+		r, err := readLoadBalancerSpec(strings.NewReader(buf.String()), "-")
+		require.NoError(t, err)
+		assert.Equal(t, "web", r.Name)
+	})
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"sigs.k8s.io/yaml"
+)
+
+// manifest describes the desired state of a set of resources, each keyed by
+// its name. It is read by `doctl apply` and `doctl plan`, and written by
+// `doctl export`.
+type manifest struct {
+	Tags          []string               `json:"tags,omitempty"`
+	Droplets      []manifestDroplet      `json:"droplets,omitempty"`
+	Volumes       []manifestVolume       `json:"volumes,omitempty"`
+	ReservedIPs   []manifestReservedIP   `json:"reserved_ips,omitempty"`
+	LoadBalancers []manifestLoadBalancer `json:"load_balancers,omitempty"`
+	Firewalls     []manifestFirewall     `json:"firewalls,omitempty"`
+	Domains       []manifestDomain       `json:"domains,omitempty"`
+	AlertPolicies []manifestAlertPolicy  `json:"alert_policies,omitempty"`
+}
+
+type manifestDroplet struct {
+	Name       string   `json:"name"`
+	Region     string   `json:"region"`
+	Size       string   `json:"size"`
+	Image      string   `json:"image"`
+	SSHKeys    []string `json:"ssh_keys,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	VPCUUID    string   `json:"vpc_uuid,omitempty"`
+	Backups    bool     `json:"backups,omitempty"`
+	IPv6       bool     `json:"ipv6,omitempty"`
+	Monitoring bool     `json:"monitoring,omitempty"`
+	UserData   string   `json:"user_data,omitempty"`
+}
+
+// manifestVolume is a block storage volume. Droplet is the name of the
+// Droplet it is attached to, if any.
+type manifestVolume struct {
+	Name            string   `json:"name"`
+	Region          string   `json:"region"`
+	SizeGigaBytes   int64    `json:"size_gigabytes"`
+	Description     string   `json:"description,omitempty"`
+	FilesystemType  string   `json:"filesystem_type,omitempty"`
+	FilesystemLabel string   `json:"filesystem_label,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	Droplet         string   `json:"droplet,omitempty"`
+}
+
+// manifestReservedIP is a reserved IP, identified by its address if it
+// already exists or otherwise by the Droplet it is assigned to.
+type manifestReservedIP struct {
+	IP      string `json:"ip,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Droplet string `json:"droplet,omitempty"`
+}
+
+// manifestLoadBalancer is a load balancer. Droplets are given by name.
+type manifestLoadBalancer struct {
+	Name                         string                `json:"name"`
+	Region                       string                `json:"region,omitempty"`
+	Size                         string                `json:"size,omitempty"`
+	SizeUnit                     uint32                `json:"size_unit,omitempty"`
+	Type                         string                `json:"type,omitempty"`
+	Network                      string                `json:"network,omitempty"`
+	VPCUUID                      string                `json:"vpc_uuid,omitempty"`
+	ForwardingRules              []godo.ForwardingRule `json:"forwarding_rules,omitempty"`
+	HealthCheck                  *godo.HealthCheck     `json:"health_check,omitempty"`
+	StickySessions               *godo.StickySessions  `json:"sticky_sessions,omitempty"`
+	Droplets                     []string              `json:"droplets,omitempty"`
+	Tag                          string                `json:"tag,omitempty"`
+	RedirectHttpToHttps          bool                  `json:"redirect_http_to_https,omitempty"`
+	EnableProxyProtocol          bool                  `json:"enable_proxy_protocol,omitempty"`
+	EnableBackendKeepalive       bool                  `json:"enable_backend_keepalive,omitempty"`
+	HTTPIdleTimeoutSeconds       *uint64               `json:"http_idle_timeout_seconds,omitempty"`
+	DisableLetsEncryptDNSRecords *bool                 `json:"disable_lets_encrypt_dns_records,omitempty"`
+	Firewall                     *godo.LBFirewall      `json:"firewall,omitempty"`
+}
+
+type manifestFirewall struct {
+	Name          string              `json:"name"`
+	InboundRules  []godo.InboundRule  `json:"inbound_rules,omitempty"`
+	OutboundRules []godo.OutboundRule `json:"outbound_rules,omitempty"`
+	Droplets      []string            `json:"droplets,omitempty"`
+	Tags          []string            `json:"tags,omitempty"`
+}
+
+// manifestDomain is a domain. When Records is set, the domain's records are
+// managed too, apart from its SOA record and DigitalOcean's NS records.
+type manifestDomain struct {
+	Name      string           `json:"name"`
+	IPAddress string           `json:"ip_address,omitempty"`
+	Records   []manifestRecord `json:"records,omitempty"`
+}
+
+type manifestRecord struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	Priority int    `json:"priority,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	Weight   int    `json:"weight,omitempty"`
+	TTL      int    `json:"ttl,omitempty"`
+	Flags    int    `json:"flags,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+}
+
+// manifestAlertPolicy is a monitoring alert policy. As alert policies have no
+// name, they are keyed by their description.
+type manifestAlertPolicy struct {
+	Description string               `json:"description"`
+	Type        string               `json:"type"`
+	Compare     godo.AlertPolicyComp `json:"compare"`
+	Value       float32              `json:"value"`
+	Window      string               `json:"window"`
+	Entities    []string             `json:"entities,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Alerts      godo.Alerts          `json:"alerts"`
+	Enabled     *bool                `json:"enabled,omitempty"`
+}
+
+// readInput reads the file at path, or stdin if path is "-".
+func readInput(stdin io.Reader, path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// readManifest reads a manifest from path, or from stdin if path is "-".
+func readManifest(stdin io.Reader, path string) (*manifest, error) {
+	b, err := readInput(stdin, path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var m manifest
+	if err := yaml.UnmarshalStrict(b, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// validate checks that every resource in the manifest has the fields needed
+// to identify and create it, and that no name is used twice.
+func (m *manifest) validate() error {
+	seen := map[string]bool{}
+	unique := func(kind, name string) error {
+		if name == "" {
+			return fmt.Errorf("manifest: every %s needs a name", kind)
+		}
+		key := kind + "/" + name
+		if seen[key] {
+			return fmt.Errorf("manifest: %s %q is defined more than once", kind, name)
+		}
+		seen[key] = true
+		return nil
+	}
+
+	for _, t := range m.Tags {
+		if err := unique("tag", t); err != nil {
+			return err
+		}
+	}
+	for _, d := range m.Droplets {
+		if err := unique("droplet", d.Name); err != nil {
+			return err
+		}
+		if d.Region == "" || d.Size == "" || d.Image == "" {
+			return fmt.Errorf("manifest: droplet %q needs a region, size, and image", d.Name)
+		}
+	}
+	for _, v := range m.Volumes {
+		if err := unique("volume", v.Name); err != nil {
+			return err
+		}
+		if v.Region == "" || v.SizeGigaBytes <= 0 {
+			return fmt.Errorf("manifest: volume %q needs a region and size_gigabytes", v.Name)
+		}
+	}
+	for _, r := range m.ReservedIPs {
+		if r.IP == "" && r.Droplet == "" {
+			return fmt.Errorf("manifest: every reserved IP needs an ip or a droplet")
+		}
+		if r.IP == "" && r.Region == "" && !m.hasDroplet(r.Droplet) {
+			return fmt.Errorf("manifest: reserved IP for droplet %q needs a region", r.Droplet)
+		}
+		if err := unique("reserved IP", r.key()); err != nil {
+			return err
+		}
+	}
+	for _, lb := range m.LoadBalancers {
+		if err := unique("load balancer", lb.Name); err != nil {
+			return err
+		}
+	}
+	for _, f := range m.Firewalls {
+		if err := unique("firewall", f.Name); err != nil {
+			return err
+		}
+	}
+	for _, d := range m.Domains {
+		if err := unique("domain", d.Name); err != nil {
+			return err
+		}
+		for _, r := range d.Records {
+			if r.Type == "" || r.Name == "" || r.Data == "" {
+				return fmt.Errorf("manifest: every record of domain %q needs a type, name, and data", d.Name)
+			}
+		}
+	}
+	for _, a := range m.AlertPolicies {
+		if a.Description == "" {
+			return fmt.Errorf("manifest: every alert policy needs a description")
+		}
+		if err := unique("alert policy", a.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manifest) hasDroplet(name string) bool {
+	for _, d := range m.Droplets {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// key is the name a reserved IP is listed under in plans.
+func (r manifestReservedIP) key() string {
+	if r.IP != "" {
+		return r.IP
+	}
+	return "assigned to " + r.Droplet
+}
+
+// key identifies a record among the records of domain. The TTL is left out so
+// that a change of TTL updates the record in place, and host names are
+// compared in the same form whether they are fully qualified, relative to
+// the domain as "@", or differ in case.
+func (r manifestRecord) key(domain string) string {
+	data := r.Data
+	switch strings.ToUpper(r.Type) {
+	case "CNAME", "MX", "NS", "SRV":
+		data = strings.ToLower(strings.TrimSuffix(data, "."))
+		if data == "@" {
+			data = strings.ToLower(domain)
+		}
+	}
+	return fmt.Sprintf("%s %s %s %d %d %d %d %s", strings.ToUpper(r.Type), strings.ToLower(r.Name), data, r.Priority, r.Port, r.Weight, r.Flags, r.Tag)
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/spf13/cobra"
+)
+
+// lookupHost resolves a hostname to its addresses. It is a variable so that
+// tests can replace it.
+var lookupHost = net.DefaultResolver.LookupHost
+
+// hostLookupTimeout bounds the time spent resolving the hostnames of
+// databases and Kubernetes clusters.
+const hostLookupTimeout = 10 * time.Second
+
+// Network creates the network command.
+func Network() *Command {
+	cmd := &Command{
+		Command: &cobra.Command{
+			Use:     "network",
+			Short:   "Display commands for investigating network addresses",
+			Long:    "The subcommands under `doctl network` help you find out which of your resources a network address belongs to.",
+			GroupID: manageResourcesGroup,
+		},
+	}
+
+	cmdWhois := CmdBuilder(cmd, RunNetworkWhois, "whois <ip>", "Find the resource an IP address belongs to",
+		`Finds the resources on your account that own the given IPv4 or IPv6 address, and reports each one's type, ID, name, project, and tags. The following are checked:
+
+- The public and private IPv4 and IPv6 addresses of Droplets
+- Reserved IPs, and the Droplets they are assigned to
+- Load balancer IP addresses
+- The public and private connection hosts of database clusters
+- Kubernetes cluster endpoints
+- The IP ranges of VPC networks
+
+Database and Kubernetes hostnames are resolved with your system's DNS resolver, so private hosts only match when the lookup is made from inside the VPC network.`,
+		Writer, displayerType(&displayers.IPOwners{}), fromFileOpt())
+	cmdWhois.Example = `The following example finds the resources that own the address ` + "`" + `10.114.0.7` + "`" + `: doctl network whois 10.114.0.7`
+
+	return cmd
+}
+
+// whoisFn finds the resources of one type that own ip.
+type whoisFn func(c *CmdConfig, ip net.IP) ([]displayers.IPOwner, error)
+
+var whoisFns = []whoisFn{
+	whoisDroplets,
+	whoisReservedIPs,
+	whoisLoadBalancers,
+	whoisDatabases,
+	whoisKubernetesClusters,
+	whoisVPCs,
+}
+
+// RunNetworkWhois finds the resources that own an IP address.
+func RunNetworkWhois(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(c.Args[0])
+	if ip == nil {
+		return fmt.Errorf("%q is not a valid IP address", c.Args[0])
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]displayers.IPOwner, len(whoisFns))
+		errs    = make([]error, len(whoisFns))
+	)
+	for i, fn := range whoisFns {
+		wg.Add(1)
+		go func(i int, fn whoisFn) {
+			defer wg.Done()
+			results[i], errs[i] = fn(c, ip)
+		}(i, fn)
+	}
+	wg.Wait()
+
+	var owners []displayers.IPOwner
+	for i := range whoisFns {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		owners = append(owners, results[i]...)
+	}
+
+	if len(owners) > 0 {
+		projects, err := projectsByURN(c)
+		if err != nil {
+			return err
+		}
+		for i := range owners {
+			owners[i].Project = projects[owners[i].URN]
+		}
+	}
+
+	return c.Display(&displayers.IPOwners{Owners: owners})
+}
+
+// projectsByURN maps the URN of every resource assigned to a project to the
+// project's name.
+func projectsByURN(c *CmdConfig) (map[string]string, error) {
+	ps := c.Projects()
+	projects, err := ps.List()
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]string{}
+	for _, p := range projects {
+		resources, err := ps.ListResources(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			out[r.URN] = p.Name
+			// Reserved IPs were once called floating IPs, and may still be
+			// listed under their old URN.
+			if rest, ok := strings.CutPrefix(r.URN, "do:floatingip:"); ok {
+				out["do:reservedip:"+rest] = p.Name
+			}
+		}
+	}
+	return out, nil
+}
+
+// hostsWithIP reports which of hosts, each of which may be an IP address, a
+// hostname, or a URL, resolve to ip. Hostnames are looked up concurrently
+// within hostLookupTimeout, and those that cannot be resolved are skipped with
+// a warning.
+func hostsWithIP(hosts []string, ip net.IP) map[string]bool {
+	ctx, cancel := context.WithTimeout(context.Background(), hostLookupTimeout)
+	defer cancel()
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		out = map[string]bool{}
+	)
+	seen := map[string]bool{}
+	for _, host := range hosts {
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+
+		name := host
+		if u, err := url.Parse(host); err == nil && u.Hostname() != "" {
+			name = u.Hostname()
+		}
+		if addr := net.ParseIP(name); addr != nil {
+			mu.Lock()
+			out[host] = addr.Equal(ip)
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(host, name string) {
+			defer wg.Done()
+			addrs, err := lookupHost(ctx, name)
+			if err != nil {
+				warn("Could not resolve %s: %v", name, err)
+				return
+			}
+			for _, a := range addrs {
+				if addr := net.ParseIP(a); addr != nil && addr.Equal(ip) {
+					mu.Lock()
+					out[host] = true
+					mu.Unlock()
+					return
+				}
+			}
+		}(host, name)
+	}
+	wg.Wait()
+	return out
+}
+
+func whoisDroplets(c *CmdConfig, ip net.IP) ([]displayers.IPOwner, error) {
+	droplets, err := c.Droplets().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []displayers.IPOwner
+	for _, d := range droplets {
+		if d.Networks == nil {
+			continue
+		}
+
+		var field string
+		for _, n := range d.Networks.V4 {
+			if net.ParseIP(n.IPAddress).Equal(ip) {
+				field = n.Type + "_ipv4"
+			}
+		}
+		for _, n := range d.Networks.V6 {
+			if net.ParseIP(n.IPAddress).Equal(ip) {
+				field = n.Type + "_ipv6"
+			}
+		}
+		if field == "" {
+			continue
+		}
+
+		owners = append(owners, displayers.IPOwner{
+			Type:  "droplet",
+			ID:    strconv.Itoa(d.ID),
+			Name:  d.Name,
+			URN:   d.URN(),
+			Field: field,
+			Tags:  d.Tags,
+		})
+	}
+	return owners, nil
+}
+
+func whoisReservedIPs(c *CmdConfig, ip net.IP) ([]displayers.IPOwner, error) {
+	ips, err := c.ReservedIPs().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []displayers.IPOwner
+	for _, r := range ips {
+		if !net.ParseIP(r.IP).Equal(ip) {
+			continue
+		}
+
+		owners = append(owners, displayers.IPOwner{
+			Type:  "reserved-ip",
+			ID:    r.IP,
+			Name:  r.IP,
+			URN:   r.URN(),
+			Field: "ip",
+		})
+
+		if d := r.Droplet; d != nil {
+			owners = append(owners, displayers.IPOwner{
+				Type:  "droplet",
+				ID:    strconv.Itoa(d.ID),
+				Name:  d.Name,
+				URN:   d.URN(),
+				Field: "reserved_ip",
+				Tags:  d.Tags,
+			})
+		}
+	}
+	return owners, nil
+}
+
+func whoisLoadBalancers(c *CmdConfig, ip net.IP) ([]displayers.IPOwner, error) {
+	lbs, err := c.LoadBalancers().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []displayers.IPOwner
+	for _, lb := range lbs {
+		if !net.ParseIP(lb.IP).Equal(ip) {
+			continue
+		}
+
+		owners = append(owners, displayers.IPOwner{
+			Type:  "load-balancer",
+			ID:    lb.ID,
+			Name:  lb.Name,
+			URN:   lb.URN(),
+			Field: "ip",
+			Tags:  lb.Tags,
+		})
+	}
+	return owners, nil
+}
+
+func whoisDatabases(c *CmdConfig, ip net.IP) ([]displayers.IPOwner, error) {
+	dbs, err := c.Databases().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, db := range dbs {
+		if db.Connection != nil {
+			hosts = append(hosts, db.Connection.Host)
+		}
+		if db.PrivateConnection != nil {
+			hosts = append(hosts, db.PrivateConnection.Host)
+		}
+	}
+	matches := hostsWithIP(hosts, ip)
+
+	var owners []displayers.IPOwner
+	for _, db := range dbs {
+		var field string
+		switch {
+		case db.Connection != nil && matches[db.Connection.Host]:
+			field = "host"
+		case db.PrivateConnection != nil && matches[db.PrivateConnection.Host]:
+			field = "private_host"
+		default:
+			continue
+		}
+
+		owners = append(owners, displayers.IPOwner{
+			Type:  "database",
+			ID:    db.ID,
+			Name:  db.Name,
+			URN:   db.URN(),
+			Field: field,
+			Tags:  db.Tags,
+		})
+	}
+	return owners, nil
+}
+
+func whoisKubernetesClusters(c *CmdConfig, ip net.IP) ([]displayers.IPOwner, error) {
+	clusters, err := c.Kubernetes().List()
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]string, 0, len(clusters))
+	for _, k := range clusters {
+		hosts = append(hosts, k.Endpoint)
+	}
+	matches := hostsWithIP(hosts, ip)
+
+	var owners []displayers.IPOwner
+	for _, k := range clusters {
+		var field string
+		switch {
+		case net.ParseIP(k.IPv4).Equal(ip):
+			field = "ipv4"
+		case matches[k.Endpoint]:
+			field = "endpoint"
+		default:
+			continue
+		}
+
+		owners = append(owners, displayers.IPOwner{
+			Type:  "kubernetes-cluster",
+			ID:    k.ID,
+			Name:  k.Name,
+			URN:   k.URN(),
+			Field: field,
+			Tags:  k.Tags,
+		})
+	}
+	return owners, nil
+}
+
+func whoisVPCs(c *CmdConfig, ip net.IP) ([]displayers.IPOwner, error) {
+	vpcs, err := c.VPCs().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []displayers.IPOwner
+	for _, v := range vpcs {
+		_, network, err := net.ParseCIDR(v.IPRange)
+		if err != nil || !network.Contains(ip) {
+			continue
+		}
+
+		owners = append(owners, displayers.IPOwner{
+			Type:  "vpc",
+			ID:    v.ID,
+			Name:  v.Name,
+			URN:   v.URN,
+			Field: "ip_range",
+		})
+	}
+	return owners, nil
+}
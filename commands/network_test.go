@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkCommand(t *testing.T) {
+	cmd := Network()
+	assert.NotNil(t, cmd)
+	assertCommandNames(t, cmd, "whois")
+}
+
+func TestRunNetworkWhois(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		origLookupHost := lookupHost
+		defer func() { lookupHost = origLookupHost }()
+		lookupHost = func(_ context.Context, host string) ([]string, error) {
+			if host == "private-db.example.com" {
+				return []string{"10.114.0.7"}, nil
+			}
+			return nil, errors.New("no such host")
+		}
+
+		droplet := do.Droplet{Droplet: &godo.Droplet{
+			ID:   5,
+			Name: "web-01",
+			Tags: []string{"web", "prod"},
+			Networks: &godo.Networks{
+				V4: []godo.NetworkV4{
+					{IPAddress: "203.0.113.5", Type: "public"},
+					{IPAddress: "10.114.0.7", Type: "private"},
+				},
+			},
+		}}
+		db := do.Database{Database: &godo.Database{
+			ID:                "db-1",
+			Name:              "api-prod",
+			Connection:        &godo.DatabaseConnection{Host: "db.example.com"},
+			PrivateConnection: &godo.DatabaseConnection{Host: "private-db.example.com"},
+		}}
+		vpc := do.VPC{VPC: &godo.VPC{ID: "vpc-1", URN: "do:vpc:vpc-1", Name: "default-nyc1", IPRange: "10.114.0.0/20"}}
+		project := do.Project{Project: &godo.Project{ID: "p-1", Name: "production"}}
+
+		tm.droplets.EXPECT().List().Return(do.Droplets{droplet}, nil)
+		tm.reservedIPs.EXPECT().List().Return(do.ReservedIPs{}, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{}, nil)
+		tm.databases.EXPECT().List().Return(do.Databases{db}, nil)
+		tm.kubernetes.EXPECT().List().Return(do.KubernetesClusters{}, nil)
+		tm.vpcs.EXPECT().List().Return(do.VPCs{vpc}, nil)
+		tm.projects.EXPECT().List().Return(do.Projects{project}, nil)
+		tm.projects.EXPECT().ListResources("p-1").Return(do.ProjectResources{
+			{ProjectResource: &godo.ProjectResource{URN: "do:droplet:5"}},
+			{ProjectResource: &godo.ProjectResource{URN: "do:dbaas:db-1"}},
+		}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "10.114.0.7")
+
+		err := RunNetworkWhois(config)
+		assert.NoError(t, err)
+
+		expected := "Type        ID       Name            Matched Field    Project       Tags        URN\n" +
+			"droplet     5        web-01          private_ipv4     production    web,prod    do:droplet:5\n" +
+			"database    db-1     api-prod        private_host     production                do:dbaas:db-1\n" +
+			"vpc         vpc-1    default-nyc1    ip_range                                   do:vpc:vpc-1\n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestRunNetworkWhoisReservedIP(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		rip := do.ReservedIP{ReservedIP: &godo.ReservedIP{
+			IP:      "198.51.100.9",
+			Droplet: &godo.Droplet{ID: 5, Name: "web-01"},
+		}}
+
+		tm.droplets.EXPECT().List().Return(do.Droplets{}, nil)
+		tm.reservedIPs.EXPECT().List().Return(do.ReservedIPs{rip}, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{}, nil)
+		tm.databases.EXPECT().List().Return(do.Databases{}, nil)
+		tm.kubernetes.EXPECT().List().Return(do.KubernetesClusters{}, nil)
+		tm.vpcs.EXPECT().List().Return(do.VPCs{}, nil)
+		tm.projects.EXPECT().List().Return(do.Projects{}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "198.51.100.9")
+
+		err := RunNetworkWhois(config)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "reserved-ip    198.51.100.9    198.51.100.9    ip")
+		assert.Contains(t, buf.String(), "droplet        5               web-01          reserved_ip")
+	})
+}
+
+func TestRunNetworkWhoisInvalidIP(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "api-prod")
+
+		err := RunNetworkWhois(config)
+		assert.EqualError(t, err, `"api-prod" is not a valid IP address`)
+	})
+}
+
+func TestHostsWithIP(t *testing.T) {
+	origLookupHost := lookupHost
+	defer func() { lookupHost = origLookupHost }()
+	lookupHost = func(_ context.Context, host string) ([]string, error) {
+		if host == "missing.example.com" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"192.0.2.10"}, nil
+	}
+
+	defer func(w io.Writer) { color.Output = w }(color.Output)
+	var buf bytes.Buffer
+	color.Output = &buf
+
+	ip := net.ParseIP("192.0.2.10")
+	got := hostsWithIP([]string{
+		"https://abc.k8s.ondigitalocean.com",
+		"192.0.2.10",
+		"192.0.2.11",
+		"missing.example.com",
+		"",
+	}, ip)
+	assert.Equal(t, map[string]bool{
+		"https://abc.k8s.ondigitalocean.com": true,
+		"192.0.2.10":                         true,
+		"192.0.2.11":                         false,
+	}, got)
+	assert.Contains(t, buf.String(), "Could not resolve missing.example.com: no such host")
+}
@@ -60,7 +60,7 @@ Projects allow you to organize your DigitalOcean resources (like Droplets, Space
 
 	cmdProjectsGet := CmdBuilder(cmd, RunProjectsGet, "get <id>", "Retrieve details for a specific project",
 		"Retrieves the following details for an existing project (use `default` as the <id> to retrieve details about your default project):"+projectDetails,
-		Writer, aliasOpt("g"), displayerType(&displayers.Project{}))
+		Writer, aliasOpt("g"), displayerType(&displayers.Project{}), fromFileOpt())
 	cmdProjectsGet.Example = `The following example retrieves details for a project with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl projects get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmdProjectsCreate := CmdBuilder(cmd, RunProjectsCreate, "create",
@@ -79,7 +79,7 @@ Projects allow you to organize your DigitalOcean resources (like Droplets, Space
 	cmdProjectsUpdate := CmdBuilder(cmd, RunProjectsUpdate, "update <id>",
 		"Update an existing project",
 		"Updates information about an existing project. Use `default` as the <id> to update your default project.",
-		Writer, aliasOpt("u"), displayerType(&displayers.Project{}))
+		Writer, aliasOpt("u"), displayerType(&displayers.Project{}), fromFileOpt())
 	AddStringFlag(cmdProjectsUpdate, doctl.ArgProjectName, "", "", "The project's name")
 	AddStringFlag(cmdProjectsUpdate, doctl.ArgProjectPurpose, "", "", "The project's purpose")
 	AddStringFlag(cmdProjectsUpdate, doctl.ArgProjectDescription, "", "",
@@ -92,9 +92,10 @@ Projects allow you to organize your DigitalOcean resources (like Droplets, Space
 
 	cmdProjectsDelete := CmdBuilder(cmd, RunProjectsDelete, "delete <id> [<id> ...]",
 		"Delete the specified project", "Deletes a project. To be deleted, a project must not have any resources assigned to it.",
-		Writer, aliasOpt("d", "rm"))
+		Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdProjectsDelete, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Deletes the project without confirmation")
+	addFanOutFlags(cmdProjectsDelete)
 	cmdProjectsDelete.Example = `The following example deletes the project with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl projects delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmd.AddCommand(ProjectResourcesCmd())
@@ -123,16 +124,16 @@ A valid URN has the format: ` + "`" + `do:resource_type:resource_id` + "`" + `.
 
 	CmdBuilder(cmd, RunProjectResourcesList, "list <project-id>", "List resources assigned to a project",
 		"List all of the resources assigned to the specified project displaying their uniform resource names (\"URNs\").",
-		Writer, aliasOpt("ls"), displayerType(&displayers.ProjectResource{}))
+		Writer, aliasOpt("ls"), displayerType(&displayers.ProjectResource{}), fromFileOpt())
 	CmdBuilder(cmd, RunProjectResourcesGet, "get <urn>", "Retrieve a resource by its URN",
 		"Retrieve information about a resource by specifying its uniform resource name (\"URN\"). Currently, only Droplets, floating IPs, load balancers, domains, volumes, and App Platform apps are supported."+urnDesc,
-		Writer, aliasOpt("g"), displayerType(&displayers.ProjectResource{}))
+		Writer, aliasOpt("g"), displayerType(&displayers.ProjectResource{}), fromFileOpt())
 
 	cmdProjectResourcesAssign := CmdBuilder(cmd, RunProjectResourcesAssign,
 		"assign <project-id> --resource=<urn> [--resource=<urn> ...]",
 		"Assign one or more resources to a project",
 		"Assign one or more resources to a project by specifying the resource's uniform resource name (\"URN\")."+urnDesc,
-		Writer, aliasOpt("a"))
+		Writer, aliasOpt("a"), fromFileOpt())
 	AddStringSliceFlag(cmdProjectResourcesAssign, doctl.ArgProjectResource, "",
 		[]string{}, "URNs specifying resources to assign to the project")
 
@@ -219,13 +220,7 @@ func RunProjectsDelete(c *CmdConfig) error {
 
 	ps := c.Projects()
 	if force || AskForConfirmDelete("project", len(c.Args)) == nil {
-		for _, id := range c.Args {
-			if err := ps.Delete(id); err != nil {
-				return err
-			}
-		}
-
-		return nil
+		return fanOut(c, c.Args, ps.Delete)
 	}
 
 	return fmt.Errorf("operation aborted")
@@ -270,15 +265,18 @@ func RunProjectResourcesGet(c *CmdConfig) error {
 	case "reservedip":
 		return RunReservedIPGet(c)
 	case "loadbalancer":
+		c.Args = []string{urn}
 		return RunLoadBalancerGet(c)
 	case "domain":
 		return RunDomainGet(c)
 	case "volume":
+		c.Args = []string{urn}
 		return RunVolumeGet(c)
 	case "kubernetes":
 		k8sCmdService := kubernetesCommandService()
 		return k8sCmdService.RunKubernetesClusterGet(c)
 	case "app":
+		c.Args = []string{urn}
 		return RunAppsGet(c)
 	default:
 		return fmt.Errorf("%q is an invalid resource type, consult the documentation", parts[1])
@@ -46,17 +46,17 @@ func ReservedIPAction() *Command {
 `
 	cmdReservedIPActionsGet := CmdBuilder(cmd, RunReservedIPActionsGet,
 		"get <reserved-ip> <action-id>", "Retrieve the status of a reserved IP action", `Retrieves the status of a reserved IP action. Outputs the following information:`+flipActionDetail, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	cmdReservedIPActionsGet.Example = `The following example retrieves the status of an action, that has the ID ` + "`" + `191669331` + "`" + `, that was taken on the reserved IP address ` + "`" + `203.0.113.25` + "`" + `: doctl compute reserved-ip-action get 203.0.113.25 191669331`
 
 	cmdReservedIPActionsAssign := CmdBuilder(cmd, RunReservedIPActionsAssign,
 		"assign <reserved-ip> <droplet-id>", "Assign a reserved IP address to a Droplet", "Assigns a reserved IP address to the specified Droplet.", Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	cmdReservedIPActionsAssign.Example = `The following example assigns the reserved IP address ` + "`" + `203.0.113.25` + "`" + ` to a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute reserved-ip-action assign 203.0.113.25 386734086`
 
 	cmdReservedIPActionsUnassign := CmdBuilder(cmd, RunReservedIPActionsUnassign,
 		"unassign <reserved-ip>", "Unassign a reserved IP address from a Droplet", `Unassigns a reserved IP address from a Droplet. Due to a shortage on IPv4 addresses, unassigned reserved IP addresses remain available on your account but accumulate charges for not being assigned.`, Writer,
-		displayerType(&displayers.Action{}))
+		displayerType(&displayers.Action{}), fromFileOpt())
 	cmdReservedIPActionsUnassign.Example = `The following example unassigns the reserved IP address ` + "`" + `203.0.113.25` + "`" + ` from a resource: doctl compute reserved-ip-action unassign 203.0.113.25`
 
 	return cmd
@@ -52,10 +52,10 @@ Reserved IP addresses can either be assigned to Droplets or held in the region t
 	cmdReservedIPCreate.Example = `The following example creates a reserved IP address in the ` + "`" + `nyc1` + "`" + ` region and assigns it to a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute reserved-ip create --region nyc1 --droplet-id 386734086`
 
 	cmdReservedIPGet := CmdBuilder(cmd, RunReservedIPGet, "get <reserved-ip>", "Retrieve information about a reserved IP address", "Retrieves detailed information about a reserved IP address, including its region and the ID of the Droplet its assigned to.", Writer,
-		aliasOpt("g"), displayerType(&displayers.ReservedIP{}))
+		aliasOpt("g"), displayerType(&displayers.ReservedIP{}), fromFileOpt())
 	cmdReservedIPGet.Example = `The following example retrieves information about the reserved IP address ` + "`" + `203.0.113.25` + "`" + `: doctl compute reserved-ip get 203.0.113.25`
 
-	cmdRunReservedIPDelete := CmdBuilder(cmd, RunReservedIPDelete, "delete <reserved-ip>", "Permanently delete a reserved IP address", "Permanently deletes a reserved IP address. This is irreversible.", Writer, aliasOpt("d", "rm"))
+	cmdRunReservedIPDelete := CmdBuilder(cmd, RunReservedIPDelete, "delete <reserved-ip>", "Permanently delete a reserved IP address", "Permanently deletes a reserved IP address. This is irreversible.", Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunReservedIPDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Deletes the reserved IP address without confirmation")
 	cmdRunReservedIPDelete.Example = `The following example deletes the reserved IP address ` + "`" + `203.0.113.25` + "`" + `: doctl compute reserved-ip delete 203.0.113.25`
 
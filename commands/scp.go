@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/ssh"
+)
+
+// SCP creates the scp command.
+func SCP(parent *Command) *Command {
+	usr, err := user.Current()
+	checkErr(err)
+
+	path := filepath.Join(usr.HomeDir, ".ssh", "id_rsa")
+
+	scpDesc := fmt.Sprintf(`Copies files to or from a Droplet, using its ID or name. One of the source and the destination is a path on a Droplet, written as `+"`"+`[user@]droplet:path`+"`"+`, and the other is a local path.
+
+Like `+"`"+`doctl compute ssh`+"`"+`, the copy uses the Droplet's public IP address unless the `+"`"+`--%s`+"`"+` flag is set, and logs in as root, or as core on Fedora CoreOS, unless a user is given.
+
+To upload to every Droplet with a tag, pass the tag with the `+"`"+`--%s`+"`"+` flag and write the destination as `+"`"+`:path`+"`"+`. Up to `+"`"+`--%s`+"`"+` Droplets are copied to at a time, and a summary of the result for each Droplet is displayed at the end.
+
+Unlike `+"`"+`doctl compute ssh`+"`"+`, the copy always uses doctl's native SSH client rather than the `+"`"+`ssh`+"`"+` or `+"`"+`scp`+"`"+` binaries on your PATH, so it has no `+"`"+`--ssh-client`+"`"+` flag and your `+"`"+`~/.ssh/config`+"`"+` does not apply. Keys are read from your SSH agent and from the `+"`"+`--ssh-key-path`+"`"+` file, and host keys are checked against `+"`"+`~/.ssh/known_hosts`+"`"+`.
+
+The Droplet needs the scp program, which is included with OpenSSH.`,
+		doctl.ArgsSSHPrivateIP, doctl.ArgTagName, doctl.ArgSSHParallel)
+
+	cmdSCP := CmdBuilder(parent, RunSCP, "scp <source> <destination>", "Copy files to or from a Droplet", scpDesc, Writer)
+	AddStringFlag(cmdSCP, doctl.ArgSSHUser, "", "", "SSH user for connection. Defaults to the user for the Droplet's image")
+	AddStringFlag(cmdSCP, doctl.ArgsSSHKeyPath, "", path, "Path to SSH private key")
+	AddIntFlag(cmdSCP, doctl.ArgsSSHPort, "", 22, "The remote port sshd is running on")
+	AddBoolFlag(cmdSCP, doctl.ArgsSSHPrivateIP, "", false, "Connect to the Droplet's private IP address")
+	AddIntFlag(cmdSCP, doctl.ArgSSHRetryMax, "", 0, "Max number of retries for a successful SSH connection to a Droplet (default is 0)")
+	AddStringFlag(cmdSCP, doctl.ArgSSHHostKeyChecking, "", "", "How host keys are checked against ~/.ssh/known_hosts: ask, accept-new, yes or no. Defaults to ask, and to accept-new with --tag-name")
+	AddBoolFlag(cmdSCP, doctl.ArgSCPRecursive, "r", false, "Copy directories and their contents")
+	AddBoolFlag(cmdSCP, doctl.ArgSCPProgress, "", true, "Display the progress of each file. Progress is not displayed when copying to a tag")
+	AddStringFlag(cmdSCP, doctl.ArgTagName, "", "", "Upload to every Droplet with this tag")
+	AddIntFlag(cmdSCP, doctl.ArgSSHParallel, "", 10, "The maximum number of Droplets to upload to at the same time when using --tag-name")
+	cmdSCP.Example = `The following example uploads a file to a Droplet named ` + "`" + `web-1` + "`" + `: doctl compute scp ./app.tar.gz web-1:/tmp/
+
+The following example downloads a directory from a Droplet: doctl compute scp -r web-1:/var/log/nginx ./logs
+
+The following example uploads a file to every Droplet tagged ` + "`" + `web` + "`" + `: doctl compute scp --tag-name web ./app.conf :/etc/app/`
+
+	return cmdSCP
+}
+
+// scpRemote is a path on a Droplet, written as [user@]droplet:path.
+type scpRemote struct {
+	user    string
+	droplet string
+	path    string
+}
+
+// parseSCPRemote parses an scp argument as a path on a Droplet. Arguments
+// without a colon, or with a slash before the first colon, are local paths.
+func parseSCPRemote(arg string) (scpRemote, bool) {
+	if filepath.VolumeName(arg) != "" {
+		return scpRemote{}, false
+	}
+	host, path, ok := strings.Cut(arg, ":")
+	if !ok || strings.ContainsAny(host, `/\`) {
+		return scpRemote{}, false
+	}
+	r := scpRemote{droplet: host, path: path}
+	if u, d, ok := strings.Cut(host, "@"); ok {
+		r.user, r.droplet = u, d
+	}
+	return r, true
+}
+
+// RunSCP copies files to or from a Droplet, or uploads them to every Droplet
+// with a tag.
+func RunSCP(c *CmdConfig) error {
+	if len(c.Args) != 2 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	tagName, err := c.Doit.GetString(c.NS, doctl.ArgTagName)
+	if err != nil {
+		return err
+	}
+
+	src, srcRemote := parseSCPRemote(c.Args[0])
+	dst, dstRemote := parseSCPRemote(c.Args[1])
+	switch {
+	case srcRemote && dstRemote:
+		return errors.New("copying between two Droplets is not supported; one of the source and the destination must be a local path")
+	case !srcRemote && !dstRemote:
+		return errors.New("one of the source and the destination must be a path on a Droplet, written as [user@]droplet:path")
+	case tagName != "" && (srcRemote || dst.droplet != ""):
+		return fmt.Errorf("with --%s, the destination must be a path written as :path", doctl.ArgTagName)
+	case tagName == "" && (src.droplet == "" && srcRemote || dst.droplet == "" && dstRemote):
+		return fmt.Errorf("a path written as :path needs --%s", doctl.ArgTagName)
+	}
+
+	upload := dstRemote
+	remote, local := dst, c.Args[0]
+	if !upload {
+		remote, local = src, c.Args[1]
+	}
+
+	user, err := c.Doit.GetString(c.NS, doctl.ArgSSHUser)
+	if err != nil {
+		return err
+	}
+	if remote.user != "" {
+		user = remote.user
+	}
+	keyPath, err := sshKeyPath(c)
+	if err != nil {
+		return err
+	}
+	port, err := c.Doit.GetInt(c.NS, doctl.ArgsSSHPort)
+	if err != nil {
+		return err
+	}
+	privateIP, err := c.Doit.GetBool(c.NS, doctl.ArgsSSHPrivateIP)
+	if err != nil {
+		return err
+	}
+	progress, err := c.Doit.GetBool(c.NS, doctl.ArgSCPProgress)
+	if err != nil {
+		return err
+	}
+
+	opts := make(ssh.Options)
+	opts[doctl.ArgSSHRetryMax], err = c.Doit.GetInt(c.NS, doctl.ArgSSHRetryMax)
+	if err != nil {
+		return err
+	}
+	opts[doctl.ArgSSHHostKeyChecking], err = c.Doit.GetString(c.NS, doctl.ArgSSHHostKeyChecking)
+	if err != nil {
+		return err
+	}
+	opts[doctl.ArgSCPRecursive], err = c.Doit.GetBool(c.NS, doctl.ArgSCPRecursive)
+	if err != nil {
+		return err
+	}
+	opts[ssh.OptionSCPUpload] = upload
+	opts[ssh.OptionSCPLocalPath] = local
+	opts[ssh.OptionSCPRemotePath] = remote.path
+
+	if tagName != "" {
+		return runSCPTag(c, tagName, user, keyPath, port, privateIP, opts)
+	}
+
+	ds := c.Droplets()
+	id, err := do.ResolveDropletID(ds, remote.droplet)
+	if err != nil {
+		return err
+	}
+	droplet, err := ds.Get(id)
+	if err != nil {
+		return err
+	}
+	if user == "" {
+		user = defaultSSHUser(droplet)
+	}
+	ip, err := privateIPElsePub(droplet, privateIP)
+	if err != nil {
+		return err
+	}
+	if ip == "" {
+		return errors.New("Could not find Droplet address")
+	}
+
+	if progress {
+		opts[ssh.OptionSCPProgress] = os.Stderr
+	}
+	return c.Doit.SCP(user, ip, keyPath, port, opts).Run()
+}
+
+// runSCPTag uploads to every Droplet with a tag, at most --parallel at a
+// time, and displays the result for each Droplet.
+func runSCPTag(c *CmdConfig, tagName, user, keyPath string, port int, privateIP bool, opts ssh.Options) error {
+	hostKeyChecking, err := tagHostKeyChecking(opts)
+	if err != nil {
+		return err
+	}
+	opts[doctl.ArgSSHHostKeyChecking] = hostKeyChecking
+	parallel, err := c.Doit.GetInt(c.NS, doctl.ArgSSHParallel)
+	if err != nil {
+		return err
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	droplets, err := c.Droplets().ListByTag(tagName)
+	if err != nil {
+		return err
+	}
+	if len(droplets) == 0 {
+		return fmt.Errorf("no Droplets have the tag %q", tagName)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, parallel)
+		results = make([]displayers.ItemResult, len(droplets))
+	)
+	for i := range droplets {
+		d := &droplets[i]
+		results[i] = displayers.ItemResult{ID: d.Name, Status: itemStatusFailed}
+
+		hostUser := user
+		if hostUser == "" {
+			hostUser = defaultSSHUser(d)
+		}
+		ip, err := privateIPElsePub(d, privateIP)
+		if err == nil && ip == "" {
+			err = errors.New("could not find Droplet address")
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := c.Doit.SCP(hostUser, ip, keyPath, port, opts).Run(); err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Status = itemStatusOK
+		}()
+	}
+	wg.Wait()
+
+	if err := c.Display(&displayers.ItemResults{Results: results}); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Status != itemStatusOK {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("the copy failed on %d of %d Droplets", failed, len(droplets))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/pkg/runner"
+	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCPUpload(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(testDropletList, nil)
+		tm.droplets.EXPECT().Get(testDroplet.ID).Return(&testDroplet, nil)
+
+		called := false
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SCPFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			called = true
+			assert.Equal(t, "root", user)
+			assert.Equal(t, "8.8.8.8", host)
+			assert.Equal(t, true, opts[ssh.OptionSCPUpload])
+			assert.Equal(t, "./app.tar.gz", opts[ssh.OptionSCPLocalPath])
+			assert.Equal(t, "/tmp/", opts[ssh.OptionSCPRemotePath])
+			assert.Equal(t, true, opts[doctl.ArgSCPRecursive])
+			return sshRunnerFunc(func() error { return nil })
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgSCPRecursive, true)
+		config.Args = append(config.Args, "./app.tar.gz", testDroplet.Name+":/tmp/")
+
+		err := RunSCP(config)
+		require.NoError(t, err)
+		assert.True(t, called)
+	})
+}
+
+func TestSCPDownload(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().Get(testDroplet.ID).Return(&testDroplet, nil)
+
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SCPFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Equal(t, "deploy", user)
+			assert.Equal(t, "172.16.1.2", host)
+			assert.Equal(t, false, opts[ssh.OptionSCPUpload])
+			assert.Equal(t, "logs", opts[ssh.OptionSCPLocalPath])
+			assert.Equal(t, "/var/log/nginx", opts[ssh.OptionSCPRemotePath])
+			return sshRunnerFunc(func() error { return nil })
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgsSSHPrivateIP, true)
+		config.Args = append(config.Args, "deploy@"+strconv.Itoa(testDroplet.ID)+":/var/log/nginx", "logs")
+
+		err := RunSCP(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSCPTag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var buf bytes.Buffer
+		config.Out = &buf
+
+		tm.droplets.EXPECT().ListByTag("web").Return(testDropletList, nil)
+
+		var (
+			mu    sync.Mutex
+			hosts []string
+		)
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SCPFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			mu.Lock()
+			hosts = append(hosts, host)
+			mu.Unlock()
+			assert.Equal(t, "/etc/app/", opts[ssh.OptionSCPRemotePath])
+			assert.Nil(t, opts[ssh.OptionSCPProgress])
+			assert.Equal(t, ssh.HostKeyCheckingAcceptNew, opts[doctl.ArgSSHHostKeyChecking])
+			return sshRunnerFunc(func() error {
+				if host == "8.8.8.9" {
+					return errors.New("scp: /etc/app/: Permission denied")
+				}
+				return nil
+			})
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgSCPProgress, true)
+		config.Args = append(config.Args, "./app.conf", ":/etc/app/")
+
+		err := RunSCP(config)
+		assert.EqualError(t, err, "the copy failed on 1 of 2 Droplets")
+		assert.ElementsMatch(t, []string{"8.8.8.8", "8.8.8.9"}, hosts)
+		assert.Regexp(t, regexp.MustCompile(`a-droplet\s+ok`), buf.String())
+		assert.Regexp(t, regexp.MustCompile(`another-droplet\s+failed\s+scp: /etc/app/: Permission denied`), buf.String())
+	})
+}
+
+func TestSCPArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		tag  string
+		err  string
+	}{
+		{name: "both remote", args: []string{"a:/x", "b:/y"}, err: "copying between two Droplets is not supported; one of the source and the destination must be a local path"},
+		{name: "both local", args: []string{"./x", "/y"}, err: "one of the source and the destination must be a path on a Droplet, written as [user@]droplet:path"},
+		{name: "slash before colon", args: []string{"./a:b", "/y"}, err: "one of the source and the destination must be a path on a Droplet, written as [user@]droplet:path"},
+		{name: "tag download", args: []string{":/x", "./y"}, tag: "web", err: "with --tag-name, the destination must be a path written as :path"},
+		{name: "tag with droplet", args: []string{"./x", "web-1:/y"}, tag: "web", err: "with --tag-name, the destination must be a path written as :path"},
+		{name: "no droplet", args: []string{"./x", ":/y"}, err: "a path written as :path needs --tag-name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+				config.Doit.Set(config.NS, doctl.ArgTagName, tt.tag)
+				config.Args = append(config.Args, tt.args...)
+				assert.EqualError(t, RunSCP(config), tt.err)
+			})
+		})
+	}
+}
@@ -0,0 +1,425 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+)
+
+const (
+	searchTypeDroplet      = "droplet"
+	searchTypeReservedIP   = "reserved-ip"
+	searchTypeLoadBalancer = "load-balancer"
+	searchTypeDatabase     = "database"
+	searchTypeKubernetes   = "kubernetes-cluster"
+	searchTypeNodePool     = "node-pool"
+	searchTypeVolume       = "volume"
+	searchTypeDomain       = "domain"
+	searchTypeDomainRecord = "domain-record"
+	searchTypeApp          = "app"
+	searchTypeVPC          = "vpc"
+)
+
+// searchTypes lists the resource types that can be searched, in the order
+// their results are displayed.
+var searchTypes = []string{
+	searchTypeDroplet,
+	searchTypeReservedIP,
+	searchTypeLoadBalancer,
+	searchTypeDatabase,
+	searchTypeKubernetes,
+	searchTypeNodePool,
+	searchTypeVolume,
+	searchTypeDomain,
+	searchTypeDomainRecord,
+	searchTypeApp,
+	searchTypeVPC,
+}
+
+// Search creates the search command.
+func Search() *Command {
+	cmd := CmdBuilder(nil, RunSearch, "search <term>", "Find resources by name, IP address, or hostname",
+		`Searches Droplets, reserved IPs, load balancers, database clusters, Kubernetes clusters and node pools, volumes, domains and their records, apps, and VPCs for the given term, and lists every resource that matches.
+
+If the term is an IP address, it matches resources with exactly that address, as well as VPCs whose IP range contains it. Otherwise, it matches any name, hostname, or address that contains the term, ignoring case.
+
+Each match is listed with its type, ID, URN, and the field that matched. Resources that do not have a URN, such as node pools and domain records, are listed without one.`,
+		Writer, displayerType(&displayers.SearchResults{}))
+	cmd.GroupID = manageResourcesGroup
+	AddStringSliceFlag(cmd, doctl.ArgSearchType, "", []string{},
+		"Only search the given resource types. Possible values: "+strings.Join(searchTypes, ", "))
+	cmd.Example = `The following example finds every resource with the IP address ` + "`" + `10.114.0.7` + "`" + `: doctl search 10.114.0.7
+
+The following example finds the Droplets and load balancers whose names contain ` + "`" + `api-prod` + "`" + `: doctl search api-prod --type droplet,load-balancer`
+
+	return cmd
+}
+
+// searchMatcher decides whether a field of a resource matches a search term.
+type searchMatcher struct {
+	term string
+	ip   net.IP
+}
+
+func newSearchMatcher(term string) *searchMatcher {
+	return &searchMatcher{
+		term: strings.ToLower(term),
+		ip:   net.ParseIP(term),
+	}
+}
+
+// match reports whether value matches the term. IP address terms only match
+// the same address, or a CIDR range containing it, so that searching for
+// 10.0.0.1 does not also find 10.0.0.10.
+func (m *searchMatcher) match(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	if m.ip != nil {
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.Equal(m.ip)
+		}
+		if _, network, err := net.ParseCIDR(value); err == nil {
+			return network.Contains(m.ip)
+		}
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(value), m.term)
+}
+
+// searchField is a named value of a resource that can match a search term.
+type searchField struct {
+	name  string
+	value string
+}
+
+// add appends r to results, recording the first of fields that matches the
+// term. It does nothing when none of them match.
+func (m *searchMatcher) add(results []displayers.SearchResult, r displayers.SearchResult, fields ...searchField) []displayers.SearchResult {
+	for _, f := range fields {
+		if m.match(f.value) {
+			r.Field = f.name
+			r.Value = f.value
+			return append(results, r)
+		}
+	}
+	return results
+}
+
+// searchFn searches the resources of one type.
+type searchFn func(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error)
+
+var searchFns = map[string]searchFn{
+	searchTypeDroplet:      searchDroplets,
+	searchTypeReservedIP:   searchReservedIPs,
+	searchTypeLoadBalancer: searchLoadBalancers,
+	searchTypeDatabase:     searchDatabases,
+	searchTypeKubernetes:   searchKubernetesClusters,
+	searchTypeNodePool:     searchNodePools,
+	searchTypeVolume:       searchVolumes,
+	searchTypeDomain:       searchDomains,
+	searchTypeDomainRecord: searchDomainRecords,
+	searchTypeApp:          searchApps,
+	searchTypeVPC:          searchVPCs,
+}
+
+// RunSearch searches all supported resource types for a term.
+func RunSearch(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+
+	types, err := c.Doit.GetStringSlice(c.NS, doctl.ArgSearchType)
+	if err != nil {
+		return err
+	}
+	if len(types) == 0 {
+		types = searchTypes
+	}
+	for _, t := range types {
+		if _, ok := searchFns[t]; !ok {
+			return fmt.Errorf("%q is not a searchable resource type; use one of: %s", t, strings.Join(searchTypes, ", "))
+		}
+	}
+
+	m := newSearchMatcher(c.Args[0])
+
+	var (
+		wg      sync.WaitGroup
+		results = make(map[string][]displayers.SearchResult, len(types))
+		errs    = make(map[string]error)
+		mu      sync.Mutex
+	)
+	for _, t := range types {
+		wg.Add(1)
+		go func(t string) {
+			defer wg.Done()
+			res, err := searchFns[t](c, m)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[t] = err
+				return
+			}
+			results[t] = res
+		}(t)
+	}
+	wg.Wait()
+
+	if len(errs) == len(types) {
+		return fmt.Errorf("search failed: %v", errs[types[0]])
+	}
+
+	var out []displayers.SearchResult
+	for _, t := range searchTypes {
+		if err, ok := errs[t]; ok {
+			warn("Could not search %ss: %v", t, err)
+			continue
+		}
+		out = append(out, results[t]...)
+	}
+
+	return c.Display(&displayers.SearchResults{Results: out})
+}
+
+func searchDroplets(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	droplets, err := c.Droplets().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, d := range droplets {
+		publicIPv4, _ := d.PublicIPv4()
+		privateIPv4, _ := d.PrivateIPv4()
+		publicIPv6, _ := d.PublicIPv6()
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeDroplet, ID: strconv.Itoa(d.ID), Name: d.Name, URN: d.URN()},
+			searchField{"name", d.Name},
+			searchField{"public_ipv4", publicIPv4},
+			searchField{"private_ipv4", privateIPv4},
+			searchField{"public_ipv6", publicIPv6},
+		)
+	}
+	return results, nil
+}
+
+func searchReservedIPs(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	ips, err := c.ReservedIPs().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, ip := range ips {
+		var name string
+		if ip.Droplet != nil {
+			name = ip.Droplet.Name
+		}
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeReservedIP, ID: ip.IP, Name: name, URN: ip.URN()},
+			searchField{"ip", ip.IP},
+		)
+	}
+	return results, nil
+}
+
+func searchLoadBalancers(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	lbs, err := c.LoadBalancers().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, lb := range lbs {
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeLoadBalancer, ID: lb.ID, Name: lb.Name, URN: lb.URN()},
+			searchField{"name", lb.Name},
+			searchField{"ip", lb.IP},
+		)
+	}
+	return results, nil
+}
+
+func searchDatabases(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	dbs, err := c.Databases().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, db := range dbs {
+		var host, privateHost string
+		if db.Connection != nil {
+			host = db.Connection.Host
+		}
+		if db.PrivateConnection != nil {
+			privateHost = db.PrivateConnection.Host
+		}
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeDatabase, ID: db.ID, Name: db.Name, URN: db.URN()},
+			searchField{"name", db.Name},
+			searchField{"host", host},
+			searchField{"private_host", privateHost},
+		)
+	}
+	return results, nil
+}
+
+func searchKubernetesClusters(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	clusters, err := c.Kubernetes().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, k := range clusters {
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeKubernetes, ID: k.ID, Name: k.Name, URN: k.URN()},
+			searchField{"name", k.Name},
+			searchField{"ip", k.IPv4},
+			searchField{"endpoint", k.Endpoint},
+		)
+	}
+	return results, nil
+}
+
+func searchNodePools(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	clusters, err := c.Kubernetes().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, k := range clusters {
+		for _, p := range k.NodePools {
+			results = m.add(results,
+				displayers.SearchResult{Type: searchTypeNodePool, ID: p.ID, Name: p.Name},
+				searchField{"name", p.Name},
+			)
+		}
+	}
+	return results, nil
+}
+
+func searchVolumes(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	volumes, err := c.Volumes().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, v := range volumes {
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeVolume, ID: v.ID, Name: v.Name, URN: v.URN()},
+			searchField{"name", v.Name},
+		)
+	}
+	return results, nil
+}
+
+func searchDomains(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	domains, err := c.Domains().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, d := range domains {
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeDomain, ID: d.Name, Name: d.Name, URN: d.URN()},
+			searchField{"name", d.Name},
+		)
+	}
+	return results, nil
+}
+
+func searchDomainRecords(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	ds := c.Domains()
+	domains, err := ds.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, d := range domains {
+		records, err := ds.Records(d.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range records {
+			fqdn := d.Name
+			if r.Name != "@" {
+				fqdn = r.Name + "." + d.Name
+			}
+			results = m.add(results,
+				displayers.SearchResult{Type: searchTypeDomainRecord, ID: strconv.Itoa(r.ID), Name: fqdn},
+				searchField{"name", fqdn},
+				searchField{"data", r.Data},
+			)
+		}
+	}
+	return results, nil
+}
+
+func searchApps(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	apps, err := c.Apps().List(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, a := range apps {
+		var name string
+		if a.Spec != nil {
+			name = a.Spec.Name
+		}
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeApp, ID: a.ID, Name: name, URN: a.URN()},
+			searchField{"name", name},
+			searchField{"default_ingress", a.DefaultIngress},
+			searchField{"live_domain", a.LiveDomain},
+		)
+	}
+	return results, nil
+}
+
+func searchVPCs(c *CmdConfig, m *searchMatcher) ([]displayers.SearchResult, error) {
+	vpcs, err := c.VPCs().List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []displayers.SearchResult
+	for _, v := range vpcs {
+		results = m.add(results,
+			displayers.SearchResult{Type: searchTypeVPC, ID: v.ID, Name: v.Name, URN: v.URN},
+			searchField{"name", v.Name},
+			searchField{"ip_range", v.IPRange},
+		)
+	}
+	return results, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchCommand(t *testing.T) {
+	cmd := Search()
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "search", cmd.Name())
+}
+
+func TestSearchMatcher(t *testing.T) {
+	tests := []struct {
+		term  string
+		value string
+		want  bool
+	}{
+		{term: "api-prod", value: "API-Prod-01", want: true},
+		{term: "api-prod", value: "api-staging", want: false},
+		{term: "10.114.0.7", value: "10.114.0.7", want: true},
+		{term: "10.114.0.7", value: "10.114.0.70", want: false},
+		{term: "10.114.0.7", value: "10.114.0.0/20", want: true},
+		{term: "10.114.0.7", value: "10.116.0.0/20", want: false},
+		{term: "10.114.0.7", value: "db-10-114-0-7.example.com", want: false},
+		{term: "anything", value: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term+"/"+tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, newSearchMatcher(tt.term).match(tt.value))
+		})
+	}
+}
+
+func TestRunSearch(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		vpc := do.VPC{VPC: &godo.VPC{ID: "vpc-1", URN: "do:vpc:vpc-1", Name: "default-test0", IPRange: "172.16.0.0/20"}}
+		lb := do.LoadBalancer{LoadBalancer: &godo.LoadBalancer{ID: "lb-1", Name: "web", IP: "172.16.1.20"}}
+
+		tm.droplets.EXPECT().List().Return(testDropletList, nil)
+		tm.loadBalancers.EXPECT().List().Return(do.LoadBalancers{lb}, nil)
+		tm.vpcs.EXPECT().List().Return(do.VPCs{vpc}, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "172.16.1.2")
+		config.Doit.Set(config.NS, doctl.ArgSearchType, []string{"vpc", "droplet", "load-balancer"})
+
+		err := RunSearch(config)
+		assert.NoError(t, err)
+
+		expected := `Type       ID       Name             Matched Field    Matched Value    URN
+droplet    1        a-droplet        private_ipv4     172.16.1.2       do:droplet:1
+vpc        vpc-1    default-test0    ip_range         172.16.0.0/20    do:vpc:vpc-1
+`
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestRunSearchDomainRecords(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		domain := do.Domain{Domain: &godo.Domain{Name: "example.com"}}
+		records := do.DomainRecords{
+			{DomainRecord: &godo.DomainRecord{ID: 1, Type: "A", Name: "@", Data: "10.114.0.7"}},
+			{DomainRecord: &godo.DomainRecord{ID: 2, Type: "A", Name: "api-prod", Data: "10.114.0.8"}},
+		}
+
+		tm.domains.EXPECT().List().Return(do.Domains{domain}, nil)
+		tm.domains.EXPECT().Records("example.com").Return(records, nil)
+
+		var buf bytes.Buffer
+		config.Out = &buf
+		config.Args = append(config.Args, "api-prod")
+		config.Doit.Set(config.NS, doctl.ArgSearchType, []string{"domain-record"})
+
+		err := RunSearch(config)
+		assert.NoError(t, err)
+
+		expected := "Type             ID    Name                    Matched Field    Matched Value           URN\n" +
+			"domain-record    2     api-prod.example.com    name             api-prod.example.com    \n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestRunSearchAllTypesFail(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.volumes.EXPECT().List().Return(nil, errors.New("forbidden"))
+
+		config.Args = append(config.Args, "data")
+		config.Doit.Set(config.NS, doctl.ArgSearchType, []string{"volume"})
+
+		err := RunSearch(config)
+		assert.EqualError(t, err, "search failed: forbidden")
+	})
+}
+
+func TestRunSearchInvalidType(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "data")
+		config.Doit.Set(config.NS, doctl.ArgSearchType, []string{"bucket"})
+
+		err := RunSearch(config)
+		assert.ErrorContains(t, err, `"bucket" is not a searchable resource type`)
+	})
+}
+
+func TestSearchResultsDisplayerJSON(t *testing.T) {
+	var buf bytes.Buffer
+	r := &displayers.SearchResults{Results: []displayers.SearchResult{
+		{Type: "volume", ID: "v-1", Name: "data", Field: "name", Value: "data"},
+	}}
+
+	assert.NoError(t, r.JSON(&buf))
+	assert.JSONEq(t, `[{"type":"volume","id":"v-1","name":"data","field":"name","value":"data"}]`, buf.String())
+}
@@ -54,13 +54,14 @@ func Snapshot() *Command {
 
 	cmdSnapshotGet := CmdBuilder(cmd, RunSnapshotGet, "get <snapshot-id>...",
 		"Retrieve a Droplet or volume snapshot", "Retrieves information about a Droplet or block storage volume snapshot, including:"+snapshotDetail,
-		Writer, aliasOpt("g"), displayerType(&displayers.Snapshot{}))
+		Writer, aliasOpt("g"), displayerType(&displayers.Snapshot{}), fromFileOpt())
 	cmdSnapshotGet.Example = `The following example retrieves information about a Droplet snapshot with ID ` + "`" + `386734086` + "`" + `: doctl compute snapshot get 386734086`
 
 	cmdRunSnapshotDelete := CmdBuilder(cmd, RunSnapshotDelete, "delete <snapshot-id>...",
 		"Delete a snapshot of a Droplet or volume", "Deletes the specified snapshot or volume. This is irreversible.",
-		Writer, aliasOpt("d", "rm"), displayerType(&displayers.Snapshot{}))
+		Writer, aliasOpt("d", "rm"), displayerType(&displayers.Snapshot{}), fromFileOpt())
 	AddBoolFlag(cmdRunSnapshotDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete the snapshot without confirmation")
+	addFanOutFlags(cmdRunSnapshotDelete)
 	cmdRunSnapshotDelete.Example = `The following example deletes a Droplet snapshot with ID ` + "`" + `386734086` + "`" + `: doctl compute snapshot delete 386734086`
 
 	return cmd
@@ -184,14 +185,7 @@ func RunSnapshotDelete(c *CmdConfig) error {
 	ids := c.Args
 
 	if force || AskForConfirmDelete("snapshot", len(ids)) == nil {
-		for _, id := range ids {
-			err := ss.Delete(id)
-			if err != nil {
-				return err
-			}
-		}
-	} else {
-		return errOperationAborted
+		return fanOut(c, ids, ss.Delete)
 	}
-	return nil
+	return errOperationAborted
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/digitalocean/doctl"
 	"github.com/digitalocean/doctl/do"
 	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -40,10 +41,14 @@ func SSH(parent *Command) *Command {
 
 	sshDesc := fmt.Sprintf(`Access a Droplet using SSH by providing its ID or name.
 
-You may specify the user to login with by passing the `+"`"+`--%s`+"`"+` flag. To access the Droplet on a non-default port, use the `+"`"+`--%s`+"`"+` flag. By default, the connection will be made to the Droplet's public IP address. In order access it using its private IP address, use the `+"`"+`--%s`+"`"+` flag.
-`, doctl.ArgSSHUser, doctl.ArgsSSHPort, doctl.ArgsSSHPrivateIP)
+You may specify the user to login with by passing the `+"`"+`--%s`+"`"+` flag. To access the Droplet on a non-default port, use the `+"`"+`--%s`+"`"+` flag. By default, the connection will be made to the Droplet's public IP address. In order access it using its private IP address, use the `+"`"+`--%s`+"`"+` flag. To reach a Droplet that only has a private IP address, connect through another Droplet in the same VPC network with the `+"`"+`--%s`+"`"+` flag.
 
-	cmdSSH := CmdBuilder(parent, RunSSH, "ssh <droplet-id|name>", "Access a Droplet using SSH", sshDesc, Writer)
+To run a command on every Droplet with a tag, pass the tag with the `+"`"+`--%s`+"`"+` flag instead of a Droplet, along with the `+"`"+`--%s`+"`"+` flag. The command runs on up to `+"`"+`--%s`+"`"+` Droplets at a time, each line of output is prefixed with the name of its Droplet, and a summary of the exit status on each Droplet is displayed at the end.
+
+The connection is made by running the `+"`"+`ssh`+"`"+` binary on your PATH, so your `+"`"+`~/.ssh/config`+"`"+` applies. Pass `+"`"+`--%s native`+"`"+` to connect without an `+"`"+`ssh`+"`"+` binary instead; the native client does not read `+"`"+`~/.ssh/config`+"`"+`. The native client is also used when there is no `+"`"+`ssh`+"`"+` binary on your PATH. When running a command on every Droplet with a tag, the keys of new hosts are added to `+"`"+`~/.ssh/known_hosts`+"`"+` without asking unless `+"`"+`--%s`+"`"+` is set.
+`, doctl.ArgSSHUser, doctl.ArgsSSHPort, doctl.ArgsSSHPrivateIP, doctl.ArgSSHJump, doctl.ArgTagName, doctl.ArgSSHCommand, doctl.ArgSSHParallel, doctl.ArgSSHClient, doctl.ArgSSHHostKeyChecking)
+
+	cmdSSH := CmdBuilder(parent, RunSSH, "ssh <droplet-id|name>", "Access a Droplet using SSH", sshDesc, Writer, fromFileOpt())
 	AddStringFlag(cmdSSH, doctl.ArgSSHUser, "", "root", "SSH user for connection")
 	AddStringFlag(cmdSSH, doctl.ArgsSSHKeyPath, "", path, "Path to SSH private key")
 	AddIntFlag(cmdSSH, doctl.ArgsSSHPort, "", 22, "The remote port sshd is running on")
@@ -51,19 +56,28 @@ You may specify the user to login with by passing the `+"`"+`--%s`+"`"+` flag. T
 	AddBoolFlag(cmdSSH, doctl.ArgsSSHPrivateIP, "", false, "SSH to Droplet's private IP address")
 	AddStringFlag(cmdSSH, doctl.ArgSSHCommand, "", "", "Command to execute on Droplet")
 	AddIntFlag(cmdSSH, doctl.ArgSSHRetryMax, "", 0, "Max number of retries for a successful SSH connection to a Droplet (default is 0)")
+	AddStringFlag(cmdSSH, doctl.ArgTagName, "", "", "Run --ssh-command on every Droplet with this tag")
+	AddIntFlag(cmdSSH, doctl.ArgSSHParallel, "", 10, "The maximum number of Droplets to run --ssh-command on at the same time when using --tag-name")
+	AddStringFlag(cmdSSH, doctl.ArgSSHClient, "", "", "The SSH implementation to use: exec runs the ssh binary on your PATH, which reads ~/.ssh/config; native connects without an ssh binary and does not read ~/.ssh/config. Defaults to exec, or to native if there is no ssh binary on your PATH")
+	AddStringFlag(cmdSSH, doctl.ArgSSHHostKeyChecking, "", "", "How host keys are checked against ~/.ssh/known_hosts: ask, accept-new, yes or no. Defaults to your ssh configuration when the ssh binary is run, to ask for the native client, and to accept-new with --tag-name")
+
+	AddStringFlag(cmdSSH, doctl.ArgSSHJump, "", "", "A Droplet, as [user@]droplet[:port], to connect through to reach the Droplet's private IP address")
+
+	sshTunnel(cmdSSH)
 
 	return cmdSSH
 }
 
 // RunSSH finds a droplet to ssh to given input parameters (name or id).
 func RunSSH(c *CmdConfig) error {
-	if len(c.Args) == 0 {
-		return doctl.NewMissingArgsErr(c.NS)
+	tagName, err := c.Doit.GetString(c.NS, doctl.ArgTagName)
+	if err != nil {
+		return err
 	}
-
-	dropletID := c.Args[0]
-
-	if dropletID == "" {
+	if tagName != "" && len(c.Args) > 0 {
+		return fmt.Errorf("specify either a Droplet or --%s, not both", doctl.ArgTagName)
+	}
+	if tagName == "" && (len(c.Args) == 0 || c.Args[0] == "") {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
@@ -72,7 +86,7 @@ func RunSSH(c *CmdConfig) error {
 		return err
 	}
 
-	keyPath, err := c.Doit.GetString(c.NS, doctl.ArgsSSHKeyPath)
+	keyPath, err := sshKeyPath(c)
 	if err != nil {
 		return err
 	}
@@ -98,11 +112,31 @@ func RunSSH(c *CmdConfig) error {
 		return nil
 	}
 
+	opts[doctl.ArgSSHClient], err = c.Doit.GetString(c.NS, doctl.ArgSSHClient)
+	if err != nil {
+		return err
+	}
+	switch opts[doctl.ArgSSHClient] {
+	case "", ssh.ClientNative, ssh.ClientExec:
+	default:
+		return fmt.Errorf("--%s %q is not valid; valid values are native and exec", doctl.ArgSSHClient, opts[doctl.ArgSSHClient])
+	}
+
+	opts[doctl.ArgSSHHostKeyChecking], err = c.Doit.GetString(c.NS, doctl.ArgSSHHostKeyChecking)
+	if err != nil {
+		return err
+	}
+
 	privateIPChoice, err := c.Doit.GetBool(c.NS, doctl.ArgsSSHPrivateIP)
 	if err != nil {
 		return err
 	}
 
+	if tagName != "" {
+		return runSSHTag(c, tagName, user, keyPath, port, privateIPChoice, opts)
+	}
+
+	dropletID := c.Args[0]
 	var droplet *do.Droplet
 
 	ds := c.Droplets()
@@ -153,6 +187,19 @@ func RunSSH(c *CmdConfig) error {
 		user = defaultSSHUser(droplet)
 	}
 
+	jumpRef, err := c.Doit.GetString(c.NS, doctl.ArgSSHJump)
+	if err != nil {
+		return err
+	}
+	if jumpRef != "" {
+		jump, ip, err := resolveSSHJump(c, jumpRef, droplet, port)
+		if err != nil {
+			return err
+		}
+		opts[ssh.OptionJump] = jump
+		return c.Doit.SSH(user, ip, keyPath, port, opts).Run()
+	}
+
 	ip, err := privateIPElsePub(droplet, privateIPChoice)
 	if err != nil {
 		return err
@@ -166,6 +213,31 @@ func RunSSH(c *CmdConfig) error {
 	return runner.Run()
 }
 
+// sshDefaultKeyPath returns the default for the --ssh-key-path flag.
+func sshDefaultKeyPath() string {
+	usr, err := user.Current()
+	checkErr(err)
+
+	return filepath.Join(usr.HomeDir, ".ssh", "id_rsa")
+}
+
+// sshKeyPath returns the --ssh-key-path flag. When the flag is not given on
+// the command line or in the config file, the key set as the default for the
+// current context by ssh-key create-local is used instead.
+func sshKeyPath(c *CmdConfig) (string, error) {
+	keyPath, err := c.Doit.GetString(c.NS, doctl.ArgsSSHKeyPath)
+	if err != nil {
+		return "", err
+	}
+	if c.Doit.IsSet(doctl.ArgsSSHKeyPath) || viper.InConfig(c.NS+"."+doctl.ArgsSSHKeyPath) {
+		return keyPath, nil
+	}
+	if path := viper.GetStringMapString(sshKeyPathsConfigKey)[currentContext()]; path != "" {
+		return path, nil
+	}
+	return keyPath, nil
+}
+
 func defaultSSHUser(droplet *do.Droplet) string {
 	slug := strings.ToLower(droplet.Image.Slug)
 	if strings.Contains(slug, "coreos") {
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/fatih/color"
+)
+
+// runSSHTag runs the ssh command on every Droplet with a tag, at most
+// --parallel at a time. Each line of output is prefixed with the Droplet's
+// name, and the exit status on every Droplet is displayed at the end.
+func runSSHTag(c *CmdConfig, tagName, user, keyPath string, port int, privateIP bool, opts ssh.Options) error {
+	if opts[doctl.ArgSSHCommand] == "" {
+		return fmt.Errorf("--%s requires --%s", doctl.ArgTagName, doctl.ArgSSHCommand)
+	}
+	hostKeyChecking, err := tagHostKeyChecking(opts)
+	if err != nil {
+		return err
+	}
+	opts[doctl.ArgSSHHostKeyChecking] = hostKeyChecking
+	parallel, err := c.Doit.GetInt(c.NS, doctl.ArgSSHParallel)
+	if err != nil {
+		return err
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	droplets, err := c.Droplets().ListByTag(tagName)
+	if err != nil {
+		return err
+	}
+	if len(droplets) == 0 {
+		return fmt.Errorf("no Droplets have the tag %q", tagName)
+	}
+
+	width := 0
+	for _, d := range droplets {
+		width = max(width, len(d.Name))
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		sem     = make(chan struct{}, parallel)
+		results = make([]displayers.SSHResult, len(droplets))
+	)
+	for i := range droplets {
+		d := &droplets[i]
+		results[i] = displayers.SSHResult{Droplet: d.Name, ExitStatus: -1}
+
+		hostUser := user
+		if hostUser == "" {
+			hostUser = defaultSSHUser(d)
+		}
+		ip, err := privateIPElsePub(d, privateIP)
+		if err == nil && ip == "" {
+			err = errors.New("could not find Droplet address")
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Host = ip
+
+		prefix := fmt.Sprintf("%-*s | ", width, d.Name)
+		stdout := &linePrefixWriter{mu: &mu, w: c.Out, prefix: prefix}
+		stderr := &linePrefixWriter{mu: &mu, w: color.Error, prefix: prefix}
+		hostOpts := maps.Clone(opts)
+		hostOpts[ssh.OptionStdin] = strings.NewReader("")
+		hostOpts[ssh.OptionStdout] = stdout
+		hostOpts[ssh.OptionStderr] = stderr
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := c.Doit.SSH(hostUser, ip, keyPath, port, hostOpts).Run()
+			stdout.Flush()
+			stderr.Flush()
+			results[i].ExitStatus = ssh.ExitStatus(err)
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := c.Display(&displayers.SSHResults{Results: results}); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.ExitStatus != 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("the command failed on %d of %d Droplets", failed, len(droplets))
+	}
+	return nil
+}
+
+// tagHostKeyChecking returns the host key checking policy for connecting to
+// every Droplet with a tag. The keys of new hosts cannot be confirmed while
+// several Droplets are connected to at once, so they are accepted by
+// default, and ask is refused.
+func tagHostKeyChecking(opts ssh.Options) (string, error) {
+	switch policy, _ := opts[doctl.ArgSSHHostKeyChecking].(string); policy {
+	case "":
+		return ssh.HostKeyCheckingAcceptNew, nil
+	case ssh.HostKeyCheckingAsk:
+		return "", fmt.Errorf("--%s ask cannot be used with --%s, since new host keys cannot be confirmed for several Droplets at once; use accept-new, yes or no", doctl.ArgSSHHostKeyChecking, doctl.ArgTagName)
+	default:
+		return policy, nil
+	}
+}
+
+// linePrefixWriter writes complete lines to w, each preceded by prefix.
+// Writers that share mu never interleave their lines.
+type linePrefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (l *linePrefixWriter) Write(p []byte) (int, error) {
+	l.buf = append(l.buf, p...)
+	i := bytes.LastIndexByte(l.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	lines := l.buf[:i+1]
+	l.buf = append([]byte(nil), l.buf[i+1:]...)
+	if err := l.writeLines(lines); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line if it does not end with a newline.
+func (l *linePrefixWriter) Flush() error {
+	if len(l.buf) == 0 {
+		return nil
+	}
+	lines := append(l.buf, '\n')
+	l.buf = nil
+	return l.writeLines(lines)
+}
+
+func (l *linePrefixWriter) writeLines(lines []byte) error {
+	var b bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 0 {
+			b.WriteString(l.prefix)
+			b.Write(line)
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := l.w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/pkg/runner"
+	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sshRunnerFunc func() error
+
+func (f sshRunnerFunc) Run() error { return f() }
+
+func TestSSH_Tag(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		var buf bytes.Buffer
+		config.Out = &buf
+
+		tm.droplets.EXPECT().ListByTag("web").Return(testDropletList, nil)
+
+		var (
+			mu    sync.Mutex
+			hosts []string
+		)
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			mu.Lock()
+			hosts = append(hosts, host)
+			mu.Unlock()
+			assert.Equal(t, "uptime", opts[doctl.ArgSSHCommand])
+			stdout := opts[ssh.OptionStdout].(io.Writer)
+			return sshRunnerFunc(func() error {
+				if host == "8.8.8.9" {
+					io.WriteString(stdout, "partial")
+					return errors.New("connection refused")
+				}
+				io.WriteString(stdout, "up 1 day\nload 0.1\n")
+				return nil
+			})
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgSSHCommand, "uptime")
+		config.Doit.Set(config.NS, doctl.ArgSSHParallel, 2)
+
+		err := RunSSH(config)
+		assert.EqualError(t, err, "the command failed on 1 of 2 Droplets")
+		assert.ElementsMatch(t, []string{"8.8.8.8", "8.8.8.9"}, hosts)
+
+		out := buf.String()
+		assert.Contains(t, out, "a-droplet       | up 1 day\na-droplet       | load 0.1\n")
+		assert.Contains(t, out, "another-droplet | partial\n")
+		assert.Regexp(t, regexp.MustCompile(`a-droplet\s+8\.8\.8\.8\s+0\s*\n`), out)
+		assert.Regexp(t, regexp.MustCompile(`another-droplet\s+8\.8\.8\.9\s+-1\s+connection refused`), out)
+	})
+}
+
+func TestSSH_TagPrivateIP(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().ListByTag("web").Return(testDropletList, nil)
+
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Contains(t, []string{"172.16.1.2", "172.16.1.4"}, host)
+			return sshRunnerFunc(func() error { return nil })
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgSSHCommand, "uptime")
+		config.Doit.Set(config.NS, doctl.ArgsSSHPrivateIP, true)
+
+		err := RunSSH(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSSH_TagUnknownHosts(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().ListByTag("web").Return(testDropletList, nil)
+
+		// New host keys cannot be confirmed on stdin, so they are accepted.
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Equal(t, ssh.HostKeyCheckingAcceptNew, opts[doctl.ArgSSHHostKeyChecking])
+			return sshRunnerFunc(func() error { return nil })
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+		config.Doit.Set(config.NS, doctl.ArgSSHCommand, "uptime")
+
+		err := RunSSH(config)
+		assert.NoError(t, err)
+
+		config.Doit.Set(config.NS, doctl.ArgSSHHostKeyChecking, "ask")
+		err = RunSSH(config)
+		assert.EqualError(t, err, "--ssh-host-key-checking ask cannot be used with --tag-name, since new host keys cannot be confirmed for several Droplets at once; use accept-new, yes or no")
+	})
+}
+
+func TestSSH_TagErrors(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgTagName, "web")
+
+		err := RunSSH(config)
+		assert.EqualError(t, err, "--tag-name requires --ssh-command")
+
+		config.Args = append(config.Args, testDroplet.Name)
+		err = RunSSH(config)
+		assert.EqualError(t, err, "specify either a Droplet or --tag-name, not both")
+	})
+}
+
+func TestLinePrefixWriter(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		mu  sync.Mutex
+	)
+	w := &linePrefixWriter{mu: &mu, w: &buf, prefix: "web | "}
+	w.Write([]byte("one\ntw"))
+	w.Write([]byte("o\nthree"))
+	assert.Equal(t, "web | one\nweb | two\n", buf.String())
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "web | one\nweb | two\nweb | three\n", buf.String())
+}
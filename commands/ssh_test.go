@@ -34,7 +34,7 @@ func TestSSHCommand(t *testing.T) {
 	}
 	cmd := SSH(parent)
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd)
+	assertCommandNames(t, cmd, "tunnel")
 }
 
 func TestSSH_ID(t *testing.T) {
@@ -156,6 +156,37 @@ func TestSSH_CommandExecuting(t *testing.T) {
 	})
 }
 
+func TestSSH_Client(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.sshRunner.EXPECT().Run().Return(nil)
+
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			assert.Equal(t, ssh.ClientExec, opts[doctl.ArgSSHClient])
+			assert.Equal(t, ssh.HostKeyCheckingAcceptNew, opts[doctl.ArgSSHHostKeyChecking])
+			return tm.sshRunner
+		}
+
+		tm.droplets.EXPECT().List().Return(testDropletList, nil)
+		config.Doit.Set(config.NS, doctl.ArgSSHClient, "exec")
+		config.Doit.Set(config.NS, doctl.ArgSSHHostKeyChecking, "accept-new")
+		config.Args = append(config.Args, testDroplet.Name)
+
+		err := RunSSH(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSSH_InvalidClient(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgSSHClient, "putty")
+		config.Args = append(config.Args, testDroplet.Name)
+
+		err := RunSSH(config)
+		assert.EqualError(t, err, `--ssh-client "putty" is not valid; valid values are native and exec`)
+	})
+}
+
 func Test_extractHostInfo(t *testing.T) {
 	cases := []struct {
 		s string
@@ -0,0 +1,305 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/ssh"
+)
+
+// sshTunnel creates the ssh tunnel command.
+func sshTunnel(parent *Command) *Command {
+	cmdTunnel := CmdBuilder(parent, RunSSHTunnel, "tunnel <droplet-id|name>", "Forward local ports through a Droplet",
+		`Forwards local ports to hosts that a Droplet can reach, such as database clusters and other services that only accept connections from inside a VPC network. Each `+"`"+`--local-forward`+"`"+` is written like the `+"`"+`-L`+"`"+` option of ssh, as `+"`"+`[bind_address:]port:host:hostport`+"`"+`, and the ports are forwarded until the command is stopped.
+
+To reach a Droplet that only has a private IP address, use the `+"`"+`--jump`+"`"+` flag to connect through another Droplet in the same VPC network.`, Writer, fromFileOpt())
+	AddStringSliceFlag(cmdTunnel, doctl.ArgSSHLocalForward, "L", nil, "A port to forward, as [bind_address:]port:host:hostport", requiredOpt())
+	addSSHConnectionFlags(cmdTunnel, sshDefaultKeyPath())
+	AddBoolFlag(cmdTunnel, doctl.ArgsSSHPrivateIP, "", false, "Connect to the Droplet's private IP address")
+	AddStringFlag(cmdTunnel, doctl.ArgSSHJump, "", "", "A Droplet, as [user@]droplet[:port], to connect through to reach the Droplet's private IP address")
+	cmdTunnel.Example = `The following example forwards local port 5432 to port 25060 of a database cluster through a Droplet named ` + "`" + `bastion` + "`" + `: doctl compute ssh tunnel bastion -L 5432:private-db-postgresql-nyc3-12345-do-user-1-0.b.db.ondigitalocean.com:25060`
+
+	return cmdTunnel
+}
+
+// addSSHConnectionFlags adds the flags for how to connect to a Droplet that
+// are shared by the commands that connect through one.
+func addSSHConnectionFlags(cmd *Command, keyPath string) {
+	AddStringFlag(cmd, doctl.ArgSSHUser, "", "", "SSH user for connection. Defaults to the user for the Droplet's image")
+	AddStringFlag(cmd, doctl.ArgsSSHKeyPath, "", keyPath, "Path to SSH private key")
+	AddIntFlag(cmd, doctl.ArgsSSHPort, "", 22, "The remote port sshd is running on")
+	AddIntFlag(cmd, doctl.ArgSSHRetryMax, "", 0, "Max number of retries for a successful SSH connection to a Droplet (default is 0)")
+	AddStringFlag(cmd, doctl.ArgSSHClient, "", "", "The SSH implementation to use: exec runs the ssh binary on your PATH, which reads ~/.ssh/config; native connects without an ssh binary and does not read ~/.ssh/config. Defaults to exec, or to native if there is no ssh binary on your PATH")
+	AddStringFlag(cmd, doctl.ArgSSHHostKeyChecking, "", "", "How host keys are checked against ~/.ssh/known_hosts: ask, accept-new, yes or no. Defaults to your ssh configuration when the ssh binary is run, and to ask for the native client")
+}
+
+// sshConnection is how to connect to a Droplet, read from the flags added by
+// addSSHConnectionFlags.
+type sshConnection struct {
+	user    string
+	keyPath string
+	port    int
+	opts    ssh.Options
+}
+
+func readSSHConnectionFlags(c *CmdConfig) (*sshConnection, error) {
+	var (
+		conn = &sshConnection{opts: make(ssh.Options)}
+		err  error
+	)
+	if conn.user, err = c.Doit.GetString(c.NS, doctl.ArgSSHUser); err != nil {
+		return nil, err
+	}
+	if conn.keyPath, err = sshKeyPath(c); err != nil {
+		return nil, err
+	}
+	if conn.port, err = c.Doit.GetInt(c.NS, doctl.ArgsSSHPort); err != nil {
+		return nil, err
+	}
+	if conn.opts[doctl.ArgSSHRetryMax], err = c.Doit.GetInt(c.NS, doctl.ArgSSHRetryMax); err != nil {
+		return nil, err
+	}
+	if conn.opts[doctl.ArgSSHClient], err = c.Doit.GetString(c.NS, doctl.ArgSSHClient); err != nil {
+		return nil, err
+	}
+	switch conn.opts[doctl.ArgSSHClient] {
+	case "", ssh.ClientNative, ssh.ClientExec:
+	default:
+		return nil, fmt.Errorf("--%s %q is not valid; valid values are native and exec", doctl.ArgSSHClient, conn.opts[doctl.ArgSSHClient])
+	}
+	if conn.opts[doctl.ArgSSHHostKeyChecking], err = c.Doit.GetString(c.NS, doctl.ArgSSHHostKeyChecking); err != nil {
+		return nil, err
+	}
+	conn.opts[doctl.ArgsSSHAgentForwarding] = false
+	conn.opts[doctl.ArgSSHCommand] = ""
+	return conn, nil
+}
+
+// RunSSHTunnel forwards local ports through a Droplet.
+func RunSSHTunnel(c *CmdConfig) error {
+	if len(c.Args) == 0 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	specs, err := c.Doit.GetStringSlice(c.NS, doctl.ArgSSHLocalForward)
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 {
+		return fmt.Errorf("at least one --%s is required", doctl.ArgSSHLocalForward)
+	}
+	forwards := make([]ssh.Forward, 0, len(specs))
+	for _, spec := range specs {
+		f, err := ssh.ParseForward(spec)
+		if err != nil {
+			return err
+		}
+		forwards = append(forwards, f)
+	}
+	privateIP, err := c.Doit.GetBool(c.NS, doctl.ArgsSSHPrivateIP)
+	if err != nil {
+		return err
+	}
+	jumpRef, err := c.Doit.GetString(c.NS, doctl.ArgSSHJump)
+	if err != nil {
+		return err
+	}
+	conn, err := readSSHConnectionFlags(c)
+	if err != nil {
+		return err
+	}
+
+	ds := c.Droplets()
+	id, err := do.ResolveDropletID(ds, c.Args[0])
+	if err != nil {
+		return err
+	}
+	droplet, err := ds.Get(id)
+	if err != nil {
+		return err
+	}
+
+	var ip string
+	if jumpRef != "" {
+		jump, targetIP, err := resolveSSHJump(c, jumpRef, droplet, conn.port)
+		if err != nil {
+			return err
+		}
+		conn.opts[ssh.OptionJump], ip = jump, targetIP
+	} else {
+		ip, err = privateIPElsePub(droplet, privateIP)
+		if err != nil {
+			return err
+		}
+		if ip == "" {
+			return errors.New("Could not find Droplet address")
+		}
+	}
+
+	conn.opts[ssh.OptionLocalForwards] = forwards
+	for _, f := range forwards {
+		notice("Forwarding %s to %s through Droplet %s", f.LocalAddr(), f.RemoteAddr(), droplet.Name)
+	}
+	notice("Press Ctrl-C to stop")
+	return c.Doit.SSH(sshUser(conn.user, droplet), ip, conn.keyPath, conn.port, conn.opts).Run()
+}
+
+// resolveSSHJump resolves a --jump flag, written as [user@]droplet[:port],
+// to a jump host for reaching target. The jump host is reached at its public
+// address, and target at its private address, so both Droplets must be in
+// the same VPC network.
+func resolveSSHJump(c *CmdConfig, ref string, target *do.Droplet, port int) (*ssh.JumpHost, string, error) {
+	info := extractHostInfo(ref)
+	ds := c.Droplets()
+	id, err := do.ResolveDropletID(ds, info.host)
+	if err != nil {
+		return nil, "", err
+	}
+	bastion, err := ds.Get(id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if bastion.ID == target.ID {
+		return nil, "", fmt.Errorf("--%s must be a different Droplet than %s", doctl.ArgSSHJump, target.Name)
+	}
+	if bastion.VPCUUID != target.VPCUUID {
+		return nil, "", fmt.Errorf("the jump host %s is not in the same VPC network as %s", bastion.Name, target.Name)
+	}
+	bastionIP, err := bastion.PublicIPv4()
+	if err != nil {
+		return nil, "", err
+	}
+	if bastionIP == "" {
+		return nil, "", fmt.Errorf("the jump host %s has no public IP address", bastion.Name)
+	}
+	targetIP, err := target.PrivateIPv4()
+	if err != nil {
+		return nil, "", err
+	}
+	if targetIP == "" {
+		return nil, "", fmt.Errorf("%s has no private IP address to reach through the jump host", target.Name)
+	}
+
+	if info.port != "" {
+		if port, err = strconv.Atoi(info.port); err != nil {
+			return nil, "", fmt.Errorf("invalid port in --%s %q", doctl.ArgSSHJump, ref)
+		}
+	}
+	return &ssh.JumpHost{User: sshUser(info.user, bastion), Host: bastionIP, Port: port}, targetIP, nil
+}
+
+// sshUser returns user, or the default user for the Droplet's image if user
+// is empty.
+func sshUser(user string, droplet *do.Droplet) string {
+	if user == "" {
+		return defaultSSHUser(droplet)
+	}
+	return user
+}
+
+// RunDatabaseTunnel forwards a local port to the private address of a
+// database cluster through a Droplet in the cluster's VPC network.
+func RunDatabaseTunnel(c *CmdConfig) error {
+	if len(c.Args) == 0 {
+		return doctl.NewMissingArgsErr(c.NS)
+	}
+	dropletRef, err := c.Doit.GetString(c.NS, doctl.ArgDatabaseTunnelDroplet)
+	if err != nil {
+		return err
+	}
+	localPort, err := c.Doit.GetInt(c.NS, doctl.ArgDatabaseTunnelLocalPort)
+	if err != nil {
+		return err
+	}
+	conn, err := readSSHConnectionFlags(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := databaseClusterID(c)
+	if err != nil {
+		return err
+	}
+	db, err := c.Databases().Get(id)
+	if err != nil {
+		return err
+	}
+	private, err := c.Databases().GetConnection(id, true)
+	if err != nil {
+		return err
+	}
+	if private.DatabaseConnection == nil || private.Host == "" {
+		return fmt.Errorf("database cluster %s has no private connection", db.Name)
+	}
+
+	droplet, err := databaseTunnelDroplet(c, db, dropletRef)
+	if err != nil {
+		return err
+	}
+	ip, err := droplet.PublicIPv4()
+	if err != nil {
+		return err
+	}
+
+	if localPort == 0 {
+		localPort = private.Port
+	}
+	f := ssh.Forward{BindAddress: "localhost", Port: localPort, Host: private.Host, HostPort: private.Port}
+	conn.opts[ssh.OptionLocalForwards] = []ssh.Forward{f}
+
+	notice("Forwarding %s to database cluster %s at %s through Droplet %s", f.LocalAddr(), db.Name, f.RemoteAddr(), droplet.Name)
+	notice("Connect to %s with the credentials from `doctl databases connection %s`. Press Ctrl-C to stop", f.LocalAddr(), db.ID)
+	return c.Doit.SSH(sshUser(conn.user, droplet), ip, conn.keyPath, conn.port, conn.opts).Run()
+}
+
+// databaseTunnelDroplet returns the Droplet to tunnel to a database cluster
+// through: the --droplet flag if it is set, or otherwise the first Droplet
+// with a public IP address in the cluster's VPC network.
+func databaseTunnelDroplet(c *CmdConfig, db *do.Database, ref string) (*do.Droplet, error) {
+	ds := c.Droplets()
+	if ref != "" {
+		id, err := do.ResolveDropletID(ds, ref)
+		if err != nil {
+			return nil, err
+		}
+		d, err := ds.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if d.VPCUUID != db.PrivateNetworkUUID {
+			return nil, fmt.Errorf("Droplet %s is not in the VPC network of database cluster %s", d.Name, db.Name)
+		}
+		if ip, _ := d.PublicIPv4(); ip == "" {
+			return nil, fmt.Errorf("Droplet %s has no public IP address", d.Name)
+		}
+		return d, nil
+	}
+
+	droplets, err := ds.List()
+	if err != nil {
+		return nil, err
+	}
+	for i, d := range droplets {
+		if ip, _ := d.PublicIPv4(); d.VPCUUID == db.PrivateNetworkUUID && ip != "" {
+			return &droplets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no Droplet with a public IP address is in the VPC network of database cluster %s; create one, or choose one with --%s",
+		db.Name, doctl.ArgDatabaseTunnelDroplet)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/pkg/runner"
+	"github.com/digitalocean/doctl/pkg/ssh"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func vpcDroplet(id int, name, vpc string, networks ...godo.NetworkV4) do.Droplet {
+	return do.Droplet{Droplet: &godo.Droplet{
+		ID:       id,
+		Name:     name,
+		VPCUUID:  vpc,
+		Image:    &godo.Image{Slug: "ubuntu-22-04-x64"},
+		Networks: &godo.Networks{V4: networks},
+	}}
+}
+
+var (
+	testBastion = vpcDroplet(10, "bastion", "vpc-1",
+		godo.NetworkV4{IPAddress: "203.0.113.10", Type: "public"},
+		godo.NetworkV4{IPAddress: "10.0.0.10", Type: "private"})
+	testPrivateOnly = vpcDroplet(11, "app-1", "vpc-1",
+		godo.NetworkV4{IPAddress: "10.0.0.11", Type: "private"})
+	testOtherVPC = vpcDroplet(12, "other", "vpc-2",
+		godo.NetworkV4{IPAddress: "203.0.113.12", Type: "public"},
+		godo.NetworkV4{IPAddress: "10.1.0.12", Type: "private"})
+)
+
+func TestSSHTunnel(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().List().Return(do.Droplets{testBastion, testPrivateOnly}, nil)
+		tm.droplets.EXPECT().Get(testBastion.ID).Return(&testBastion, nil)
+
+		called := false
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			called = true
+			assert.Equal(t, "root", user)
+			assert.Equal(t, "203.0.113.10", host)
+			assert.Nil(t, opts[ssh.OptionJump])
+			assert.Equal(t, []ssh.Forward{
+				{BindAddress: "localhost", Port: 5432, Host: "db.internal", HostPort: 25060},
+				{BindAddress: "0.0.0.0", Port: 8080, Host: "10.0.0.11", HostPort: 80},
+			}, opts[ssh.OptionLocalForwards])
+			return sshRunnerFunc(func() error { return nil })
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgSSHLocalForward, []string{"5432:db.internal:25060", "0.0.0.0:8080:10.0.0.11:80"})
+		config.Args = append(config.Args, "bastion")
+
+		require.NoError(t, RunSSHTunnel(config))
+		assert.True(t, called)
+	})
+}
+
+func TestSSHTunnelInvalidForward(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgSSHLocalForward, []string{"5432:db.internal"})
+		config.Args = append(config.Args, "bastion")
+
+		err := RunSSHTunnel(config)
+		assert.EqualError(t, err, `invalid forward "5432:db.internal"; use [bind_address:]port:host:hostport`)
+	})
+}
+
+func TestSSHJump(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().Get(testPrivateOnly.ID).Return(&testPrivateOnly, nil)
+		tm.droplets.EXPECT().List().Return(do.Droplets{testBastion, testPrivateOnly}, nil)
+		tm.droplets.EXPECT().Get(testBastion.ID).Return(&testBastion, nil)
+
+		called := false
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			called = true
+			assert.Equal(t, "10.0.0.11", host)
+			assert.Equal(t, &ssh.JumpHost{User: "admin", Host: "203.0.113.10", Port: 2222}, opts[ssh.OptionJump])
+			return sshRunnerFunc(func() error { return nil })
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgSSHJump, "admin@bastion:2222")
+		config.Args = append(config.Args, "11")
+
+		require.NoError(t, RunSSH(config))
+		assert.True(t, called)
+	})
+}
+
+func TestSSHJumpErrors(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.droplets.EXPECT().Get(testPrivateOnly.ID).Return(&testPrivateOnly, nil)
+		tm.droplets.EXPECT().Get(testOtherVPC.ID).Return(&testOtherVPC, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgSSHLocalForward, []string{"5432:db.internal:25060"})
+		config.Doit.Set(config.NS, doctl.ArgSSHJump, "12")
+		config.Args = append(config.Args, "11")
+
+		err := RunSSHTunnel(config)
+		assert.EqualError(t, err, "the jump host other is not in the same VPC network as app-1")
+	})
+}
+
+func TestDatabaseTunnel(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		db := do.Database{Database: &godo.Database{ID: testDBCluster.ID, Name: "sunny-db-cluster", PrivateNetworkUUID: "vpc-1"}}
+		private := do.DatabaseConnection{DatabaseConnection: &godo.DatabaseConnection{Host: "private-db.internal", Port: 25060}}
+		tm.databases.EXPECT().Get(db.ID).Return(&db, nil)
+		tm.databases.EXPECT().GetConnection(db.ID, true).Return(&private, nil)
+		tm.droplets.EXPECT().List().Return(do.Droplets{testOtherVPC, testPrivateOnly, testBastion}, nil)
+
+		called := false
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.SSHFn = func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+			called = true
+			assert.Equal(t, "203.0.113.10", host)
+			assert.Equal(t, []ssh.Forward{
+				{BindAddress: "localhost", Port: 15432, Host: "private-db.internal", HostPort: 25060},
+			}, opts[ssh.OptionLocalForwards])
+			return sshRunnerFunc(func() error { return nil })
+		}
+
+		config.Doit.Set(config.NS, doctl.ArgDatabaseTunnelLocalPort, 15432)
+		config.Args = append(config.Args, db.ID)
+
+		require.NoError(t, RunDatabaseTunnel(config))
+		assert.True(t, called)
+	})
+}
+
+func TestDatabaseTunnelNoDroplet(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		db := do.Database{Database: &godo.Database{ID: testDBCluster.ID, Name: "sunny-db-cluster", PrivateNetworkUUID: "vpc-1"}}
+		private := do.DatabaseConnection{DatabaseConnection: &godo.DatabaseConnection{Host: "private-db.internal", Port: 25060}}
+		tm.databases.EXPECT().Get(db.ID).Return(&db, nil)
+		tm.databases.EXPECT().GetConnection(db.ID, true).Return(&private, nil)
+		tm.droplets.EXPECT().Get(testOtherVPC.ID).Return(&testOtherVPC, nil)
+
+		config.Doit.Set(config.NS, doctl.ArgDatabaseTunnelDroplet, "12")
+		config.Args = append(config.Args, db.ID)
+
+		err := RunDatabaseTunnel(config)
+		assert.EqualError(t, err, "Droplet other is not in the VPC network of database cluster sunny-db-cluster")
+	})
+}
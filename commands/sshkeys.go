@@ -41,30 +41,32 @@ DigitalOcean allows you to add SSH public keys to the interface so that you can
 		aliasOpt("ls"), displayerType(&displayers.Key{}))
 
 	CmdBuilder(cmd, RunKeyGet, "get <key-id|key-fingerprint>", "Retrieve information about an SSH key on your account", `Use this command to get the id, fingerprint, public_key, and name of a specific SSH key on your account.`, Writer,
-		aliasOpt("g"), displayerType(&displayers.Key{}))
+		aliasOpt("g"), displayerType(&displayers.Key{}), fromFileOpt())
 
 	cmdSSHKeysCreate := CmdBuilder(cmd, RunKeyCreate, "create <key-name>", "Create a new SSH key on your account", `Use this command to add a new SSH key to your account.
 
 Specify a `+"`"+`<key-name>`+"`"+` for the key, and set the `+"`"+`--public-key`+"`"+` flag to a string with the contents of the key.
 
 Note that creating a key will not add it to any Droplets.`, Writer,
-		aliasOpt("c"), displayerType(&displayers.Key{}))
+		aliasOpt("c"), displayerType(&displayers.Key{}), fromFileOpt())
 	AddStringFlag(cmdSSHKeysCreate, doctl.ArgKeyPublicKey, "", "", "Key contents", requiredOpt())
 
 	cmdSSHKeysImport := CmdBuilder(cmd, RunKeyImport, "import <key-name>", "Import an SSH key from your computer to your account", `Use this command to add a new SSH key to your account, using a local public key file.
 
 Note that importing a key to your account will not add it to any Droplets`, Writer,
-		aliasOpt("i"), displayerType(&displayers.Key{}))
+		aliasOpt("i"), displayerType(&displayers.Key{}), fromFileOpt())
 	AddStringFlag(cmdSSHKeysImport, doctl.ArgKeyPublicKeyFile, "", "", "Public key file", requiredOpt())
 
+	sshKeyCreateLocal(cmd)
+
 	cmdRunKeyDelete := CmdBuilder(cmd, RunKeyDelete, "delete <key-id|key-fingerprint>", "Permanently delete an SSH key from your account", `Use this command to permanently delete an SSH key from your account.
 
 Note that this does not delete an SSH key from any Droplets.`, Writer,
-		aliasOpt("d", "rm"))
+		aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunKeyDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete the key without a confirmation prompt")
 
 	cmdSSHKeysUpdate := CmdBuilder(cmd, RunKeyUpdate, "update <key-id|key-fingerprint>", "Update an SSH key's name", `Use this command to update the name of an SSH key.`, Writer,
-		aliasOpt("u"), displayerType(&displayers.Key{}))
+		aliasOpt("u"), displayerType(&displayers.Key{}), fromFileOpt())
 	AddStringFlag(cmdSSHKeysUpdate, doctl.ArgKeyName, "", "", "Key name", requiredOpt())
 
 	return cmd
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/commands/displayers"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeyPathsConfigKey is the config key of the default SSH key for each
+// context, set by ssh-key create-local.
+const sshKeyPathsConfigKey = "ssh-key-paths"
+
+// Types of key that ssh-key create-local generates.
+const (
+	sshKeyTypeED25519 = "ed25519"
+	sshKeyTypeRSA     = "rsa"
+)
+
+// sshKeyRSABits is the size of the RSA keys that are generated.
+var sshKeyRSABits = 4096
+
+// sshKeyCreateLocal creates the ssh-key create-local command.
+func sshKeyCreateLocal(parent *Command) *Command {
+	cmdCreateLocal := CmdBuilder(parent, RunKeyCreateLocal, "create-local <key-name>", "Generate an SSH key and add it to your account",
+		`Generates a new SSH key pair on your computer and adds the public key to your account, in place of running `+"`"+`ssh-keygen`+"`"+` and `+"`"+`doctl compute ssh-key import`+"`"+`.
+
+The private key is written to `+"`"+`~/.ssh/id_<type>_<key-name>`+"`"+`, or to the file set with the `+"`"+`--private-key-file`+"`"+` flag, and can only be read by you. The public key is written next to it, with a `+"`"+`.pub`+"`"+` extension. Existing key files are never overwritten. The private key is not protected by a passphrase; use `+"`"+`ssh-keygen -p -f <file>`+"`"+` to add one.
+
+With `+"`"+`--set-default`+"`"+`, the key is also saved in your doctl config as the key that `+"`"+`doctl compute ssh`+"`"+` and the other commands that connect to Droplets use for the current authentication context, unless another key is given with `+"`"+`--ssh-key-path`+"`"+`.
+
+Note that creating a key will not add it to any Droplets.`, Writer,
+		displayerType(&displayers.Key{}), fromFileOpt())
+	AddStringFlag(cmdCreateLocal, doctl.ArgKeyType, "", sshKeyTypeED25519, `The type of key to generate; either "ed25519" or "rsa"`)
+	AddStringFlag(cmdCreateLocal, doctl.ArgKeyPrivateKeyFile, "", "", "The file to write the private key to. Defaults to ~/.ssh/id_<type>_<key-name>")
+	AddBoolFlag(cmdCreateLocal, doctl.ArgKeySetDefault, "", false, "Use the key by default when connecting to Droplets with the current context")
+	cmdCreateLocal.Example = `The following example generates a key named ` + "`" + `laptop` + "`" + `, adds it to your account, and makes it the default key for the current context: doctl compute ssh-key create-local laptop --set-default`
+
+	return cmdCreateLocal
+}
+
+// RunKeyCreateLocal generates an SSH key pair and uploads the public key.
+func RunKeyCreateLocal(c *CmdConfig) error {
+	err := ensureOneArg(c)
+	if err != nil {
+		return err
+	}
+	name := c.Args[0]
+
+	keyType, err := c.Doit.GetString(c.NS, doctl.ArgKeyType)
+	if err != nil {
+		return err
+	}
+	privatePath, err := c.Doit.GetString(c.NS, doctl.ArgKeyPrivateKeyFile)
+	if err != nil {
+		return err
+	}
+	setDefault, err := c.Doit.GetBool(c.NS, doctl.ArgKeySetDefault)
+	if err != nil {
+		return err
+	}
+
+	if privatePath == "" {
+		usr, err := user.Current()
+		if err != nil {
+			return err
+		}
+		privatePath = filepath.Join(usr.HomeDir, ".ssh", "id_"+keyType+"_"+sshKeyFileName(name))
+	}
+	if privatePath, err = filepath.Abs(privatePath); err != nil {
+		return err
+	}
+	publicPath := privatePath + ".pub"
+	for _, path := range []string{privatePath, publicPath} {
+		if _, err := os.Lstat(path); err == nil {
+			return fmt.Errorf("%s already exists; choose another file with --%s", path, doctl.ArgKeyPrivateKeyFile)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	privateKey, publicKey, err := generateSSHKey(keyType, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(privatePath), 0700); err != nil {
+		return err
+	}
+	if err := writeNewFile(privatePath, privateKey, 0600); err != nil {
+		return err
+	}
+	if err := writeNewFile(publicPath, publicKey, 0644); err != nil {
+		os.Remove(privatePath)
+		return err
+	}
+
+	r, err := c.Keys().Create(&godo.KeyCreateRequest{
+		Name:      name,
+		PublicKey: string(publicKey),
+	})
+	if err != nil {
+		// The key is not in use anywhere yet, so remove it to allow the
+		// command to be run again.
+		os.Remove(privatePath)
+		os.Remove(publicPath)
+		return err
+	}
+
+	if setDefault {
+		paths := viper.GetStringMapString(sshKeyPathsConfigKey)
+		paths[currentContext()] = privatePath
+		viper.Set(sshKeyPathsConfigKey, paths)
+		if err := writeConfig(); err != nil {
+			return err
+		}
+	}
+
+	notice("Wrote the private key to %s and the public key to %s", privatePath, publicPath)
+	return c.Display(&displayers.Key{Keys: do.SSHKeys{*r}})
+}
+
+// generateSSHKey generates a key pair, and returns the private key in the
+// OpenSSH format and the public key in the authorized_keys format.
+func generateSSHKey(keyType, comment string) ([]byte, []byte, error) {
+	var (
+		private crypto.PrivateKey
+		public  crypto.PublicKey
+	)
+	switch keyType {
+	case sshKeyTypeED25519:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		private, public = priv, pub
+	case sshKeyTypeRSA:
+		priv, err := rsa.GenerateKey(rand.Reader, sshKeyRSABits)
+		if err != nil {
+			return nil, nil, err
+		}
+		private, public = priv, &priv.PublicKey
+	default:
+		return nil, nil, fmt.Errorf("invalid key type %q, must be one of: ed25519, rsa", keyType)
+	}
+
+	block, err := ssh.MarshalPrivateKey(private, comment)
+	if err != nil {
+		return nil, nil, err
+	}
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return nil, nil, err
+	}
+	authorized := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(sshPublic)), "\n") + " " + comment + "\n"
+	return pem.EncodeToMemory(block), []byte(authorized), nil
+}
+
+// sshKeyFileName returns a key name with the characters that do not belong in
+// a file name replaced.
+func sshKeyFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// writeNewFile writes a file that must not already exist.
+func writeNewFile(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// currentContext returns the authentication context in use.
+func currentContext() string {
+	context := Context
+	if context == "" {
+		context = viper.GetString(doctl.ArgContext)
+	}
+	if context == "" {
+		context = doctl.ArgDefaultContext
+	}
+	return context
+}
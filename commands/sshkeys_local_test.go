@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digitalocean/doctl"
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/godo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestKeysCreateLocal(t *testing.T) {
+	for _, keyType := range []string{"ed25519", "rsa"} {
+		t.Run(keyType, func(t *testing.T) {
+			bits := sshKeyRSABits
+			sshKeyRSABits = 2048
+			defer func() { sshKeyRSABits = bits }()
+
+			withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+				path := filepath.Join(t.TempDir(), ".ssh", "laptop")
+
+				var uploaded string
+				tm.keys.EXPECT().Create(gomock.Any()).DoAndReturn(func(kcr *godo.KeyCreateRequest) (*do.SSHKey, error) {
+					assert.Equal(t, "laptop", kcr.Name)
+					uploaded = kcr.PublicKey
+					return &testKey, nil
+				})
+
+				config.Args = append(config.Args, "laptop")
+				config.Doit.Set(config.NS, doctl.ArgKeyType, keyType)
+				config.Doit.Set(config.NS, doctl.ArgKeyPrivateKeyFile, path)
+
+				require.NoError(t, RunKeyCreateLocal(config))
+
+				info, err := os.Stat(path)
+				require.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+				info, err = os.Stat(filepath.Dir(path))
+				require.NoError(t, err)
+				assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+				private, err := os.ReadFile(path)
+				require.NoError(t, err)
+				signer, err := ssh.ParsePrivateKey(private)
+				require.NoError(t, err)
+				public, err := os.ReadFile(path + ".pub")
+				require.NoError(t, err)
+				assert.Equal(t, uploaded, string(public))
+
+				key, comment, _, _, err := ssh.ParseAuthorizedKey(public)
+				require.NoError(t, err)
+				assert.Equal(t, "laptop", comment)
+				assert.Equal(t, signer.PublicKey().Marshal(), key.Marshal())
+			})
+		})
+	}
+}
+
+func TestKeysCreateLocalExistingFile(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := filepath.Join(t.TempDir(), "laptop")
+		require.NoError(t, os.WriteFile(path+".pub", []byte("existing"), 0644))
+
+		config.Args = append(config.Args, "laptop")
+		config.Doit.Set(config.NS, doctl.ArgKeyType, "ed25519")
+		config.Doit.Set(config.NS, doctl.ArgKeyPrivateKeyFile, path)
+
+		err := RunKeyCreateLocal(config)
+		assert.EqualError(t, err, path+".pub already exists; choose another file with --private-key-file")
+
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+		public, err := os.ReadFile(path + ".pub")
+		require.NoError(t, err)
+		assert.Equal(t, "existing", string(public))
+	})
+}
+
+func TestKeysCreateLocalUploadFails(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		path := filepath.Join(t.TempDir(), "laptop")
+		tm.keys.EXPECT().Create(gomock.Any()).Return(nil, assert.AnError)
+
+		config.Args = append(config.Args, "laptop")
+		config.Doit.Set(config.NS, doctl.ArgKeyType, "ed25519")
+		config.Doit.Set(config.NS, doctl.ArgKeyPrivateKeyFile, path)
+
+		assert.Equal(t, assert.AnError, RunKeyCreateLocal(config))
+
+		// The files are removed so that the command can be run again.
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(path + ".pub")
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestKeysCreateLocalInvalidType(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Args = append(config.Args, "laptop")
+		config.Doit.Set(config.NS, doctl.ArgKeyType, "dsa")
+		config.Doit.Set(config.NS, doctl.ArgKeyPrivateKeyFile, filepath.Join(t.TempDir(), "laptop"))
+
+		err := RunKeyCreateLocal(config)
+		assert.EqualError(t, err, `invalid key type "dsa", must be one of: ed25519, rsa`)
+	})
+}
+
+func TestKeysCreateLocalSetDefault(t *testing.T) {
+	cfw := cfgFileWriter
+	defer func() {
+		cfgFileWriter = cfw
+		viper.Set(sshKeyPathsConfigKey, nil)
+	}()
+	cfgFileWriter = func() (io.WriteCloser, error) { return &nopWriteCloser{Writer: io.Discard}, nil }
+
+	path := filepath.Join(t.TempDir(), "laptop")
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.keys.EXPECT().Create(gomock.Any()).Return(&testKey, nil)
+
+		config.Args = append(config.Args, "laptop")
+		config.Doit.Set(config.NS, doctl.ArgKeyType, "ed25519")
+		config.Doit.Set(config.NS, doctl.ArgKeyPrivateKeyFile, path)
+		config.Doit.Set(config.NS, doctl.ArgKeySetDefault, true)
+
+		require.NoError(t, RunKeyCreateLocal(config))
+		assert.Equal(t, map[string]string{currentContext(): path}, viper.GetStringMapString(sshKeyPathsConfigKey))
+	})
+
+	// Commands that connect to Droplets use the key unless --ssh-key-path is given.
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		config.Doit.Set(config.NS, doctl.ArgsSSHKeyPath, "/home/sammy/.ssh/id_rsa")
+		tc := config.Doit.(*doctl.TestConfig)
+		tc.IsSetMap = map[string]bool{}
+
+		keyPath, err := sshKeyPath(config)
+		require.NoError(t, err)
+		assert.Equal(t, path, keyPath)
+
+		tc.IsSetMap[doctl.ArgsSSHKeyPath] = true
+		keyPath, err = sshKeyPath(config)
+		require.NoError(t, err)
+		assert.Equal(t, "/home/sammy/.ssh/id_rsa", keyPath)
+	})
+}
@@ -32,7 +32,7 @@ var (
 func TestSSHKeysCommand(t *testing.T) {
 	cmd := SSHKeys()
 	assert.NotNil(t, cmd)
-	assertCommandNames(t, cmd, "create", "delete", "get", "import", "list", "update")
+	assertCommandNames(t, cmd, "create", "create-local", "delete", "get", "import", "list", "update")
 }
 
 func TestKeysList(t *testing.T) {
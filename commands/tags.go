@@ -40,11 +40,11 @@ resources attribute with information about resources that have been tagged.`,
 		},
 	}
 
-	cmdTagCreate := CmdBuilder(cmd, RunCmdTagCreate, "create <tag-name>", "Create a tag", `Creates a new tag that you can apply to resources.`, Writer)
+	cmdTagCreate := CmdBuilder(cmd, RunCmdTagCreate, "create <tag-name>", "Create a tag", `Creates a new tag that you can apply to resources.`, Writer, fromFileOpt())
 	cmdTagCreate.Example = `The following example creates a tag name ` + "`" + `web` + "`" + `: doctl compute tag create web`
 
 	cmdTagGet := CmdBuilder(cmd, RunCmdTagGet, "get <tag-name>", "Retrieve information about a tag", `Retrieves the number of resources using the tag.`, Writer,
-		displayerType(&displayers.Tag{}))
+		displayerType(&displayers.Tag{}), fromFileOpt())
 	cmdTagGet.Example = `The following example retrieves information about the tag named ` + "`" + `web` + "`" + `: doctl compute tag get web`
 
 	CmdBuilder(cmd, RunCmdTagList, "list", "List all tags", `Retrieves a list of all the tags in your account and how many resources are using each tag.`, Writer,
@@ -52,17 +52,18 @@ resources attribute with information about resources that have been tagged.`,
 
 	cmdRunTagDelete := CmdBuilder(cmd, RunCmdTagDelete, "delete <tag-name>...", "Delete a tag", `Deletes a tag from your account.
 
-Deleting a tag also removes the tag from all the resources that had been tagged with it.`, Writer, aliasOpt("rm"))
+Deleting a tag also removes the tag from all the resources that had been tagged with it.`, Writer, aliasOpt("rm"), fromFileOpt())
 	AddBoolFlag(cmdRunTagDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete tag without confirmation prompt")
+	addFanOutFlags(cmdRunTagDelete)
 	cmdRunTagDelete.Example = `The following example deletes the tag named ` + "`" + `web` + "`" + `: doctl compute tag delete web`
 
 	cmdApplyTag := CmdBuilder(cmd, RunCmdApplyTag, "apply <tag-name> --resource=<urn> [--resource=<urn> ...]", "Apply a tag to resources", `Tag one or more resources. You can tag Droplets, images, volumes, volume snapshots, and database clusters.
 	
-Resources must be specified as Uniform Resource Names (URNs) and has the following syntax: `+"`"+`do:<resource_type>:<identifier>`+"`"+`.`, Writer)
+Resources must be specified as Uniform Resource Names (URNs) and has the following syntax: `+"`"+`do:<resource_type>:<identifier>`+"`"+`.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdApplyTag, doctl.ArgResourceType, "", []string{}, "The resource to tag in URN format", requiredOpt())
 	cmdApplyTag.Example = `The following example tags two Droplet with the tag named ` + "`" + `web` + "`" + `: doctl compute tag apply web --resource=do:droplet:386734086,do:droplet:191669331`
 
-	cmdRemoveTag := CmdBuilder(cmd, RunCmdRemoveTag, "remove <tag-name> --resource=<urn> [--resource=<urn> ...]", "Remove a tag from resources", `Removes a tag from one or more resources. Resources must be specified as Uniform Resource Names (URNs) and has the following syntax: `+"`"+`do:<resource_type>:<identifier>`+"`"+`.`, Writer)
+	cmdRemoveTag := CmdBuilder(cmd, RunCmdRemoveTag, "remove <tag-name> --resource=<urn> [--resource=<urn> ...]", "Remove a tag from resources", `Removes a tag from one or more resources. Resources must be specified as Uniform Resource Names (URNs) and has the following syntax: `+"`"+`do:<resource_type>:<identifier>`+"`"+`.`, Writer, fromFileOpt())
 	AddStringSliceFlag(cmdRemoveTag, doctl.ArgResourceType, "", []string{}, "The resource to untag in URN format", requiredOpt())
 	cmdRemoveTag.Example = `The following example removes the tag named ` + "`" + `web` + "`" + ` from two Droplets: doctl compute tag remove web --resource=do:droplet:386734086,do:droplet:191669331`
 
@@ -128,18 +129,11 @@ func RunCmdTagDelete(c *CmdConfig) error {
 	}
 
 	if force || AskForConfirmDelete("tag", len(c.Args)) == nil {
-		for id := range c.Args {
-			name := c.Args[id]
-			ts := c.Tags()
-			if err := ts.Delete(name); err != nil {
-				return err
-			}
-		}
-	} else {
-		return errOperationAborted
+		ts := c.Tags()
+		return fanOut(c, c.Args, ts.Delete)
 	}
 
-	return nil
+	return errOperationAborted
 }
 
 // RunCmdApplyTag applies a tag to one or more resources.
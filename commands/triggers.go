@@ -43,14 +43,14 @@ when events from that source type occur.  Currently, only the ` + "`" + `schedul
 
 	CmdBuilder(cmd, RunTriggerToggle(true), "enable <triggerName>",
 		"Enable a trigger", "Use `doctl serverless triggers enable <triggerName>` to enable a trigger",
-		Writer, displayerType(&displayers.Triggers{}))
+		Writer, displayerType(&displayers.Triggers{}), fromFileOpt())
 	CmdBuilder(cmd, RunTriggerToggle(false), "disable <triggerName>",
 		"Disable a trigger", "Use `doctl serverless triggers disable <triggerName>` to disable a trigger",
-		Writer, displayerType(&displayers.Triggers{}))
+		Writer, displayerType(&displayers.Triggers{}), fromFileOpt())
 
 	CmdBuilder(cmd, RunTriggersGet, "get <triggerName>", "Get the details for a trigger",
 		`Use `+"`"+`doctl serverless triggers get <triggerName>`+"`"+` for details about <triggerName>.`,
-		Writer, displayerType(&displayers.Triggers{}))
+		Writer, displayerType(&displayers.Triggers{}), fromFileOpt())
 
 	return cmd
 }
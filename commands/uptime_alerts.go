@@ -49,7 +49,7 @@ In order to set up uptime alerts, you must first set up an uptime check. Uptime
 	- `+"`"+`down`+"`"+`: Alerts on whether the endpoints registers as down from any of the configured regions. No `+"`"+`--threshold`+"`"+` value is necessary.
 	- `+"`"+`down_global`+"`"+`: Alerts on a target registering as down globally. No `+"`"+`--threshold`+"`"+` value is necessary.
 	- `+"`"+`ssl_expiry`+"`"+`: Alerts on an SSL certificate expiring within the set threshold of days. `+"`"+`--threshold`+"`"+` value is an integer representing days.`, Writer,
-		aliasOpt("c"), displayerType(&displayers.UptimeAlert{}), overrideCmdNS("uptime-alert"))
+		aliasOpt("c"), displayerType(&displayers.UptimeAlert{}), overrideCmdNS("uptime-alert"), fromFileOpt())
 
 	AddStringFlag(cmdUptimeAlertsCreate, doctl.ArgUptimeAlertName, "", "", "The name of the alert", requiredOpt())
 	AddStringFlag(cmdUptimeAlertsCreate, doctl.ArgUptimeAlertType, "", "", "The metric to alert on. Possible values: `latency`, `down`, `down_global`, `ssl_expiry`", requiredOpt())
@@ -62,15 +62,15 @@ In order to set up uptime alerts, you must first set up an uptime check. Uptime
 	cmdUptimeAlertsCreate.Example = `The following example creates an alert for an uptime check with an ID of f81d4fae-7dec-11d0-a765-00a0c91e6bf6. The alert triggers if the endpoint's latency exceed 500ms for more than two minutes: doctl monitoring uptime alert create f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --name "Example Alert" --type latency --threshold 100 --comparison greater_than --period 2m --emails "admin@example.com"`
 
 	cmdUptimeAlertsGet := CmdBuilder(cmd, RunUptimeAlertsGet, "get <uptime-check-id> <uptime-alert-id>", "Get uptime alert", `Retrieves information about an uptime alert policy.`, Writer,
-		aliasOpt("g"), displayerType(&displayers.UptimeAlert{}))
+		aliasOpt("g"), displayerType(&displayers.UptimeAlert{}), fromFileOpt())
 	cmdUptimeAlertsGet.Example = `The following example retrieves the configuration for an alert policy with the ID ` + "`" + `418b7972-fc67-41ea-ab4b-6f9477c4f7d8` + "`" + ` that is a policy of an uptime check with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl monitoring uptime alert get f81d4fae-7dec-11d0-a765-00a0c91e6bf6 418b7972-fc67-41ea-ab4b-6f9477c4f7d8`
 
 	cmdUptimeAlertsList := CmdBuilder(cmd, RunUptimeAlertsList, "list <uptime-check-id>", "List uptime alerts", `Retrieves a list of the alert policies for an uptime check.`, Writer,
-		aliasOpt("ls"), displayerType(&displayers.UptimeAlert{}))
+		aliasOpt("ls"), displayerType(&displayers.UptimeAlert{}), fromFileOpt())
 	cmdUptimeAlertsList.Example = `The following example lists the alert policies for an uptime check with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl monitoring uptime alert list f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmdUptimeAlertsUpdate := CmdBuilder(cmd, RunUptimeAlertsUpdate, "update <uptime-check-id> <uptime-alert-id>", "Update an uptime alert", `Updates an uptime alert configuration.`, Writer,
-		aliasOpt("u"), displayerType(&displayers.UptimeAlert{}), overrideCmdNS("uptime-alert"))
+		aliasOpt("u"), displayerType(&displayers.UptimeAlert{}), overrideCmdNS("uptime-alert"), fromFileOpt())
 	AddStringFlag(cmdUptimeAlertsUpdate, doctl.ArgUptimeAlertName, "", "", "The name of the alert", requiredOpt())
 	AddStringFlag(cmdUptimeAlertsUpdate, doctl.ArgUptimeAlertType, "", "", "The metric to alert on. Possible values: `latency`, `down`, `down_global`, `ssl_expiry`", requiredOpt())
 	AddIntFlag(cmdUptimeAlertsUpdate, doctl.ArgUptimeAlertThreshold, "", 0, "The threshold at which to trigger the alert. The specific threshold is dependent on the alert type.")
@@ -82,7 +82,7 @@ In order to set up uptime alerts, you must first set up an uptime check. Uptime
 	cmdUptimeAlertsUpdate.Example = `The following example updates an alert policy with the ID ` + "`" + `418b7972-fc67-41ea-ab4b-6f9477c4f7d8` + "`" + ` that is a policy of an uptime check with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl monitoring uptime alert update f81d4fae-7dec-11d0-a765-00a0c91e6bf6 418b7972-fc67-41ea-ab4b-6f9477c4f7d8 --name "Example Alert" --type latency --threshold 100 --comparison greater_than --period 2m --emails "admin@example.com"`
 
 	cmdUptimeAlertsDelete := CmdBuilder(cmd, RunUptimeAlertsDelete, "delete <uptime-check-id> <uptime-alert-id>", "Delete an uptime alert", `Deletes an uptime check on your account by ID.`, Writer,
-		aliasOpt("d", "del", "rm"))
+		aliasOpt("d", "del", "rm"), fromFileOpt())
 	cmdUptimeAlertsDelete.Example = `The following example deletes an alert policy with the ID ` + "`" + `418b7972-fc67-41ea-ab4b-6f9477c4f7d8` + "`" + ` that is a policy of an uptime check with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl monitoring uptime alert delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6 418b7972-fc67-41ea-ab4b-6f9477c4f7d8`
 
 	return cmd
@@ -40,7 +40,7 @@ and alert you when they're slow, unavailable, or SSL certificates are expiring.`
 	cmdUptimeChecksCreate := CmdBuilder(cmd, RunUptimeChecksCreate, "create <uptime-check-name>", "Create an uptime check", `Creates an uptime check on your account. Uptime checks monitor any endpoint that is accessible over HTTP, HTTPS, ping (ICMP).
 	
 	You can use this check to set up an alert policy using the `+"`"+`doctl monitoring uptime alert`+"`"+` commands.`, Writer,
-		aliasOpt("c"), displayerType(&displayers.UptimeCheck{}))
+		aliasOpt("c"), displayerType(&displayers.UptimeCheck{}), fromFileOpt())
 	AddStringFlag(cmdUptimeChecksCreate, doctl.ArgUptimeCheckTarget, "", "", "A valid URL to monitor", requiredOpt())
 	AddStringFlag(cmdUptimeChecksCreate, doctl.ArgUptimeCheckType, "", "", "The protocol to use to monitor the target URL. Possible values: `ping`, `http`, `https`. Defaults to either `http` or `https`, depending on the URL target provided")
 	AddStringSliceFlag(cmdUptimeChecksCreate, doctl.ArgUptimeCheckRegions, "", []string{"us_east"}, "A comma-separated list of regions to monitor the target from. Possible values: `us_east`, `us_west`, `eu_west`, `se_asia`. Defaults to `us_east`")
@@ -48,7 +48,7 @@ and alert you when they're slow, unavailable, or SSL certificates are expiring.`
 	cmdUptimeChecksCreate.Example = `The following example creates an uptime check that monitors the URL, ` + "`" + `example.com` + "`" + ` from the eastern and western regions of the Unites States: doctl monitoring uptime create --target https://example.com --type https --regions us_east,us_west --enabled true`
 
 	cmdUptimeChecksGet := CmdBuilder(cmd, RunUptimeChecksGet, "get <uptime-check-id>", "Get an uptime check", `Retrieves information about an uptime check on your account.`, Writer,
-		aliasOpt("g"), displayerType(&displayers.UptimeCheck{}))
+		aliasOpt("g"), displayerType(&displayers.UptimeCheck{}), fromFileOpt())
 	cmdUptimeChecksGet.Example = `The following example retrieves the ID, name, and target of an uptime check: doctl monitoring uptime get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmdUptimeChecksList := CmdBuilder(cmd, RunUptimeChecksList, "list", "List uptime checks", `Retrieves a list of all of the uptime checks on your account.`, Writer,
@@ -58,7 +58,7 @@ and alert you when they're slow, unavailable, or SSL certificates are expiring.`
 	cmdUptimeCheckUpdate := CmdBuilder(cmd, RunUptimeChecksUpdate, "update <uptime-check-id>", "Update an uptime check", `Updates an uptime check on your account.
 
 All of these flags are required. Uptime checks cannot be disabled via `+"`"+`doctl`+"`"+`. You can only disable them using the control panel or the public API.`, Writer,
-		aliasOpt("u"), displayerType(&displayers.UptimeCheck{}))
+		aliasOpt("u"), displayerType(&displayers.UptimeCheck{}), fromFileOpt())
 	AddStringFlag(cmdUptimeCheckUpdate, doctl.ArgUptimeCheckName, "", "", "A name for the check", requiredOpt())
 	AddStringFlag(cmdUptimeCheckUpdate, doctl.ArgUptimeCheckTarget, "", "", "A valid URL to monitor", requiredOpt())
 	AddStringFlag(cmdUptimeCheckUpdate, doctl.ArgUptimeCheckType, "", "", "The protocol to use to monitor the target URL. Possible values: `ping`, `http`, `https`. Defaults to either `http` or `https`, depending on the URL target provided", requiredOpt())
@@ -66,7 +66,7 @@ All of these flags are required. Uptime checks cannot be disabled via `+"`"+`doc
 	cmdUptimeCheckUpdate.Example = `The following example updates the name, target, type, and regions of an uptime check: doctl monitoring uptime update f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --name example --target https://example.com --type https --regions us_east,us_west`
 
 	cmdUptimeChecksDelete := CmdBuilder(cmd, RunUptimeChecksDelete, "delete <uptime-check-id>", "Delete an uptime check", `Deletes an uptime check on your account.`, Writer,
-		aliasOpt("d", "del", "rm"))
+		aliasOpt("d", "del", "rm"), fromFileOpt())
 	cmdUptimeChecksDelete.Example = `The following example deletes an uptime check with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl monitoring uptime delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmd.AddCommand(UptimeAlert())
@@ -14,8 +14,6 @@ limitations under the License.
 package commands
 
 import (
-	"strconv"
-
 	"github.com/spf13/cobra"
 
 	"github.com/digitalocean/doctl"
@@ -72,36 +70,36 @@ func VolumeAction() *Command {
 - The slug for the region where the action occurred.
 	`
 
-	cmdVolumeActionsGet := CmdBuilder(cmd, RunVolumeActionsGet, "get <volume-id>", "Retrieve the status of a volume action", `Retrieves the status of a volume action, including the following details:`+actionDetail, Writer,
-		displayerType(&displayers.Action{}))
+	cmdVolumeActionsGet := CmdBuilder(cmd, RunVolumeActionsGet, "get <volume-id|name>", "Retrieve the status of a volume action", `Retrieves the status of a volume action, including the following details:`+actionDetail, Writer,
+		displayerType(&displayers.Action{}), fromFileOpt())
 	AddIntFlag(cmdVolumeActionsGet, doctl.ArgActionID, "", 0, "action id", requiredOpt())
 	cmdVolumeActionsGet.Example = `The following example retrieves the status of an action taken on a volume with the UUID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute volume-action get f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --action-id 191669331`
 
-	cmdVolumeActionsList := CmdBuilder(cmd, RunVolumeActionsList, "list <volume-id>", "Retrieve a list of actions taken on a volume", `Retrieves a list of actions taken on a volume. The following details are provided:`+actionDetail, Writer,
-		aliasOpt("ls"), displayerType(&displayers.Action{}))
+	cmdVolumeActionsList := CmdBuilder(cmd, RunVolumeActionsList, "list <volume-id|name>", "Retrieve a list of actions taken on a volume", `Retrieves a list of actions taken on a volume. The following details are provided:`+actionDetail, Writer,
+		aliasOpt("ls"), displayerType(&displayers.Action{}), fromFileOpt())
 	cmdVolumeActionsList.Example = `The following example retrieves a list of actions taken on a volume with the UUID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `. The command also uses the ` + "`" + `--format` + "`" + ` flag to return only the resource ID and status for each action listed: doctl compute volume-action list f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --format ResourceID,Status`
 
-	cmdRunVolumeAttach := CmdBuilder(cmd, RunVolumeAttach, "attach <volume-id> <droplet-id>", "Attach a volume to a Droplet", `Attaches a block storage volume to a Droplet.
+	cmdRunVolumeAttach := CmdBuilder(cmd, RunVolumeAttach, "attach <volume-id|name> <droplet-id>", "Attach a volume to a Droplet", `Attaches a block storage volume to a Droplet.
 
 You can only attach one Droplet to a volume at a time. However, you can attach up to fifteen different volumes to a Droplet at a time.
 
 When you attach a pre-formatted volume to Ubuntu, Debian, Fedora, Fedora Atomic, and CentOS Droplets created on or after April 26, 2018, the volume automatically mounts. On older Droplets, additional configuration is required. Visit https://docs.digitalocean.com/products/volumes/how-to/mount/ for details`, Writer,
-		aliasOpt("a"))
+		aliasOpt("a"), fromFileOpt())
 	AddBoolFlag(cmdRunVolumeAttach, doctl.ArgCommandWait, "", false, "Instructs the terminal to wait for the volume to attach before returning control to the user")
 	cmdRunVolumeAttach.Example = `The following example attaches a volume with the UUID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` to a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute volume-action attach f81d4fae-7dec-11d0-a765-00a0c91e6bf6 386734086`
 
-	cmdRunVolumeDetach := CmdBuilder(cmd, RunVolumeDetach, "detach <volume-id> <droplet-id>", "Detach a volume from a Droplet", `Detaches a block storage volume from a Droplet.`, Writer,
-		aliasOpt("d"))
+	cmdRunVolumeDetach := CmdBuilder(cmd, RunVolumeDetach, "detach <volume-id|name> <droplet-id>", "Detach a volume from a Droplet", `Detaches a block storage volume from a Droplet.`, Writer,
+		aliasOpt("d"), fromFileOpt())
 	AddBoolFlag(cmdRunVolumeDetach, doctl.ArgCommandWait, "", false, "Instructs the terminal to wait for the volume to detach before returning control to the user")
 	cmdRunVolumeDetach.Example = `The following example detaches a volume with the UUID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + ` from a Droplet with the ID ` + "`" + `386734086` + "`" + `: doctl compute volume-action detach f81d4fae-7dec-11d0-a765-00a0c91e6bf6 386734086`
 
-	CmdBuilder(cmd, RunVolumeDetach, "detach-by-droplet-id <volume-id> <droplet-id>", "(Deprecated) Detach a volume. Use `detach` instead.", "This command detaches a volume. This command is deprecated. Use `doctl compute volume-action detach` instead.",
-		Writer)
+	CmdBuilder(cmd, RunVolumeDetach, "detach-by-droplet-id <volume-id|name> <droplet-id>", "(Deprecated) Detach a volume. Use `detach` instead.", "This command detaches a volume. This command is deprecated. Use `doctl compute volume-action detach` instead.",
+		Writer, fromFileOpt())
 
-	cmdRunVolumeResize := CmdBuilder(cmd, RunVolumeResize, "resize <volume-id>", "Resize the disk of a volume", `Resizes a block storage volume.
+	cmdRunVolumeResize := CmdBuilder(cmd, RunVolumeResize, "resize <volume-id|name>", "Resize the disk of a volume", `Resizes a block storage volume.
 
 Volumes may only be resized upwards. The maximum size for a volume is 16TiB.`, Writer,
-		aliasOpt("r"))
+		aliasOpt("r"), fromFileOpt())
 	AddIntFlag(cmdRunVolumeResize, doctl.ArgSizeSlug, "", 0, "The volume's new size, in GiB",
 		requiredOpt())
 	AddStringFlag(cmdRunVolumeResize, doctl.ArgRegionSlug, "", "", "The volume's current region",
@@ -119,11 +117,13 @@ func RunVolumeAttach(c *CmdConfig) error {
 		if len(c.Args) != 2 {
 			return nil, doctl.NewMissingArgsErr(c.NS)
 		}
-		volumeID := c.Args[0]
-		dropletID, err := strconv.Atoi(c.Args[1])
+		volumeID, err := do.ResolveVolumeID(c.Volumes(), c.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		dropletID, err := do.ResolveDropletID(c.Droplets(), c.Args[1])
 		if err != nil {
 			return nil, err
-
 		}
 		a, err := das.Attach(volumeID, dropletID)
 		return a, err
@@ -137,8 +137,11 @@ func RunVolumeDetach(c *CmdConfig) error {
 		if len(c.Args) != 2 {
 			return nil, doctl.NewMissingArgsErr(c.NS)
 		}
-		volumeID := c.Args[0]
-		dropletID, err := strconv.Atoi(c.Args[1])
+		volumeID, err := do.ResolveVolumeID(c.Volumes(), c.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		dropletID, err := do.ResolveDropletID(c.Droplets(), c.Args[1])
 		if err != nil {
 			return nil, err
 		}
@@ -155,7 +158,10 @@ func RunVolumeResize(c *CmdConfig) error {
 		if err != nil {
 			return nil, err
 		}
-		volumeID := c.Args[0]
+		volumeID, err := do.ResolveVolumeID(c.Volumes(), c.Args[0])
+		if err != nil {
+			return nil, err
+		}
 
 		size, err := c.Doit.GetInt(c.NS, doctl.ArgSizeSlug)
 		if err != nil {
@@ -180,7 +186,10 @@ func RunVolumeActionsGet(c *CmdConfig) error {
 		return err
 	}
 
-	volumeID := c.Args[0]
+	volumeID, err := do.ResolveVolumeID(c.Volumes(), c.Args[0])
+	if err != nil {
+		return err
+	}
 	actionID, err := c.Doit.GetInt(c.NS, doctl.ArgActionID)
 	if err != nil {
 		return err
@@ -204,7 +213,10 @@ func RunVolumeActionsList(c *CmdConfig) error {
 		return err
 	}
 
-	volumeID := c.Args[0]
+	volumeID, err := do.ResolveVolumeID(c.Volumes(), c.Args[0])
+	if err != nil {
+		return err
+	}
 	vList, err := c.VolumeActions().List(volumeID)
 	if err != nil {
 		return err
@@ -49,7 +49,7 @@ Volumes function as raw block devices, meaning they appear to the operating syst
 You can use flags to specify the volume size, region, description, filesystem type, tags, and to create a volume from an existing volume snapshot.
 
 Use the `+"`"+`doctl compute volume-action attach <volume-id> <droplet-id>`+"`"+` command to attach a new volume to a Droplet.`, Writer,
-		aliasOpt("c"), displayerType(&displayers.Volume{}))
+		aliasOpt("c"), displayerType(&displayers.Volume{}), fromFileOpt())
 	AddStringFlag(cmdVolumeCreate, doctl.ArgVolumeSize, "", "4TiB", "Volume size",
 		requiredOpt())
 	AddStringFlag(cmdVolumeCreate, doctl.ArgVolumeDesc, "", "", "A description of the volume")
@@ -60,19 +60,20 @@ Use the `+"`"+`doctl compute volume-action attach <volume-id> <droplet-id>`+"`"+
 	AddStringSliceFlag(cmdVolumeCreate, doctl.ArgTag, "", []string{}, "A comma-separated list of tags to apply to the volume. For example, `--tag frontend` or `--tag frontend,backend`")
 	cmdVolumeCreate.Example = `The following example creates a 4TiB volume named ` + "`" + `example-volume` + "`" + ` in the ` + "`" + `nyc1` + "`" + ` region. The command also applies two tags to the volume: doctl compute volume create example-volume --region nyc1 --size 4TiB --tag frontend,backend`
 
-	cmdRunVolumeDelete := CmdBuilder(cmd, RunVolumeDelete, "delete <volume-id>", "Delete a block storage volume", `Deletes a block storage volume by ID, destroying all of its data and removing it from your account. This is irreversible.`, Writer,
-		aliasOpt("d", "rm"))
+	cmdRunVolumeDelete := CmdBuilder(cmd, RunVolumeDelete, "delete <volume-id|name>...", "Delete a block storage volume", `Deletes one or more block storage volumes by ID or name, destroying all of its data and removing it from your account. This is irreversible.`, Writer,
+		aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunVolumeDelete, doctl.ArgForce, doctl.ArgShortForce, false, "Delete the volume without prompting for confirmation")
+	addFanOutFlags(cmdRunVolumeDelete)
 	cmdRunVolumeDelete.Example = `The following example deletes a volume with the UUID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute volume delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
-	cmdVolumeGet := CmdBuilder(cmd, RunVolumeGet, "get <volume-id>", "Retrieve an existing block storage volume", `Retrieves information about a block storage volume.`, Writer, aliasOpt("g"),
-		displayerType(&displayers.Volume{}))
+	cmdVolumeGet := CmdBuilder(cmd, RunVolumeGet, "get <volume-id|name>", "Retrieve an existing block storage volume", `Retrieves information about a block storage volume.`, Writer, aliasOpt("g"),
+		displayerType(&displayers.Volume{}), fromFileOpt())
 	cmdVolumeGet.Example = `The following example retrieves information about a volume with the UUID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl compute volume get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
-	cmdRunVolumeSnapshot := CmdBuilder(cmd, RunVolumeSnapshot, "snapshot <volume-id>", "Create a block storage volume snapshot", `Creates a snapshot of a block storage volume by ID.
+	cmdRunVolumeSnapshot := CmdBuilder(cmd, RunVolumeSnapshot, "snapshot <volume-id|name>", "Create a block storage volume snapshot", `Creates a snapshot of a block storage volume by ID.
 
 You can use a block storage volume snapshot ID as a flag with `+"`"+`doctl volume create`+"`"+` to create a new block storage volume with the same data as the volume the snapshot was taken from.`, Writer,
-		aliasOpt("s"), displayerType(&displayers.Volume{}))
+		aliasOpt("s"), displayerType(&displayers.Volume{}), fromFileOpt())
 	AddStringFlag(cmdRunVolumeSnapshot, doctl.ArgSnapshotName, "", "", "The snapshot name", requiredOpt())
 	AddStringFlag(cmdRunVolumeSnapshot, doctl.ArgSnapshotDesc, "", "", "A description of the snapshot")
 	AddStringSliceFlag(cmdRunVolumeSnapshot, doctl.ArgTag, "", []string{}, "A comma-separate list of tags to apply to the snapshot. For example, `--tag frontend` or `--tag frontend,backend`")
@@ -222,9 +223,15 @@ func RunVolumeDelete(c *CmdConfig) error {
 		return err
 	}
 
-	if force || AskForConfirmDelete("volume", 1) == nil {
-		id := c.Args[0]
-		return c.Volumes().DeleteVolume(id)
+	if force || AskForConfirmDelete("volume", len(c.Args)) == nil {
+		vs := c.Volumes()
+		return fanOut(c, c.Args, func(ref string) error {
+			id, err := do.ResolveVolumeID(vs, ref)
+			if err != nil {
+				return err
+			}
+			return vs.DeleteVolume(id)
+		})
 	}
 	return errOperationAborted
 }
@@ -235,8 +242,11 @@ func RunVolumeGet(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 
 	}
-	id := c.Args[0]
 	al := c.Volumes()
+	id, err := do.ResolveVolumeID(al, c.Args[0])
+	if err != nil {
+		return err
+	}
 	d, err := al.Get(id)
 	if err != nil {
 		return err
@@ -252,7 +262,10 @@ func RunVolumeSnapshot(c *CmdConfig) error {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
 
-	id := c.Args[0]
+	id, err := do.ResolveVolumeID(c.Volumes(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	name, err := c.Doit.GetString(c.NS, doctl.ArgSnapshotName)
 	if err != nil {
@@ -14,6 +14,7 @@ limitations under the License.
 package commands
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/digitalocean/doctl"
@@ -45,7 +46,20 @@ func TestVolumeCommand(t *testing.T) {
 
 func TestVolumesGet(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
-		tm.volumes.EXPECT().Get("test-volume").Return(&testVolume, nil)
+		tm.volumes.EXPECT().Get(testVolume.ID).Return(&testVolume, nil)
+
+		config.Args = append(config.Args, testVolume.ID)
+
+		err := RunVolumeGet(config)
+		assert.NoError(t, err)
+	})
+}
+
+func TestVolumesGetByName(t *testing.T) {
+	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
+		tm.volumes.EXPECT().Get("test-volume").Return(nil, &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}})
+		tm.volumes.EXPECT().List().Return(testVolumeList, nil)
+		tm.volumes.EXPECT().Get(testVolume.ID).Return(&testVolume, nil)
 
 		config.Args = append(config.Args, "test-volume")
 
@@ -133,9 +147,9 @@ func TestVolumeCreateFromSnapshot(t *testing.T) {
 
 func TestVolumesDelete(t *testing.T) {
 	withTestClient(t, func(config *CmdConfig, tm *tcMocks) {
-		tm.volumes.EXPECT().DeleteVolume("test-volume").Return(nil)
+		tm.volumes.EXPECT().DeleteVolume(testVolume.ID).Return(nil)
 
-		config.Args = append(config.Args, "test-volume")
+		config.Args = append(config.Args, testVolume.ID)
 
 		config.Doit.Set(config.NS, doctl.ArgForce, true)
 
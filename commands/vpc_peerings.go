@@ -35,7 +35,7 @@ With the VPC Peerings commands, you can get, list, create, update, or delete VPC
 `
 	cmdPeeringGet := CmdBuilder(cmd, RunVPCPeeringGet, "get <id>",
 		"Retrieves a VPC Peering", "Retrieves information about a VPC Peering, including:"+peeringDetails, Writer,
-		aliasOpt("g"), displayerType(&displayers.VPCPeering{}))
+		aliasOpt("g"), displayerType(&displayers.VPCPeering{}), fromFileOpt())
 	cmdPeeringGet.Example = `The following example retrieves information about a VPC Peering with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl vpcs peerings get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmdPeeringList := CmdBuilder(cmd, RunVPCPeeringList, "list", "List VPC Peerings", "Retrieves a list of the VPC Peerings on your account, including the following information for each:"+peeringDetails, Writer,
@@ -54,7 +54,7 @@ With the VPC Peerings commands, you can get, list, create, update, or delete VPC
 		` : doctl vpcs peerings create example-peering-name --vpc-ids f81d4fae-7dec-11d0-a765-00a0c91e6bf6,3f900b61-30d7-40d8-9711-8c5d6264b268`
 
 	cmdPeeringUpdate := CmdBuilder(cmd, RunVPCPeeringUpdate, "update <id>",
-		"Update a VPC Peering's name", `Use this command to update the name of a VPC Peering`, Writer, aliasOpt("u"))
+		"Update a VPC Peering's name", `Use this command to update the name of a VPC Peering`, Writer, aliasOpt("u"), fromFileOpt())
 	AddStringFlag(cmdPeeringUpdate, doctl.ArgVPCPeeringName, "", "",
 		"The VPC Peering's name", requiredOpt())
 	cmdPeeringUpdate.Example = `The following example updates the name of a VPC Peering with the ID ` +
@@ -62,7 +62,7 @@ With the VPC Peerings commands, you can get, list, create, update, or delete VPC
 		`: doctl vpcs peerings update f81d4fae-7dec-11d0-a765-00a0c91e6bf6 --name new-name`
 
 	cmdPeeringDelete := CmdBuilder(cmd, RunVPCPeeringDelete, "delete <id>",
-		"Permanently delete a VPC Peering", `Permanently deletes the specified VPC Peering. This is irreversible.`, Writer, aliasOpt("d", "rm"))
+		"Permanently delete a VPC Peering", `Permanently deletes the specified VPC Peering. This is irreversible.`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdPeeringDelete, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Delete the VPC Peering without any confirmation prompt")
 	AddBoolFlag(cmdPeeringDelete, doctl.ArgCommandWait, "", false,
@@ -51,8 +51,8 @@ With the VPC commands, you can list, create, or delete VPCs, and manage their co
 - The VPC network's creation date, in ISO8601 combined date and time format
 `
 
-	cmdVPCGet := CmdBuilder(cmd, RunVPCGet, "get <id>", "Retrieve a VPC network", "Retrieve information about a VPC network, including:"+vpcDetail, Writer,
-		aliasOpt("g"), displayerType(&displayers.VPC{}))
+	cmdVPCGet := CmdBuilder(cmd, RunVPCGet, "get <vpc-id|name>", "Retrieve a VPC network", "Retrieve information about a VPC network, including:"+vpcDetail, Writer,
+		aliasOpt("g"), displayerType(&displayers.VPC{}), fromFileOpt())
 	cmdVPCGet.Example = `The following example retrieves information about a VPC network with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl vpcs get f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
 
 	cmdRecordCreate := CmdBuilder(cmd, RunVPCCreate, "create",
@@ -65,8 +65,8 @@ With the VPC commands, you can list, create, or delete VPCs, and manage their co
 	AddStringFlag(cmdRecordCreate, doctl.ArgRegionSlug, "", "", "The VPC network's region slug, such as `nyc1`", requiredOpt())
 	cmdRecordCreate.Example = `The following example creates a VPC network named ` + "`" + `example-vpc` + "`" + ` in the ` + "`" + `nyc1` + "`" + ` region: doctl vpcs create --name example-vpc --region nyc1`
 
-	cmdRecordUpdate := CmdBuilder(cmd, RunVPCUpdate, "update <id>",
-		"Update a VPC network's configuration", `Updates a VPC network's configuration. You can update its name, description, and default state.`, Writer, aliasOpt("u"))
+	cmdRecordUpdate := CmdBuilder(cmd, RunVPCUpdate, "update <vpc-id|name>",
+		"Update a VPC network's configuration", `Updates a VPC network's configuration. You can update its name, description, and default state.`, Writer, aliasOpt("u"), fromFileOpt())
 	AddStringFlag(cmdRecordUpdate, doctl.ArgVPCName, "", "",
 		"The VPC network's name")
 	AddStringFlag(cmdRecordUpdate, doctl.ArgVPCDescription, "", "",
@@ -79,10 +79,10 @@ With the VPC commands, you can list, create, or delete VPCs, and manage their co
 		aliasOpt("ls"), displayerType(&displayers.VPC{}))
 	cmdVPCList.Example = `The following example lists the VPCs on your account and uses the --format flag to return only the name, IP range, and region for each VPC network: doctl vpcs list --format Name,IPRange,Region`
 
-	cmdRunRecordDelete := CmdBuilder(cmd, RunVPCDelete, "delete <id>",
+	cmdRunRecordDelete := CmdBuilder(cmd, RunVPCDelete, "delete <vpc-id|name>",
 		"Permanently delete a VPC network", `Permanently deletes the specified VPC. This is irreversible.
 		
-		You cannot delete VPCs that are default networks for a region. To delete a default VPC network, make another VPC network the default for the region using the `+"`"+`doctl vpcs update <vpc-network-id> --default=true`+"`"+` command, and then delete the target VPC network.`, Writer, aliasOpt("d", "rm"))
+		You cannot delete VPCs that are default networks for a region. To delete a default VPC network, make another VPC network the default for the region using the `+"`"+`doctl vpcs update <vpc-network-id> --default=true`+"`"+` command, and then delete the target VPC network.`, Writer, aliasOpt("d", "rm"), fromFileOpt())
 	AddBoolFlag(cmdRunRecordDelete, doctl.ArgForce, doctl.ArgShortForce, false,
 		"Delete the VPC without a confirmation prompt")
 	cmdRunRecordDelete.Example = `The following example deletes the VPC network with the ID ` + "`" + `f81d4fae-7dec-11d0-a765-00a0c91e6bf6` + "`" + `: doctl vpcs delete f81d4fae-7dec-11d0-a765-00a0c91e6bf6`
@@ -96,7 +96,10 @@ func RunVPCGet(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	vpcUUID := c.Args[0]
+	vpcUUID, err := do.ResolveVPCID(c.VPCs(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	vpcs := c.VPCs()
 	vpc, err := vpcs.Get(vpcUUID)
@@ -162,7 +165,10 @@ func RunVPCUpdate(c *CmdConfig) error {
 	if len(c.Args) == 0 {
 		return doctl.NewMissingArgsErr(c.NS)
 	}
-	vpcUUID := c.Args[0]
+	vpcUUID, err := do.ResolveVPCID(c.VPCs(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	options := make([]godo.VPCSetField, 0)
 
@@ -210,7 +216,10 @@ func RunVPCDelete(c *CmdConfig) error {
 	if err != nil {
 		return err
 	}
-	vpcUUID := c.Args[0]
+	vpcUUID, err := do.ResolveVPCID(c.VPCs(), c.Args[0])
+	if err != nil {
+		return err
+	}
 
 	force, err := c.Doit.GetBool(c.NS, doctl.ArgForce)
 	if err != nil {
@@ -88,11 +88,11 @@ func TestVPCUpdate(t *testing.T) {
 		{
 			desc: "update vpc name",
 			setup: func(in *CmdConfig) {
-				in.Args = append(in.Args, "vpc-uuid")
+				in.Args = append(in.Args, "e819b321-a9a1-4078-b437-8e6b8bf13530")
 				in.Doit.Set(in.NS, doctl.ArgVPCName, "update-vpc-name-test")
 
 			},
-			expectedVPCId: "vpc-uuid",
+			expectedVPCId: "e819b321-a9a1-4078-b437-8e6b8bf13530",
 			expectedRequest: []godo.VPCSetField{
 				godo.VPCSetName("update-vpc-name-test"),
 			},
@@ -101,12 +101,12 @@ func TestVPCUpdate(t *testing.T) {
 		{
 			desc: "update vpc name and description",
 			setup: func(in *CmdConfig) {
-				in.Args = append(in.Args, "vpc-uuid")
+				in.Args = append(in.Args, "e819b321-a9a1-4078-b437-8e6b8bf13530")
 				in.Doit.Set(in.NS, doctl.ArgVPCName, "update-vpc-name-test")
 				in.Doit.Set(in.NS, doctl.ArgVPCDescription, "i am a new desc")
 
 			},
-			expectedVPCId: "vpc-uuid",
+			expectedVPCId: "e819b321-a9a1-4078-b437-8e6b8bf13530",
 			expectedRequest: []godo.VPCSetField{
 				godo.VPCSetName("update-vpc-name-test"),
 				godo.VPCSetDescription("i am a new desc"),
@@ -116,12 +116,12 @@ func TestVPCUpdate(t *testing.T) {
 		{
 			desc: "update vpc name and description and set to default",
 			setup: func(in *CmdConfig) {
-				in.Args = append(in.Args, "vpc-uuid")
+				in.Args = append(in.Args, "e819b321-a9a1-4078-b437-8e6b8bf13530")
 				in.Doit.Set(in.NS, doctl.ArgVPCName, "update-vpc-name-test")
 				in.Doit.Set(in.NS, doctl.ArgVPCDescription, "i am a new desc")
 				in.Doit.Set(in.NS, doctl.ArgVPCDefault, true)
 			},
-			expectedVPCId: "vpc-uuid",
+			expectedVPCId: "e819b321-a9a1-4078-b437-8e6b8bf13530",
 			expectedRequest: []godo.VPCSetField{
 				godo.VPCSetName("update-vpc-name-test"),
 				godo.VPCSetDescription("i am a new desc"),
@@ -132,10 +132,10 @@ func TestVPCUpdate(t *testing.T) {
 		{
 			desc: "update only default",
 			setup: func(in *CmdConfig) {
-				in.Args = append(in.Args, "vpc-uuid")
+				in.Args = append(in.Args, "e819b321-a9a1-4078-b437-8e6b8bf13530")
 				in.Doit.Set(in.NS, doctl.ArgVPCDefault, true)
 			},
-			expectedVPCId: "vpc-uuid",
+			expectedVPCId: "e819b321-a9a1-4078-b437-8e6b8bf13530",
 			expectedRequest: []godo.VPCSetField{
 				godo.VPCSetDefault(),
 			},
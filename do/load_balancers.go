@@ -15,6 +15,11 @@ package do
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/digitalocean/godo"
 )
@@ -39,6 +44,7 @@ type LoadBalancersService interface {
 	AddForwardingRules(lbID string, rules ...godo.ForwardingRule) error
 	RemoveForwardingRules(lbID string, rules ...godo.ForwardingRule) error
 	PurgeCache(lbID string) error
+	DropletHealth(lbID string) (map[int]bool, error)
 }
 
 var _ LoadBalancersService = &loadBalancersService{}
@@ -139,3 +145,35 @@ func (lbs *loadBalancersService) PurgeCache(lbID string) error {
 	_, err := lbs.client.LoadBalancers.PurgeCache(context.TODO(), lbID)
 	return err
 }
+
+// DropletHealth returns whether each Droplet behind a load balancer passed
+// its latest health check, keyed by Droplet ID. It reads the load balancer's
+// droplets_health_checks metric for the last five minutes. Droplets without
+// results yet, such as ones added since the latest sample, are missing.
+func (lbs *loadBalancersService) DropletHealth(lbID string) (map[int]bool, error) {
+	end := time.Now()
+	q := url.Values{}
+	q.Set("lb_id", lbID)
+	q.Set("start", strconv.FormatInt(end.Add(-5*time.Minute).Unix(), 10))
+	q.Set("end", strconv.FormatInt(end.Unix(), 10))
+	path := fmt.Sprintf("v2/monitoring/metrics/load_balancer/droplets_health_checks?%s", q.Encode())
+
+	req, err := lbs.client.NewRequest(context.TODO(), http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp godo.MetricsResponse
+	if _, err := lbs.client.Do(context.TODO(), req, &resp); err != nil {
+		return nil, err
+	}
+
+	health := map[int]bool{}
+	for _, r := range resp.Data.Result {
+		id, err := strconv.Atoi(string(r.Metric["droplet_id"]))
+		if err != nil || len(r.Values) == 0 {
+			continue
+		}
+		health[id] = r.Values[len(r.Values)-1].Value > 0
+	}
+	return health, nil
+}
@@ -107,6 +107,21 @@ func (mr *MockLoadBalancersServiceMockRecorder) Delete(lbID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLoadBalancersService)(nil).Delete), lbID)
 }
 
+// DropletHealth mocks base method.
+func (m *MockLoadBalancersService) DropletHealth(lbID string) (map[int]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropletHealth", lbID)
+	ret0, _ := ret[0].(map[int]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DropletHealth indicates an expected call of DropletHealth.
+func (mr *MockLoadBalancersServiceMockRecorder) DropletHealth(lbID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropletHealth", reflect.TypeOf((*MockLoadBalancersService)(nil).DropletHealth), lbID)
+}
+
 // Get mocks base method.
 func (m *MockLoadBalancersService) Get(lbID string) (*do.LoadBalancer, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,502 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl/pkg/urn"
+	"github.com/digitalocean/godo"
+	"github.com/google/uuid"
+)
+
+// URN collections for the resource types that can be resolved by name.
+const (
+	URNCollectionApp               = "app"
+	URNCollectionCDN               = "cdn"
+	URNCollectionCertificate       = "certificate"
+	URNCollectionDatabase          = "dbaas"
+	URNCollectionDroplet           = "droplet"
+	URNCollectionFirewall          = "firewall"
+	URNCollectionKubernetesCluster = "kubernetes"
+	URNCollectionLoadBalancer      = "loadbalancer"
+	URNCollectionProject           = "project"
+	URNCollectionVolume            = "volume"
+	URNCollectionVPC               = "vpc"
+)
+
+// AmbiguousNameError is returned when a name given in place of a resource ID
+// matches more than one resource.
+type AmbiguousNameError struct {
+	Kind string
+	Name string
+	IDs  []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%d %ss are named %q; use one of their IDs instead: %s",
+		len(e.IDs), e.Kind, e.Name, strings.Join(e.IDs, ", "))
+}
+
+// NameNotFoundError is returned when a name given in place of a resource ID
+// does not match any resource.
+type NameNotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e *NameNotFoundError) Error() string {
+	return fmt.Sprintf("%s with the ID or name %q could not be found", e.Kind, e.Name)
+}
+
+// namedResource is the subset of a resource needed to match it by name.
+type namedResource struct {
+	id    string
+	names []string
+}
+
+// resolver resolves references to resources of a single type.
+type resolver struct {
+	kind       string
+	collection string
+	// isID reports whether a reference is certainly an ID, so that it is
+	// used without any requests.
+	isID func(string) bool
+	// get, if set, is called with a reference that may be an ID, before the
+	// resources are listed to match it as a name. Only a not found error
+	// falls through to the name lookup.
+	get  func(string) error
+	list func() ([]namedResource, error)
+}
+
+// resolve turns ref, which may be a resource ID, the exact name of a
+// resource, or a URN of the form do:<collection>:<id>, into a resource ID.
+func (r *resolver) resolve(ref string) (string, error) {
+	ids, err := r.resolveAll([]string{ref})
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// resolveAll resolves several references, listing the resources at most
+// once for the ones that are names.
+func (r *resolver) resolveAll(refs []string) ([]string, error) {
+	var list []namedResource
+	listed := false
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, ok, err := r.lookup(ref)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if !listed {
+				if list, err = r.list(); err != nil {
+					return nil, err
+				}
+				listed = true
+			}
+			if id, err = r.match(ref, list); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// lookup returns the ID that ref refers to without listing resources, or
+// false if ref needs to be matched as a name. A ref that may be an ID is
+// looked up as one first, and is only matched as a name if that lookup finds
+// nothing; other errors from the lookup are returned.
+func (r *resolver) lookup(ref string) (string, bool, error) {
+	if u, err := urn.ParseURN(ref); err == nil && u.Namespace() == "do" {
+		if u.Collection() != r.collection {
+			return "", false, fmt.Errorf("%q is the URN of a %s resource, not a %s", ref, u.Collection(), r.kind)
+		}
+		return u.Identifier(), true, nil
+	}
+
+	if r.isID != nil && r.isID(ref) {
+		return ref, true, nil
+	}
+	if r.get != nil {
+		err := r.get(ref)
+		switch {
+		case err == nil:
+			return ref, true, nil
+		case !isNotFound(err):
+			return "", false, err
+		}
+	}
+	return "", false, nil
+}
+
+// match returns the ID of the only resource in list named ref.
+func (r *resolver) match(ref string, list []namedResource) (string, error) {
+	var ids []string
+	for _, res := range list {
+		for _, name := range res.names {
+			if name == ref {
+				ids = append(ids, res.id)
+				break
+			}
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", &NameNotFoundError{Kind: r.kind, Name: ref}
+	case 1:
+		return ids[0], nil
+	default:
+		return "", &AmbiguousNameError{Kind: r.kind, Name: ref, IDs: ids}
+	}
+}
+
+// isNotFound reports whether err is an API response saying that a resource
+// does not exist.
+func isNotFound(err error) bool {
+	var errResponse *godo.ErrorResponse
+	return errors.As(err, &errResponse) && errResponse.Response != nil &&
+		errResponse.Response.StatusCode == http.StatusNotFound
+}
+
+// isUUID reports whether s is a hyphenated UUID, the format used for the IDs
+// of most resource types.
+func isUUID(s string) bool {
+	if _, err := uuid.Parse(s); err != nil {
+		return false
+	}
+	return strings.Contains(s, "-")
+}
+
+func isIntID(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// dropletResolver resolves Droplets. Only Droplets have integer IDs, so any
+// integer is taken to be an ID and anything else is matched as a name.
+func dropletResolver(ds DropletsService) *resolver {
+	return &resolver{
+		kind:       "Droplet",
+		collection: URNCollectionDroplet,
+		isID:       isIntID,
+		list: func() ([]namedResource, error) {
+			list, err := ds.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, d := range list {
+				out = append(out, namedResource{id: strconv.Itoa(d.ID), names: []string{d.Name}})
+			}
+			return out, nil
+		},
+	}
+}
+
+// ResolveDropletID resolves a Droplet ID, name or URN to a Droplet ID.
+func ResolveDropletID(ds DropletsService, ref string) (int, error) {
+	ids, err := ResolveDropletIDs(ds, []string{ref})
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// ResolveDropletIDs resolves Droplet IDs, names or URNs to Droplet IDs, in
+// the same order. The Droplets are listed at most once.
+func ResolveDropletIDs(ds DropletsService, refs []string) ([]int, error) {
+	ids, err := dropletResolver(ds).resolveAll(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		dropletID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Droplet ID %q", id)
+		}
+		out = append(out, dropletID)
+	}
+	return out, nil
+}
+
+// ResolveVolumeID resolves a volume ID, name or URN to a volume ID.
+func ResolveVolumeID(vs VolumesService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "volume",
+		collection: URNCollectionVolume,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := vs.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := vs.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, v := range list {
+				out = append(out, namedResource{id: v.ID, names: []string{v.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveLoadBalancerID resolves a load balancer ID, name or URN to a load
+// balancer ID.
+func ResolveLoadBalancerID(lbs LoadBalancersService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "load balancer",
+		collection: URNCollectionLoadBalancer,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := lbs.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := lbs.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, lb := range list {
+				out = append(out, namedResource{id: lb.ID, names: []string{lb.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveFirewallID resolves a firewall ID, name or URN to a firewall ID.
+func ResolveFirewallID(fs FirewallsService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "firewall",
+		collection: URNCollectionFirewall,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := fs.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := fs.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, f := range list {
+				out = append(out, namedResource{id: f.ID, names: []string{f.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveVPCID resolves a VPC ID, name or URN to a VPC ID.
+func ResolveVPCID(vs VPCsService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "VPC",
+		collection: URNCollectionVPC,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := vs.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := vs.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, v := range list {
+				out = append(out, namedResource{id: v.ID, names: []string{v.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveDatabaseID resolves a database cluster ID, name or URN to a
+// database cluster ID.
+func ResolveDatabaseID(ds DatabasesService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "database cluster",
+		collection: URNCollectionDatabase,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := ds.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := ds.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, d := range list {
+				out = append(out, namedResource{id: d.ID, names: []string{d.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveKubernetesClusterID resolves a Kubernetes cluster ID, name or URN to
+// a Kubernetes cluster ID.
+func ResolveKubernetesClusterID(ks KubernetesService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "Kubernetes cluster",
+		collection: URNCollectionKubernetesCluster,
+		// Kubernetes cluster IDs are always UUIDs, and anything else has
+		// always been matched as a name.
+		isID: isUUID,
+		list: func() ([]namedResource, error) {
+			list, err := ks.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, k := range list {
+				out = append(out, namedResource{id: k.ID, names: []string{k.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveCertificateID resolves a certificate ID, name or URN to a
+// certificate ID.
+func ResolveCertificateID(cs CertificatesService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "certificate",
+		collection: URNCollectionCertificate,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := cs.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := cs.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, c := range list {
+				out = append(out, namedResource{id: c.ID, names: []string{c.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveCDNID resolves a CDN ID or URN to a CDN ID. As CDN endpoints have no
+// name, the origin, endpoint or custom domain may be given instead.
+func ResolveCDNID(cs CDNsService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "CDN",
+		collection: URNCollectionCDN,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := cs.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := cs.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, c := range list {
+				names := []string{c.Origin, c.Endpoint}
+				if c.CustomDomain != "" {
+					names = append(names, c.CustomDomain)
+				}
+				out = append(out, namedResource{id: c.ID, names: names})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveAppID resolves an app ID, name or URN to an app ID.
+func ResolveAppID(as AppsService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "app",
+		collection: URNCollectionApp,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := as.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := as.List(false)
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, a := range list {
+				if a.Spec == nil {
+					continue
+				}
+				out = append(out, namedResource{id: a.ID, names: []string{a.Spec.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
+
+// ResolveProjectID resolves a project ID, name or URN to a project ID.
+func ResolveProjectID(ps ProjectsService, ref string) (string, error) {
+	r := &resolver{
+		kind:       "project",
+		collection: URNCollectionProject,
+		isID:       isUUID,
+		get: func(id string) error {
+			_, err := ps.Get(id)
+			return err
+		},
+		list: func() ([]namedResource, error) {
+			list, err := ps.List()
+			if err != nil {
+				return nil, err
+			}
+			out := make([]namedResource, 0, len(list))
+			for _, p := range list {
+				out = append(out, namedResource{id: p.ID, names: []string{p.Name}})
+			}
+			return out, nil
+		},
+	}
+	return r.resolve(ref)
+}
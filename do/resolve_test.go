@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package do_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/doctl/do"
+	"github.com/digitalocean/doctl/do/mocks"
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// errNotFound is the error returned by Get for an unknown ID.
+var errNotFound = &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+func TestResolveVolumeID(t *testing.T) {
+	const (
+		id      = "506f78a4-e098-11e5-ad9f-000f53306ae1"
+		otherID = "7724db7c-e098-11e5-b522-000f53304e51"
+	)
+	volumes := []do.Volume{
+		{Volume: &godo.Volume{ID: id, Name: "data"}},
+		{Volume: &godo.Volume{ID: otherID, Name: "shared"}},
+		{Volume: &godo.Volume{ID: "0b2fc8f8-e099-11e5-8e3c-000f53306ae1", Name: "shared"}},
+	}
+
+	t.Run("by ID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+
+		got, err := do.ResolveVolumeID(vs, id)
+		require.NoError(t, err)
+		assert.Equal(t, id, got)
+	})
+
+	t.Run("by URN", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+
+		got, err := do.ResolveVolumeID(vs, "do:volume:"+id)
+		require.NoError(t, err)
+		assert.Equal(t, id, got)
+	})
+
+	t.Run("by URN of another collection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+
+		_, err := do.ResolveVolumeID(vs, "do:droplet:1234")
+		assert.EqualError(t, err, `"do:droplet:1234" is the URN of a droplet resource, not a volume`)
+	})
+
+	t.Run("by ID that is not a UUID", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+		vs.EXPECT().Get("some-volume-id").Return(&do.Volume{Volume: &godo.Volume{ID: "some-volume-id"}}, nil)
+
+		got, err := do.ResolveVolumeID(vs, "some-volume-id")
+		require.NoError(t, err)
+		assert.Equal(t, "some-volume-id", got)
+	})
+
+	t.Run("by ID with a failed lookup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+		vs.EXPECT().Get("data").Return(nil, errors.New("unauthorized"))
+
+		_, err := do.ResolveVolumeID(vs, "data")
+		assert.EqualError(t, err, "unauthorized")
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+		vs.EXPECT().Get("data").Return(nil, errNotFound)
+		vs.EXPECT().List().Return(volumes, nil)
+
+		got, err := do.ResolveVolumeID(vs, "data")
+		require.NoError(t, err)
+		assert.Equal(t, id, got)
+	})
+
+	t.Run("by ambiguous name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+		vs.EXPECT().Get("shared").Return(nil, errNotFound)
+		vs.EXPECT().List().Return(volumes, nil)
+
+		_, err := do.ResolveVolumeID(vs, "shared")
+		var ambiguous *do.AmbiguousNameError
+		require.ErrorAs(t, err, &ambiguous)
+		assert.Equal(t, []string{otherID, "0b2fc8f8-e099-11e5-8e3c-000f53306ae1"}, ambiguous.IDs)
+		assert.EqualError(t, err, `2 volumes are named "shared"; use one of their IDs instead: 7724db7c-e098-11e5-b522-000f53304e51, 0b2fc8f8-e099-11e5-8e3c-000f53306ae1`)
+	})
+
+	t.Run("by unknown name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vs := mocks.NewMockVolumesService(ctrl)
+		vs.EXPECT().Get("missing").Return(nil, errNotFound)
+		vs.EXPECT().List().Return(volumes, nil)
+
+		_, err := do.ResolveVolumeID(vs, "missing")
+		assert.EqualError(t, err, `volume with the ID or name "missing" could not be found`)
+	})
+}
+
+func TestResolveDropletID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ds := mocks.NewMockDropletsService(ctrl)
+	ds.EXPECT().List().Return(do.Droplets{
+		{Droplet: &godo.Droplet{ID: 1, Name: "web-1"}},
+	}, nil)
+
+	id, err := do.ResolveDropletID(ds, "do:droplet:42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	id, err = do.ResolveDropletID(ds, "web-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestResolveCDNID(t *testing.T) {
+	const id = "19f06b6a-3ace-4315-b086-499a0e521b76"
+
+	ctrl := gomock.NewController(t)
+	cs := mocks.NewMockCDNsService(ctrl)
+	cs.EXPECT().Get("assets.example.com").Return(nil, errNotFound)
+	cs.EXPECT().Get("static.nyc3.digitaloceanspaces.com").Return(nil, errNotFound)
+	cs.EXPECT().List().Return([]do.CDN{
+		{CDN: &godo.CDN{ID: id, Origin: "static.nyc3.digitaloceanspaces.com", Endpoint: "static.nyc3.cdn.digitaloceanspaces.com", CustomDomain: "assets.example.com"}},
+	}, nil).Times(2)
+
+	got, err := do.ResolveCDNID(cs, "assets.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, id, got)
+
+	got, err = do.ResolveCDNID(cs, "static.nyc3.digitaloceanspaces.com")
+	require.NoError(t, err)
+	assert.Equal(t, id, got)
+}
@@ -210,6 +210,7 @@ type Config interface {
 	GetGodoClient(trace, allowRetries bool, accessToken string) (*godo.Client, error)
 	GetDockerEngineClient() (builder.DockerEngineClient, error)
 	SSH(user, host, keyPath string, port int, opts ssh.Options) runner.Runner
+	SCP(user, host, keyPath string, port int, opts ssh.Options) runner.Runner
 	Listen(url *url.URL, token string, schemaFunc listen.SchemaFunc, out io.Writer) listen.ListenerService
 	Set(ns, key string, val any)
 	IsSet(key string) bool
@@ -312,8 +313,28 @@ func userAgent() string {
 	return fmt.Sprintf("doctl/%s (%s %s)", DoitVersion.String(), runtime.GOOS, runtime.GOARCH)
 }
 
-// SSH creates a ssh connection to a host.
+// SSH creates a ssh connection to a host. The connection runs the ssh
+// binary, so that ~/.ssh/config applies, unless the ssh-client option is
+// "native", or is not set and there is no ssh binary on the PATH, in which
+// case it connects in-process instead.
 func (c *LiveConfig) SSH(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+	forwards, _ := opts[ssh.OptionLocalForwards].([]ssh.Forward)
+	client, _ := opts[ArgSSHClient].(string)
+	if client == "" {
+		client = ssh.DefaultClient()
+	}
+	if client == ssh.ClientNative {
+		if len(forwards) > 0 {
+			return &ssh.Tunnel{Client: sshClient(user, host, keyPath, port, opts), Forwards: forwards}
+		}
+		return sshClient(user, host, keyPath, port, opts)
+	}
+
+	jump, _ := opts[ssh.OptionJump].(*ssh.JumpHost)
+	hostKeyChecking, _ := opts[ArgSSHHostKeyChecking].(string)
+	stdin, _ := opts[ssh.OptionStdin].(io.Reader)
+	stdout, _ := opts[ssh.OptionStdout].(io.Writer)
+	stderr, _ := opts[ssh.OptionStderr].(io.Writer)
 	return &ssh.Runner{
 		User:            user,
 		Host:            host,
@@ -322,7 +343,67 @@ func (c *LiveConfig) SSH(user, host, keyPath string, port int, opts ssh.Options)
 		AgentForwarding: opts[ArgsSSHAgentForwarding].(bool),
 		Command:         opts[ArgSSHCommand].(string),
 		RetriesMax:      opts[ArgSSHRetryMax].(int),
+		HostKeyChecking: hostKeyChecking,
+		Jump:            jump,
+		LocalForwards:   forwards,
+		Stdin:           stdin,
+		Stdout:          stdout,
+		Stderr:          stderr,
+	}
+}
+
+// SCP creates a copy of files to or from a host. The copy always connects
+// in-process, since it does not run the scp binary.
+func (c *LiveConfig) SCP(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+	upload, _ := opts[ssh.OptionSCPUpload].(bool)
+	localPath, _ := opts[ssh.OptionSCPLocalPath].(string)
+	remotePath, _ := opts[ssh.OptionSCPRemotePath].(string)
+	recursive, _ := opts[ArgSCPRecursive].(bool)
+	progress, _ := opts[ssh.OptionSCPProgress].(io.Writer)
+	return &ssh.Copier{
+		Client:     sshClient(user, host, keyPath, port, opts),
+		Upload:     upload,
+		LocalPath:  localPath,
+		RemotePath: remotePath,
+		Recursive:  recursive,
+		Progress:   progress,
+	}
+}
+
+func sshClient(user, host, keyPath string, port int, opts ssh.Options) *ssh.Client {
+	agentForwarding, _ := opts[ArgsSSHAgentForwarding].(bool)
+	command, _ := opts[ArgSSHCommand].(string)
+	retriesMax, _ := opts[ArgSSHRetryMax].(int)
+	hostKeyChecking, _ := opts[ArgSSHHostKeyChecking].(string)
+	stdin, _ := opts[ssh.OptionStdin].(io.Reader)
+	stdout, _ := opts[ssh.OptionStdout].(io.Writer)
+	stderr, _ := opts[ssh.OptionStderr].(io.Writer)
+	client := &ssh.Client{
+		User:            user,
+		Host:            host,
+		KeyPath:         keyPath,
+		Port:            port,
+		AgentForwarding: agentForwarding,
+		Command:         command,
+		RetriesMax:      retriesMax,
+		HostKeyChecking: hostKeyChecking,
+		Stdin:           stdin,
+		Stdout:          stdout,
+		Stderr:          stderr,
+	}
+	if jump, ok := opts[ssh.OptionJump].(*ssh.JumpHost); ok && jump != nil {
+		client.Jump = &ssh.Client{
+			User:            jump.User,
+			Host:            jump.Host,
+			KeyPath:         keyPath,
+			Port:            jump.Port,
+			RetriesMax:      retriesMax,
+			HostKeyChecking: hostKeyChecking,
+			Stdin:           stdin,
+			Stderr:          stderr,
+		}
 	}
+	return client
 }
 
 // Listen creates a websocket connection
@@ -483,6 +564,7 @@ func isRequired(key string) bool {
 // TestConfig is an implementation of Config for testing.
 type TestConfig struct {
 	SSHFn              func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner
+	SCPFn              func(user, host, keyPath string, port int, opts ssh.Options) runner.Runner
 	ListenFn           func(url *url.URL, token string, schemaFunc listen.SchemaFunc, out io.Writer) listen.ListenerService
 	v                  *viper.Viper
 	IsSetMap           map[string]bool
@@ -497,6 +579,9 @@ func NewTestConfig() *TestConfig {
 		SSHFn: func(u, h, kp string, p int, opts ssh.Options) runner.Runner {
 			return &MockRunner{}
 		},
+		SCPFn: func(u, h, kp string, p int, opts ssh.Options) runner.Runner {
+			return &MockRunner{}
+		},
 		ListenFn: func(url *url.URL, token string, schemaFunc listen.SchemaFunc, out io.Writer) listen.ListenerService {
 			return &MockListener{}
 		},
@@ -522,6 +607,11 @@ func (c *TestConfig) SSH(user, host, keyPath string, port int, opts ssh.Options)
 	return c.SSHFn(user, host, keyPath, port, opts)
 }
 
+// SCP returns a mock copy of files.
+func (c *TestConfig) SCP(user, host, keyPath string, port int, opts ssh.Options) runner.Runner {
+	return c.SCPFn(user, host, keyPath, port, opts)
+}
+
 // Listen returns a mock websocket listener
 func (c *TestConfig) Listen(url *url.URL, token string, schemaFunc listen.SchemaFunc, out io.Writer) listen.ListenerService {
 	return c.ListenFn(url, token, schemaFunc, out)
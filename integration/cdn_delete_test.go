@@ -30,6 +30,12 @@ var _ = suite("compute/cdn/delete", func(t *testing.T, when spec.G, it spec.S) {
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"endpoint":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodDelete {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
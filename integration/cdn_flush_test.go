@@ -49,6 +49,14 @@ var _ = suite("compute/cdn/flush", func(t *testing.T, when spec.G, it spec.S) {
 				expect.Contains(request.Files, "*")
 
 				w.WriteHeader(http.StatusNoContent)
+			case "/v2/cdn/endpoints/some-cdn-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"endpoint":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
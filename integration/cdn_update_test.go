@@ -33,6 +33,12 @@ var _ = suite("compute/cdn/update", func(t *testing.T, when spec.G, it spec.S) {
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"endpoint":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodPut {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/digitalocean/doctl/pkg/certcheck/certchecktest"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +22,8 @@ var _ = suite("compute/certificate/create", func(t *testing.T, when spec.G, it s
 	var (
 		expect *require.Assertions
 		server *httptest.Server
+
+		certCustomCreateJSONReq string
 	)
 
 	it.Before(func() {
@@ -82,14 +86,29 @@ var _ = suite("compute/certificate/create", func(t *testing.T, when spec.G, it s
 
 	when("asking for a custom cert", func() {
 		it("creates the certificate", func() {
+			// The files are checked before they are uploaded, so they must
+			// hold a valid certificate chain and its key.
+			leaf, intermediate, _, err := certchecktest.NewChain("example.com")
+			expect.NoError(err)
 			testFiles := []string{"test-private.key", "test-cert.chain", "test-leaf.cert"}
+			contents := []string{leaf.KeyPEM(), certchecktest.PEM(intermediate), certchecktest.PEM(leaf)}
 			dir := t.TempDir()
 
-			for _, f := range testFiles {
-				err := os.WriteFile(filepath.Join(dir, f), []byte(f), 0600)
+			for i, f := range testFiles {
+				err := os.WriteFile(filepath.Join(dir, f), []byte(contents[i]), 0600)
 				expect.NoError(err)
 			}
 
+			req, err := json.Marshal(map[string]string{
+				"name":              "test-cert",
+				"private_key":       contents[0],
+				"certificate_chain": contents[1],
+				"leaf_certificate":  contents[2],
+				"type":              "custom",
+			})
+			expect.NoError(err)
+			certCustomCreateJSONReq = string(req)
+
 			cmd := exec.Command(builtBinaryPath,
 				"-t", "some-magic-token",
 				"-u", server.URL,
@@ -113,15 +132,6 @@ const (
 	certCreateOutput = `
 ID                                      Name           DNS Names    SHA-1 Fingerprint                           Expiration Date         Created At              Type      State
 892071a0-bb95-49bc-8021-3afd67a210bf    web-cert-01                 dfcc9f57d86bf58e321c2c6c31c7a971be244ac7    2017-02-22T00:23:00Z    2017-02-08T16:02:37Z    custom    verified
-`
-	certCustomCreateJSONReq = `
-{
-  "name":"test-cert",
-  "private_key":"test-private.key",
-  "leaf_certificate":"test-leaf.cert",
-  "certificate_chain":"test-cert.chain",
-  "type":"custom"
-}
 `
 	certLECreateReqJSONReq = `
 {
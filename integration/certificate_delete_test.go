@@ -31,6 +31,12 @@ var _ = suite("compute/certificate/delete", func(t *testing.T, when spec.G, it s
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"certificate":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodDelete {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
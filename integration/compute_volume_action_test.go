@@ -105,6 +105,14 @@ var _ = suite("compute/volume-action", func(t *testing.T, when spec.G, it spec.S
 				}
 
 				w.Write([]byte(volumeActionsResponse))
+			case "/v2/volumes/1", "/v2/volumes/22", "/v2/volumes/13", "/v2/volumes/1213":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"volume":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
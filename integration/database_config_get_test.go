@@ -60,6 +60,14 @@ var _ = suite("database/config/get", func(t *testing.T, when spec.G, it spec.S)
 				}
 
 				w.Write([]byte(databaseConfigRedisGetResponse))
+			case "/v2/databases/mysql-database-id", "/v2/databases/pg-database-id", "/v2/databases/redis-database-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"database":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
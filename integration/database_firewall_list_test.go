@@ -37,6 +37,14 @@ var _ = suite("database/firewalls", func(t *testing.T, when spec.G, it spec.S) {
 				}
 
 				w.Write([]byte(databasesListFirewallRuleResponse))
+			case "/v2/databases/1213":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"database":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
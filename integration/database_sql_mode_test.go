@@ -51,6 +51,14 @@ var _ = suite("database/sql-mode/set", func(t *testing.T, when spec.G, it spec.S
 				expect.NoError(err)
 
 				w.WriteHeader(http.StatusNoContent)
+			case "/v2/databases/some-database-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"database":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
@@ -115,6 +123,14 @@ var _ = suite("database/sql-mode/get", func(t *testing.T, when spec.G, it spec.S
 
 				w.WriteHeader(http.StatusOK)
 				w.Write(buffer.Bytes())
+			case "/v2/databases/some-database-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"database":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
@@ -36,6 +36,14 @@ var _ = suite("database/user/get", func(t *testing.T, when spec.G, it spec.S) {
 				}
 
 				w.Write([]byte(databaseUserGetResponse))
+			case "/v2/databases/some-database-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"database":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
@@ -87,6 +87,9 @@ var _ = suite("compute/domain/records/create", func(t *testing.T, when spec.G, i
 					"example.com",
 					"--record-name", "example.com",
 					"--record-type", "SRV",
+					"--record-data", "sip.example.com.",
+					"--record-priority", "0",
+					"--record-weight", "0",
 					"--record-port", "0",
 				)
 
@@ -138,7 +141,7 @@ ID    Type    Name           Data    Priority    Port    TTL    Weight
 }
 `
 	domainRecordsCreateRequest = `
-{"flags":0, "name":"example.com", "port":0, "priority":0, "ttl":1800, "type":"SRV", "weight":0}
+{"data":"sip.example.com.", "flags":0, "name":"example.com", "port":0, "priority":0, "ttl":1800, "type":"SRV", "weight":0}
 `
 
 	domainRecordsCreateWithoutPortOutput = `
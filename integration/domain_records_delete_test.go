@@ -109,7 +109,7 @@ var _ = suite("compute/domain/records/delete", func(t *testing.T, when spec.G, i
 
 			output, err := cmd.CombinedOutput()
 			expect.NoError(err, fmt.Sprintf("received error output: %s", output))
-			expect.Empty(output)
+			expect.Equal(strings.TrimSpace(domainRecordsDeleteMultipleOutput), strings.TrimSpace(string(output)))
 		})
 	})
 
@@ -152,3 +152,9 @@ var _ = suite("compute/domain/records/delete", func(t *testing.T, when spec.G, i
 		})
 	})
 })
+
+const domainRecordsDeleteMultipleOutput = `
+ID      Status    Error
+1337    ok        
+7331    ok        
+`
@@ -135,6 +135,25 @@ var _ = suite("compute/droplet/delete", func(t *testing.T, when spec.G, it spec.
 		})
 	})
 
+	when("reading Droplet IDs from stdin", func() {
+		it("deletes the listed Droplets", func() {
+			cmd := exec.Command(builtBinaryPath,
+				"-t", "some-magic-token",
+				"-u", server.URL,
+				"compute",
+				"droplet",
+				"delete",
+				"--from-file", "-",
+				"--force",
+			)
+			cmd.Stdin = strings.NewReader(`{"id":1337,"name":"some-droplet-name"}` + "\n")
+
+			output, err := cmd.CombinedOutput()
+			expect.NoError(err, fmt.Sprintf("received error output: %s", output))
+			expect.Empty(output)
+		})
+	})
+
 	when("deleting one Droplet without force flag", func() {
 		it("errors without confirmation", func() {
 			cmd := exec.Command(builtBinaryPath,
@@ -30,6 +30,12 @@ var _ = suite("compute/firewall/delete", func(t *testing.T, when spec.G, it spec
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"firewall":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodDelete {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
@@ -43,6 +49,12 @@ var _ = suite("compute/firewall/delete", func(t *testing.T, when spec.G, it spec
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"firewall":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodDelete {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
@@ -93,7 +105,7 @@ var _ = suite("compute/firewall/delete", func(t *testing.T, when spec.G, it spec
 
 			output, err := cmd.CombinedOutput()
 			expect.NoError(err, fmt.Sprintf("received unexpected error: %s", output))
-			expect.Empty(output)
+			expect.Equal(strings.TrimSpace(firewallDeleteMultipleOutput), strings.TrimSpace(string(output)))
 		})
 	})
 
@@ -132,3 +144,9 @@ var _ = suite("compute/firewall/delete", func(t *testing.T, when spec.G, it spec
 		})
 	})
 })
+
+const firewallDeleteMultipleOutput = `
+ID                                      Status    Error
+e4b9c960-d385-4950-84f3-d102162e6be5    ok        
+aaa-bbb-ccc-ddd-eee                     ok        
+`
@@ -93,7 +93,7 @@ var _ = suite("compute/image/delete", func(t *testing.T, when spec.G, it spec.S)
 
 			output, err := cmd.CombinedOutput()
 			expect.NoError(err, fmt.Sprintf("received unexpected error: %s", output))
-			expect.Empty(output)
+			expect.Equal(strings.TrimSpace(imageDeleteMultipleOutput), strings.TrimSpace(string(output)))
 		})
 	})
 
@@ -132,3 +132,9 @@ var _ = suite("compute/image/delete", func(t *testing.T, when spec.G, it spec.S)
 		})
 	})
 })
+
+const imageDeleteMultipleOutput = `
+ID      Status    Error
+1111    ok        
+2222    ok        
+`
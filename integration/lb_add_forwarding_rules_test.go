@@ -42,6 +42,14 @@ var _ = suite("compute/load-balancer/add-forwarding-rules", func(t *testing.T, w
 				expect.JSONEq(lbAddForwardingRulesRequest, string(reqBody))
 
 				w.WriteHeader(http.StatusNoContent)
+			case "/v2/load_balancers/that-lb-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"load_balancer":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
@@ -32,6 +32,12 @@ var _ = suite("compute/load-balancer/delete", func(t *testing.T, when spec.G, it
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"load_balancer":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodDelete {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
@@ -42,6 +42,14 @@ var _ = suite("compute/load-balancer/remove-forwarding-rules", func(t *testing.T
 				expect.JSONEq(lbRemoveForwardingRulesRequest, string(reqBody))
 
 				w.WriteHeader(http.StatusNoContent)
+			case "/v2/load_balancers/basic-load-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"load_balancer":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
@@ -34,6 +34,12 @@ var _ = suite("compute/load-balancer/update", func(t *testing.T, when spec.G, it
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"load_balancer":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodPut {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
@@ -105,7 +105,7 @@ var _ = suite("compute/snapshot/delete", func(t *testing.T, when spec.G, it spec
 
 			output, err := cmd.CombinedOutput()
 			expect.NoError(err, fmt.Sprintf("received unexpected error: %s", output))
-			expect.Empty(output)
+			expect.Equal(strings.TrimSpace(snapshotDeleteMultipleOutput), strings.TrimSpace(string(output)))
 		})
 	})
 
@@ -144,3 +144,9 @@ var _ = suite("compute/snapshot/delete", func(t *testing.T, when spec.G, it spec
 		})
 	})
 })
+
+const snapshotDeleteMultipleOutput = `
+ID          Status    Error
+53344211    ok        
+123456      ok        
+`
@@ -105,7 +105,7 @@ var _ = suite("compute/tags/delete", func(t *testing.T, when spec.G, it spec.S)
 
 			output, err := cmd.CombinedOutput()
 			expect.NoError(err, fmt.Sprintf("received error output: %s", output))
-			expect.Empty(output)
+			expect.Equal(strings.TrimSpace(tagsDeleteMultipleOutput), strings.TrimSpace(string(output)))
 		})
 	})
 
@@ -144,3 +144,9 @@ var _ = suite("compute/tags/delete", func(t *testing.T, when spec.G, it spec.S)
 		})
 	})
 })
+
+const tagsDeleteMultipleOutput = `
+ID     Status    Error
+foo    ok        
+bar    ok        
+`
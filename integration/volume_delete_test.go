@@ -31,6 +31,12 @@ var _ = suite("compute/volume/delete", func(t *testing.T, when spec.G, it spec.S
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"volume":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodDelete {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
@@ -48,6 +48,14 @@ var _ = suite("compute/volume/snapshot", func(t *testing.T, when spec.G, it spec
 				expect.JSONEq(volumeSnapshotRequest, string(reqBody))
 
 				w.Write([]byte(volumeSnapshotResponse))
+			case "/v2/volumes/my-volume-id":
+				// The ID is looked up before it is used.
+				if req.Method != http.MethodGet {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+
+				w.Write([]byte(`{"volume":{}}`))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				if err != nil {
@@ -35,6 +35,12 @@ var _ = suite("vpcs/update", func(t *testing.T, when spec.G, it spec.S) {
 					return
 				}
 
+				if req.Method == http.MethodGet {
+					// The ID is looked up before it is used.
+					w.Write([]byte(`{"vpc":{}}`))
+					return
+				}
+
 				if req.Method != http.MethodPatch {
 					w.WriteHeader(http.StatusMethodNotAllowed)
 					return
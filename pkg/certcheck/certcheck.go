@@ -0,0 +1,266 @@
+// Package certcheck parses PEM-encoded certificates and private keys and
+// finds the problems that would make a certificate upload fail or leave
+// clients unable to trust it.
+package certcheck
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ExpiryWarning is how close to expiry a certificate must be for Check to
+// warn about it.
+const ExpiryWarning = 30 * 24 * time.Hour
+
+// Report is the result of checking a certificate, its chain and its key.
+type Report struct {
+	Leaf *x509.Certificate
+	// Chain holds the certificates that issued Leaf, in order from the
+	// leaf's issuer towards the root. Certificates that were given but are
+	// not part of the chain are left out.
+	Chain []*x509.Certificate
+	// Reordered is set when the chain was given in a different order.
+	Reordered bool
+
+	// Errors are problems that the upload would fail on or that make the
+	// certificate unusable. Warnings are problems that clients may notice.
+	Errors   []string
+	Warnings []string
+}
+
+// ParseCertificates returns the certificates in the PEM data, in order.
+// Blocks that are not certificates are skipped.
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// ParsePrivateKey returns the first private key in the PEM data, or nil if
+// there is none. PKCS #1, PKCS #8 and SEC 1 keys are supported.
+func ParsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, nil
+		}
+		switch block.Type {
+		case "PRIVATE KEY":
+			return parseKey(x509.ParsePKCS8PrivateKey(block.Bytes))
+		case "RSA PRIVATE KEY":
+			return parseKey(x509.ParsePKCS1PrivateKey(block.Bytes))
+		case "EC PRIVATE KEY":
+			return parseKey(x509.ParseECPrivateKey(block.Bytes))
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, errors.New("the private key is encrypted; decrypt it before uploading")
+		}
+	}
+}
+
+func parseKey[K any](key K, err error) (crypto.PrivateKey, error) {
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return key, nil
+}
+
+// FindLeaf picks the end-entity certificate out of certs: one that did not
+// issue any of the others, preferring one that matches key. The remaining
+// certificates are returned in their original order.
+func FindLeaf(certs []*x509.Certificate, key crypto.PrivateKey) (*x509.Certificate, []*x509.Certificate) {
+	leaf := -1
+	for i, c := range certs {
+		if issuesAny(c, certs) {
+			continue
+		}
+		if leaf < 0 || (key != nil && keyMatches(c, key)) {
+			leaf = i
+		}
+	}
+	if leaf < 0 {
+		if len(certs) == 0 {
+			return nil, nil
+		}
+		leaf = 0
+	}
+
+	rest := make([]*x509.Certificate, 0, len(certs)-1)
+	rest = append(rest, certs[:leaf]...)
+	rest = append(rest, certs[leaf+1:]...)
+	return certs[leaf], rest
+}
+
+func issuesAny(issuer *x509.Certificate, certs []*x509.Certificate) bool {
+	for _, c := range certs {
+		if c != issuer && !c.Equal(issuer) && c.CheckSignatureFrom(issuer) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Check orders chain from the leaf's issuer upwards and checks that each
+// certificate signed the one before it, that key matches leaf, and that
+// every certificate is currently valid and uses a strong key. key may be nil
+// to skip the key check.
+func Check(leaf *x509.Certificate, chain []*x509.Certificate, key crypto.PrivateKey, now time.Time) *Report {
+	r := &Report{Leaf: leaf}
+
+	if key != nil && !keyMatches(leaf, key) {
+		r.Errors = append(r.Errors, fmt.Sprintf("the private key does not match the certificate %s", quoted(leaf)))
+	}
+
+	remaining := append([]*x509.Certificate(nil), chain...)
+	for cur := leaf; !selfSigned(cur); {
+		i := issuerIndex(cur, remaining)
+		if i < 0 {
+			break
+		}
+		cur = remaining[i]
+		r.Chain = append(r.Chain, cur)
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+	for i, c := range r.Chain {
+		if i >= len(chain) || !c.Equal(chain[i]) {
+			r.Reordered = true
+			break
+		}
+	}
+
+	switch {
+	case len(chain) > 0 && len(r.Chain) == 0 && !selfSigned(leaf):
+		r.Errors = append(r.Errors, fmt.Sprintf("the chain does not contain the issuer of %s (%s)", quoted(leaf), leaf.Issuer))
+	case len(chain) == 0 && selfSigned(leaf):
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s is self-signed; clients will not trust it", quoted(leaf)))
+	case len(chain) == 0:
+		r.Warnings = append(r.Warnings, "no certificate chain was given; clients that do not already have the intermediate certificates will not trust the certificate")
+	}
+	for _, c := range remaining {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s is not part of the chain for %s and was left out", quoted(c), quoted(leaf)))
+	}
+
+	if len(leaf.DNSNames) == 0 && len(leaf.IPAddresses) == 0 {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s has no subject alternative names; browsers ignore the common name", quoted(leaf)))
+	}
+
+	for _, c := range append([]*x509.Certificate{leaf}, r.Chain...) {
+		r.checkValidity(c, now)
+		r.checkStrength(c)
+	}
+	return r
+}
+
+func (r *Report) checkValidity(c *x509.Certificate, now time.Time) {
+	switch {
+	case now.After(c.NotAfter):
+		r.Errors = append(r.Errors, fmt.Sprintf("%s expired on %s", quoted(c), c.NotAfter.Format(time.RFC3339)))
+	case now.Before(c.NotBefore):
+		r.Errors = append(r.Errors, fmt.Sprintf("%s is not valid until %s", quoted(c), c.NotBefore.Format(time.RFC3339)))
+	case c.NotAfter.Sub(now) < ExpiryWarning:
+		days := int(c.NotAfter.Sub(now).Hours() / 24)
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s expires on %s, in %d days", quoted(c), c.NotAfter.Format(time.RFC3339), days))
+	}
+}
+
+func (r *Report) checkStrength(c *x509.Certificate) {
+	switch k := c.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("%s has a weak %s key; use at least 2048 bits", quoted(c), KeyDescription(c)))
+		}
+	case *ecdsa.PublicKey:
+		if k.Curve.Params().BitSize < 256 {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("%s has a weak %s key; use at least P-256", quoted(c), KeyDescription(c)))
+		}
+	}
+	if selfSigned(c) {
+		// Clients do not check the signature of a trusted root.
+		return
+	}
+	switch c.SignatureAlgorithm {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%s is signed with %s, which clients reject", quoted(c), c.SignatureAlgorithm))
+	}
+}
+
+// EncodeCertificates returns the certificates as concatenated PEM blocks.
+func EncodeCertificates(certs []*x509.Certificate) string {
+	var b bytes.Buffer
+	for _, c := range certs {
+		pem.Encode(&b, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})
+	}
+	return b.String()
+}
+
+// Name returns a short name for a certificate: its common name, or its full
+// subject if it has no common name.
+func Name(c *x509.Certificate) string {
+	if c.Subject.CommonName != "" {
+		return c.Subject.CommonName
+	}
+	return c.Subject.String()
+}
+
+func quoted(c *x509.Certificate) string {
+	return fmt.Sprintf("%q", Name(c))
+}
+
+// KeyDescription describes the public key of a certificate, such as
+// "RSA 2048" or "ECDSA P-256".
+func KeyDescription(c *x509.Certificate) string {
+	switch k := c.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA " + k.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return c.PublicKeyAlgorithm.String()
+	}
+}
+
+func keyMatches(c *x509.Certificate, key crypto.PrivateKey) bool {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return false
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && pub.Equal(c.PublicKey)
+}
+
+func issuerIndex(c *x509.Certificate, candidates []*x509.Certificate) int {
+	for i, issuer := range candidates {
+		if c.CheckSignatureFrom(issuer) == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+func selfSigned(c *x509.Certificate) bool {
+	return bytes.Equal(c.RawIssuer, c.RawSubject) && c.CheckSignature(c.SignatureAlgorithm, c.RawTBSCertificate, c.Signature) == nil
+}
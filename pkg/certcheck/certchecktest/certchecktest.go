@@ -0,0 +1,119 @@
+// Package certchecktest creates certificates and keys for tests.
+package certchecktest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+)
+
+// Certificate is a certificate and its private key.
+type Certificate struct {
+	*x509.Certificate
+	Key crypto.Signer
+}
+
+// New creates a certificate from tmpl, signed by issuer, or self-signed if
+// issuer is nil. The certificate gets a new P-256 key unless key is set.
+// Unset serial numbers and validity periods default to a random serial
+// valid from an hour ago for a year.
+func New(tmpl *x509.Certificate, issuer *Certificate, key crypto.Signer) (*Certificate, error) {
+	if key == nil {
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	t := *tmpl
+	if t.SerialNumber == nil {
+		serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+		if err != nil {
+			return nil, err
+		}
+		t.SerialNumber = serial
+	}
+	if t.NotBefore.IsZero() {
+		t.NotBefore = time.Now().Add(-time.Hour)
+	}
+	if t.NotAfter.IsZero() {
+		t.NotAfter = time.Now().Add(365 * 24 * time.Hour)
+	}
+
+	parent, signer := &t, key
+	if issuer != nil {
+		parent, signer = issuer.Certificate, issuer.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &t, parent, key.Public(), signer)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{Certificate: cert, Key: key}, nil
+}
+
+// NewCA creates a certificate authority named name, signed by issuer or
+// self-signed if issuer is nil.
+func NewCA(name string, issuer *Certificate) (*Certificate, error) {
+	return New(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, issuer, nil)
+}
+
+// NewChain creates a leaf certificate for dnsNames issued by an
+// intermediate, which is issued by a root.
+func NewChain(dnsNames ...string) (leaf, intermediate, root *Certificate, err error) {
+	root, err = NewCA("Test Root", nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	intermediate, err = NewCA("Test Intermediate", root)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	name := "leaf"
+	if len(dnsNames) > 0 {
+		name = dnsNames[0]
+	}
+	leaf, err = New(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		DNSNames:    dnsNames,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, intermediate, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return leaf, intermediate, root, nil
+}
+
+// PEM returns the certificates as concatenated PEM blocks.
+func PEM(certs ...*Certificate) string {
+	var b bytes.Buffer
+	for _, c := range certs {
+		pem.Encode(&b, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})
+	}
+	return b.String()
+}
+
+// KeyPEM returns the certificate's private key as a PKCS #8 PEM block.
+func (c *Certificate) KeyPEM() string {
+	der, err := x509.MarshalPKCS8PrivateKey(c.Key)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dnsquery sends DNS queries to a specific server, such as an
+// authoritative name server or a public resolver, and returns the answers in
+// a presentation form that can be compared across servers.
+package dnsquery
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl/pkg/zonefile"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// typeCAA is the CAA record type, which dnsmessage does not define.
+const typeCAA dnsmessage.Type = 257
+
+// Types maps the supported record type names to their DNS types.
+var Types = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CAA":   typeCAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"NS":    dnsmessage.TypeNS,
+	"SRV":   dnsmessage.TypeSRV,
+	"TXT":   dnsmessage.TypeTXT,
+}
+
+// Server returns addr with the DNS port added if it has none.
+func Server(addr string) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	return net.JoinHostPort(strings.Trim(addr, "[]"), "53")
+}
+
+// Query asks server for the records of type recordType at name and returns
+// the data of each answer in the form described by Format. server is a
+// host, optionally with a port. A name that does not exist has no answers
+// and is not an error. The query is retried over TCP if the UDP answer is
+// truncated.
+func Query(ctx context.Context, server, name, recordType string) ([]string, error) {
+	qtype, ok := Types[strings.ToUpper(recordType)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+	qname, err := dnsmessage.NewName(zonefile.Fqdn(name))
+	if err != nil {
+		return nil, err
+	}
+
+	id := uint16(rand.Intn(1 << 16))
+	query, err := (&dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}).Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	server = Server(server)
+	resp, err := exchange(ctx, "udp", server, query)
+	if err == nil && resp.Truncated {
+		resp, err = exchange(ctx, "tcp", server, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.ID != id {
+		return nil, fmt.Errorf("%s: mismatched response ID", server)
+	}
+
+	switch resp.RCode {
+	case dnsmessage.RCodeSuccess, dnsmessage.RCodeNameError:
+	default:
+		return nil, fmt.Errorf("%s: %s", server, strings.TrimPrefix(resp.RCode.String(), "RCode"))
+	}
+
+	var answers []string
+	for _, rr := range resp.Answers {
+		if rr.Header.Type != qtype || !strings.EqualFold(rr.Header.Name.String(), qname.String()) {
+			continue
+		}
+		data, err := format(rr.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", server, err)
+		}
+		answers = append(answers, data)
+	}
+	return answers, nil
+}
+
+func exchange(ctx context.Context, network, server string, query []byte) (*dnsmessage.Message, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	var buf []byte
+	if network == "tcp" {
+		msg := binary.BigEndian.AppendUint16(nil, uint16(len(query)))
+		if _, err := conn.Write(append(msg, query...)); err != nil {
+			return nil, err
+		}
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return nil, err
+		}
+		buf = make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		buf = make([]byte, 65535)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[:n]
+	}
+
+	var resp dnsmessage.Message
+	if err := resp.Unpack(buf); err != nil {
+		return nil, fmt.Errorf("%s: invalid response: %w", server, err)
+	}
+	return &resp, nil
+}
+
+// format returns the presentation form of a record's data. Host names are
+// lowercase and fully qualified, MX data is "preference host", SRV data is
+// "priority weight port target", CAA data is "flags tag value", and the
+// strings of a TXT record are concatenated.
+func format(body dnsmessage.ResourceBody) (string, error) {
+	switch b := body.(type) {
+	case *dnsmessage.AResource:
+		return net.IP(b.A[:]).String(), nil
+	case *dnsmessage.AAAAResource:
+		return net.IP(b.AAAA[:]).String(), nil
+	case *dnsmessage.CNAMEResource:
+		return strings.ToLower(b.CNAME.String()), nil
+	case *dnsmessage.NSResource:
+		return strings.ToLower(b.NS.String()), nil
+	case *dnsmessage.MXResource:
+		return fmt.Sprintf("%d %s", b.Pref, strings.ToLower(b.MX.String())), nil
+	case *dnsmessage.SRVResource:
+		return fmt.Sprintf("%d %d %d %s", b.Priority, b.Weight, b.Port, strings.ToLower(b.Target.String())), nil
+	case *dnsmessage.TXTResource:
+		return strings.Join(b.TXT, ""), nil
+	case *dnsmessage.UnknownResource:
+		if b.Type == typeCAA {
+			return formatCAA(b.Data)
+		}
+	}
+	return "", fmt.Errorf("unexpected %T in answer", body)
+}
+
+// formatCAA decodes CAA record data, as described in RFC 8659.
+func formatCAA(data []byte) (string, error) {
+	if len(data) < 2 || len(data) < 2+int(data[1]) {
+		return "", errors.New("invalid CAA record")
+	}
+	flags, tagLen := data[0], int(data[1])
+	tag, value := data[2:2+tagLen], data[2+tagLen:]
+	return strconv.Itoa(int(flags)) + " " + strings.ToLower(string(tag)) + " " + string(value), nil
+}
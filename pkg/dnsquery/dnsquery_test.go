@@ -0,0 +1,65 @@
+package dnsquery_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/doctl/pkg/dnsquery"
+	"github.com/digitalocean/doctl/pkg/dnsquery/dnsquerytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	srv, err := dnsquerytest.NewServer()
+	require.NoError(t, err)
+	defer srv.Close()
+
+	long := strings.Repeat("a", 300)
+	srv.Set("example.com", "A", "192.0.2.1", "192.0.2.2")
+	srv.Set("example.com", "AAAA", "2001:db8::1")
+	srv.Set("www.example.com", "CNAME", "Example.COM.")
+	srv.Set("example.com", "MX", "10 mx1.example.net.")
+	srv.Set("_sip._tcp.example.com", "SRV", "10 60 5060 sip.example.com.")
+	srv.Set("example.com", "TXT", long)
+	srv.Set("example.com", "CAA", "0 issue letsencrypt.org")
+
+	tests := []struct {
+		name, recordType string
+		expected         []string
+	}{
+		{"example.com", "A", []string{"192.0.2.1", "192.0.2.2"}},
+		{"example.com", "aaaa", []string{"2001:db8::1"}},
+		{"WWW.example.com.", "CNAME", []string{"example.com."}},
+		{"example.com", "MX", []string{"10 mx1.example.net."}},
+		{"_sip._tcp.example.com", "SRV", []string{"10 60 5060 sip.example.com."}},
+		{"example.com", "TXT", []string{long}},
+		{"example.com", "CAA", []string{"0 issue letsencrypt.org"}},
+		{"missing.example.com", "A", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.recordType, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			answers, err := dnsquery.Query(ctx, srv.Addr, tt.name, tt.recordType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, answers)
+		})
+	}
+}
+
+func TestQueryUnsupportedType(t *testing.T) {
+	_, err := dnsquery.Query(context.Background(), "127.0.0.1", "example.com", "HINFO")
+	assert.EqualError(t, err, `unsupported record type "HINFO"`)
+}
+
+func TestServer(t *testing.T) {
+	assert.Equal(t, "192.0.2.1:53", dnsquery.Server("192.0.2.1"))
+	assert.Equal(t, "[2001:db8::1]:53", dnsquery.Server("2001:db8::1"))
+	assert.Equal(t, "127.0.0.1:5353", dnsquery.Server("127.0.0.1:5353"))
+	assert.Equal(t, "ns1.digitalocean.com:53", dnsquery.Server("ns1.digitalocean.com"))
+}
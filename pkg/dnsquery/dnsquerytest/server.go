@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dnsquerytest provides a local DNS server for tests.
+package dnsquerytest
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/doctl/pkg/dnsquery"
+	"github.com/digitalocean/doctl/pkg/zonefile"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Server is a DNS server listening on a local UDP port. It answers queries
+// from the records added with Set, in the presentation form returned by
+// dnsquery.Query.
+type Server struct {
+	// Addr is the server's address, in host:port form.
+	Addr string
+
+	conn    net.PacketConn
+	mu      sync.Mutex
+	records map[string][]string
+}
+
+// NewServer starts a server with no records. Callers should Close it when
+// they are done.
+func NewServer() (*Server, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{Addr: conn.LocalAddr().String(), conn: conn, records: map[string][]string{}}
+	go s.serve()
+	return s, nil
+}
+
+// Set replaces the answers for name and recordType.
+func (s *Server) Set(name, recordType string, values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key(name, recordType)] = values
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.conn.Close()
+}
+
+func key(name, recordType string) string {
+	return strings.ToLower(zonefile.Fqdn(name)) + " " + strings.ToUpper(recordType)
+}
+
+func (s *Server) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		resp, err := s.answer(buf[:n])
+		if err != nil {
+			continue
+		}
+		s.conn.WriteTo(resp, addr)
+	}
+}
+
+func (s *Server) answer(query []byte) ([]byte, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(query); err != nil || len(msg.Questions) != 1 {
+		return nil, fmt.Errorf("invalid query")
+	}
+	q := msg.Questions[0]
+
+	var recordType string
+	for name, t := range dnsquery.Types {
+		if t == q.Type {
+			recordType = name
+		}
+	}
+
+	s.mu.Lock()
+	values, ok := s.records[key(q.Name.String(), recordType)]
+	s.mu.Unlock()
+
+	resp := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: msg.ID, Response: true, Authoritative: true},
+		Questions: msg.Questions,
+	}
+	if !ok {
+		resp.RCode = dnsmessage.RCodeNameError
+	}
+	for _, v := range values {
+		body, err := parse(q.Type, v)
+		if err != nil {
+			return nil, err
+		}
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: 300},
+			Body:   body,
+		})
+	}
+	return resp.Pack()
+}
+
+func parse(t dnsmessage.Type, v string) (dnsmessage.ResourceBody, error) {
+	fields := strings.Fields(v)
+	name := func(i int) (dnsmessage.Name, error) {
+		if i >= len(fields) {
+			return dnsmessage.Name{}, fmt.Errorf("invalid data %q", v)
+		}
+		return dnsmessage.NewName(zonefile.Fqdn(fields[i]))
+	}
+	num := func(i int) uint16 {
+		n, _ := strconv.Atoi(fields[i])
+		return uint16(n)
+	}
+
+	switch t {
+	case dnsmessage.TypeA:
+		var b dnsmessage.AResource
+		copy(b.A[:], net.ParseIP(v).To4())
+		return &b, nil
+	case dnsmessage.TypeAAAA:
+		var b dnsmessage.AAAAResource
+		copy(b.AAAA[:], net.ParseIP(v).To16())
+		return &b, nil
+	case dnsmessage.TypeCNAME:
+		n, err := name(0)
+		return &dnsmessage.CNAMEResource{CNAME: n}, err
+	case dnsmessage.TypeNS:
+		n, err := name(0)
+		return &dnsmessage.NSResource{NS: n}, err
+	case dnsmessage.TypeMX:
+		n, err := name(1)
+		if err != nil {
+			return nil, err
+		}
+		return &dnsmessage.MXResource{Pref: num(0), MX: n}, nil
+	case dnsmessage.TypeSRV:
+		n, err := name(3)
+		if err != nil {
+			return nil, err
+		}
+		return &dnsmessage.SRVResource{Priority: num(0), Weight: num(1), Port: num(2), Target: n}, nil
+	case dnsmessage.TypeTXT:
+		var chunks []string
+		for len(v) > 255 {
+			chunks = append(chunks, v[:255])
+			v = v[255:]
+		}
+		return &dnsmessage.TXTResource{TXT: append(chunks, v)}, nil
+	case dnsquery.Types["CAA"]:
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid data %q", v)
+		}
+		data := []byte{byte(num(0)), byte(len(fields[1]))}
+		data = append(data, fields[1]...)
+		data = append(data, strings.Join(fields[2:], " ")...)
+		return &dnsmessage.UnknownResource{Type: t, Data: data}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
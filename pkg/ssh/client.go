@@ -0,0 +1,475 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/doctl/pkg/runner"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/term"
+)
+
+// The SSH implementations that doctl can use.
+const (
+	// ClientNative connects in-process with Client.
+	ClientNative = "native"
+	// ClientExec runs the ssh binary with Runner.
+	ClientExec = "exec"
+)
+
+// lookPath finds a binary on the PATH. It is a variable so that tests can
+// replace it.
+var lookPath = exec.LookPath
+
+// DefaultClient returns the SSH implementation used when none is chosen:
+// ClientExec if there is an ssh binary on the PATH, and ClientNative
+// otherwise.
+func DefaultClient() string {
+	if _, err := lookPath("ssh"); err != nil {
+		return ClientNative
+	}
+	return ClientExec
+}
+
+// Host key checking policies, named after the values of the OpenSSH
+// StrictHostKeyChecking option.
+const (
+	// HostKeyCheckingAsk asks before adding an unknown host key to
+	// known_hosts. It is the default.
+	HostKeyCheckingAsk = "ask"
+	// HostKeyCheckingAcceptNew adds unknown host keys to known_hosts without
+	// asking.
+	HostKeyCheckingAcceptNew = "accept-new"
+	// HostKeyCheckingYes refuses hosts whose key is not in known_hosts.
+	HostKeyCheckingYes = "yes"
+	// HostKeyCheckingNo does not check host keys.
+	HostKeyCheckingNo = "no"
+)
+
+var (
+	// dialTimeout limits each attempt to connect and complete the SSH
+	// handshake.
+	dialTimeout = 30 * time.Second
+	// retryInterval is the wait between connection attempts.
+	retryInterval = time.Second
+)
+
+// Client runs ssh commands in-process, without the ssh binary. Unlike
+// Runner, its connections can also be used directly through Dial.
+type Client struct {
+	User            string
+	Host            string
+	KeyPath         string
+	Port            int
+	AgentForwarding bool
+	Command         string
+	RetriesMax      int
+
+	// KnownHostsPath is the known_hosts file used to check host keys. It
+	// defaults to ~/.ssh/known_hosts.
+	KnownHostsPath string
+	// HostKeyChecking is one of the HostKeyChecking policies. It defaults to
+	// HostKeyCheckingAsk.
+	HostKeyChecking string
+
+	// Jump, if set, is the host that the connection is made through.
+	Jump *Client
+
+	// Stdin, Stdout and Stderr default to the process's standard streams.
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+var _ runner.Runner = &Client{}
+
+// Run connects to the host and runs Command, or an interactive shell if
+// Command is empty. A terminal is allocated for the shell when standard
+// input is a terminal.
+func (c *Client) Run() error {
+	client, err := c.Dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	if c.AgentForwarding {
+		if err := forwardAgent(client, session); err != nil {
+			return err
+		}
+	}
+
+	session.Stdin = c.stdin()
+	session.Stdout = c.stdout()
+	session.Stderr = c.stderr()
+
+	if c.Command != "" {
+		return session.Run(c.Command)
+	}
+
+	if f, ok := session.Stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		restore, err := startTerminal(session, int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	if err := session.Shell(); err != nil {
+		return err
+	}
+	return session.Wait()
+}
+
+// Dial connects and authenticates to the host, trying up to RetriesMax times
+// when the connection fails.
+func (c *Client) Dial() (*ssh.Client, error) {
+	config, agentConn, err := c.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.dial(config)
+	if agentConn != nil {
+		// The agent signs for the keys it holds, so its connection stays
+		// open for as long as the client is.
+		if err != nil {
+			agentConn.Close()
+			return nil, err
+		}
+		go func() {
+			client.Wait()
+			agentConn.Close()
+		}()
+	}
+	return client, err
+}
+
+func (c *Client) dial(config *ssh.ClientConfig) (*ssh.Client, error) {
+	if c.Jump == nil {
+		return dialWithRetries("tcp", c.addr(), config, c.RetriesMax)
+	}
+
+	jump, err := c.Jump.Dial()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the jump host %s: %w", c.Jump.Host, err)
+	}
+	conn, err := jump.Dial("tcp", c.addr())
+	if err != nil {
+		jump.Close()
+		return nil, fmt.Errorf("failed to reach %s from the jump host %s: %w", c.addr(), c.Jump.Host, err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, c.addr(), config)
+	if err != nil {
+		jump.Close()
+		return nil, err
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	go func() {
+		client.Wait()
+		jump.Close()
+	}()
+	return client, nil
+}
+
+func dialWithRetries(network, addr string, config *ssh.ClientConfig, attempts int) (*ssh.Client, error) {
+	for i := 1; ; i++ {
+		client, err := ssh.Dial(network, addr, config)
+		if err == nil {
+			return client, nil
+		}
+		// Only network errors are retried; a failed handshake or
+		// authentication fails the same way every time.
+		var opErr *net.OpError
+		if i >= attempts || !errors.As(err, &opErr) {
+			return nil, err
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
+func (c *Client) addr() string {
+	port := c.Port
+	if port == 0 {
+		port = 22
+	}
+	return net.JoinHostPort(c.Host, strconv.Itoa(port))
+}
+
+// clientConfig returns the configuration for connecting to the host, and
+// the connection to the SSH agent, if any, that its keys sign through.
+func (c *Client) clientConfig() (*ssh.ClientConfig, net.Conn, error) {
+	hostKeyCallback, err := c.hostKeyCallback()
+	if err != nil {
+		return nil, nil, err
+	}
+	signers, agentConn, err := c.signers()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &ssh.ClientConfig{
+		User:            c.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signers...)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         dialTimeout,
+	}, agentConn, nil
+}
+
+// signers returns the keys held by the SSH agent, followed by the key in
+// KeyPath. A missing key file is skipped if the agent has keys. The agent's
+// keys are used through the returned connection, which the caller closes
+// once it no longer needs them.
+func (c *Client) signers() ([]ssh.Signer, net.Conn, error) {
+	var (
+		signers   []ssh.Signer
+		agentConn net.Conn
+	)
+	if conn, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK")); err == nil {
+		if agentSigners, err := agent.NewClient(conn).Signers(); err == nil && len(agentSigners) > 0 {
+			signers = append(signers, agentSigners...)
+			agentConn = conn
+		} else {
+			conn.Close()
+		}
+	}
+
+	if c.KeyPath != "" {
+		signer, err := c.readKey()
+		switch {
+		case err == nil:
+			signers = append(signers, signer)
+		case errors.Is(err, fs.ErrNotExist) && len(signers) > 0:
+		default:
+			if agentConn != nil {
+				agentConn.Close()
+			}
+			return nil, nil, err
+		}
+	}
+
+	if len(signers) == 0 {
+		return nil, nil, errors.New("no SSH keys are available: set a key path or start an SSH agent")
+	}
+	return signers, agentConn, nil
+}
+
+// readKey reads the private key in KeyPath, asking for its passphrase if it
+// is encrypted.
+func (c *Client) readKey() (ssh.Signer, error) {
+	pem, err := os.ReadFile(c.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := ssh.ParsePrivateKey(pem)
+	var missing *ssh.PassphraseMissingError
+	if !errors.As(err, &missing) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the SSH key %s: %w", c.KeyPath, err)
+		}
+		return signer, nil
+	}
+
+	tty, ok := c.terminal()
+	if !ok {
+		return nil, fmt.Errorf("the SSH key %s is protected by a passphrase; add it to an SSH agent to use it without a terminal", c.KeyPath)
+	}
+	fmt.Fprintf(c.stderr(), "Enter passphrase for key '%s': ", c.KeyPath)
+	passphrase, err := term.ReadPassword(int(tty.Fd()))
+	fmt.Fprintln(c.stderr())
+	if err != nil {
+		return nil, err
+	}
+	signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the SSH key %s: %w", c.KeyPath, err)
+	}
+	return signer, nil
+}
+
+// hostKeyCallback checks host keys against the known_hosts file, and adds
+// the keys of new hosts as allowed by HostKeyChecking.
+func (c *Client) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	policy := c.HostKeyChecking
+	switch policy {
+	case "":
+		policy = HostKeyCheckingAsk
+	case HostKeyCheckingAsk, HostKeyCheckingAcceptNew, HostKeyCheckingYes:
+	case HostKeyCheckingNo:
+		return ssh.InsecureIgnoreHostKey(), nil
+	default:
+		return nil, fmt.Errorf("invalid host key checking policy %q; valid values are ask, accept-new, yes and no", policy)
+	}
+
+	path := c.KnownHostsPath
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			return nil, err
+		}
+	}
+	check, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := check(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) {
+			return err
+		}
+		if len(keyErr.Want) > 0 {
+			want := keyErr.Want[0]
+			return fmt.Errorf("the host key of %s has changed since it was added to %s on line %d; if the host was rebuilt, remove the old key with: ssh-keygen -R %s",
+				hostname, want.Filename, want.Line, knownhosts.Normalize(hostname))
+		}
+
+		fingerprint := ssh.FingerprintSHA256(key)
+		switch policy {
+		case HostKeyCheckingYes:
+			return fmt.Errorf("the host key of %s is not in %s (%s key fingerprint %s)", hostname, path, key.Type(), fingerprint)
+		case HostKeyCheckingAsk:
+			if err := c.confirmHostKey(hostname, key); err != nil {
+				return err
+			}
+		}
+		return appendKnownHost(path, hostname, key)
+	}, nil
+}
+
+// confirmHostKey asks whether to trust the key of a host that is not in
+// known_hosts.
+func (c *Client) confirmHostKey(hostname string, key ssh.PublicKey) error {
+	tty, ok := c.terminal()
+	if !ok {
+		return fmt.Errorf("the host key of %s is not known and cannot be confirmed without a terminal (%s key fingerprint %s)", hostname, key.Type(), ssh.FingerprintSHA256(key))
+	}
+	fmt.Fprintf(c.stderr(), "The authenticity of host '%s' can't be established.\n%s key fingerprint is %s.\nAre you sure you want to continue connecting (yes/no)? ",
+		hostname, key.Type(), ssh.FingerprintSHA256(key))
+	answer, err := bufio.NewReader(tty).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if strings.TrimSpace(strings.ToLower(answer)) != "yes" {
+		return fmt.Errorf("the host key of %s was not accepted", hostname)
+	}
+	return nil
+}
+
+func appendKnownHost(path, hostname string, key ssh.PublicKey) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key))
+	return err
+}
+
+// forwardAgent forwards the local SSH agent to the session.
+func forwardAgent(client *ssh.Client, session *ssh.Session) error {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return errors.New("agent forwarding needs an SSH agent, but SSH_AUTH_SOCK is not set")
+	}
+	if err := agent.ForwardToRemote(client, sock); err != nil {
+		return err
+	}
+	return agent.RequestAgentForwarding(session)
+}
+
+// startTerminal allocates a terminal for the session that matches the local
+// one, and puts the local terminal in raw mode until restore is called.
+func startTerminal(session *ssh.Session, fd int) (restore func(), err error) {
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return nil, err
+	}
+	termType := os.Getenv("TERM")
+	if termType == "" {
+		termType = "xterm-256color"
+	}
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty(termType, height, width, modes); err != nil {
+		return nil, err
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	stopResize := watchWindowSize(session, fd)
+	return func() {
+		stopResize()
+		term.Restore(fd, state)
+	}, nil
+}
+
+// terminal returns standard input if it is a terminal.
+func (c *Client) terminal() (*os.File, bool) {
+	f, ok := c.stdin().(*os.File)
+	return f, ok && term.IsTerminal(int(f.Fd()))
+}
+
+func (c *Client) stdin() io.Reader {
+	if c.Stdin != nil {
+		return c.Stdin
+	}
+	return os.Stdin
+}
+
+func (c *Client) stdout() io.Writer {
+	if c.Stdout != nil {
+		return c.Stdout
+	}
+	return os.Stdout
+}
+
+func (c *Client) stderr() io.Writer {
+	if c.Stderr != nil {
+		return c.Stderr
+	}
+	return os.Stderr
+}
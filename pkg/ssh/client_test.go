@@ -0,0 +1,295 @@
+package ssh
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// testServer is an SSH server that accepts the key in
+// testdata/id_rsa_without_password. It runs scp commands locally, and
+// answers every other command with its name.
+type testServer struct {
+	addr    string
+	hostKey ssh.Signer
+}
+
+func newTestServer(t *testing.T) *testServer {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostKey, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	authorized, err := os.ReadFile("testdata/id_rsa_without_password.pub")
+	require.NoError(t, err)
+	userKey, _, _, _, err := ssh.ParseAuthorizedKey(authorized)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if bytes.Equal(key.Marshal(), userKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, assert.AnError
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConn(conn, config)
+		}
+	}()
+	return &testServer{addr: l.Addr().String(), hostKey: hostKey}
+}
+
+func serveTestConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for nc := range chans {
+		if nc.ChannelType() == "direct-tcpip" {
+			go serveTestForward(nc)
+			continue
+		}
+		ch, reqs, err := nc.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer ch.Close()
+			for req := range reqs {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				var payload struct{ Command string }
+				ssh.Unmarshal(req.Payload, &payload)
+				req.Reply(true, nil)
+				status := 0
+				if strings.HasPrefix(payload.Command, "scp ") {
+					// Like sshd, do not wait for the client to close stdin.
+					cmd := exec.Command("sh", "-c", payload.Command)
+					cmd.Stdout, cmd.Stderr = ch, ch.Stderr()
+					stdin, _ := cmd.StdinPipe()
+					go func() {
+						io.Copy(stdin, ch)
+						stdin.Close()
+					}()
+					if err := cmd.Run(); err != nil {
+						status = 1
+					}
+				} else {
+					ch.Write([]byte("ran " + payload.Command + "\n"))
+				}
+				ch.CloseWrite()
+				ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{uint32(status)}))
+				return
+			}
+		}()
+	}
+}
+
+// serveTestForward connects a forwarded connection to its destination.
+func serveTestForward(nc ssh.NewChannel) {
+	var payload struct {
+		Host     string
+		Port     uint32
+		OrigHost string
+		OrigPort uint32
+	}
+	if err := ssh.Unmarshal(nc.ExtraData(), &payload); err != nil {
+		nc.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	conn, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		nc.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	ch, reqs, err := nc.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	go func() {
+		io.Copy(conn, ch)
+		conn.(*net.TCPConn).CloseWrite()
+	}()
+	io.Copy(ch, conn)
+	ch.Close()
+	conn.Close()
+}
+
+func (s *testServer) client(t *testing.T, knownHosts, policy string) (*Client, *bytes.Buffer) {
+	host, port, err := net.SplitHostPort(s.addr)
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	return &Client{
+		User:            "root",
+		Host:            host,
+		Port:            p,
+		KeyPath:         "testdata/id_rsa_without_password",
+		Command:         "uptime",
+		KnownHostsPath:  knownHosts,
+		HostKeyChecking: policy,
+		Stdin:           &bytes.Buffer{},
+		Stdout:          &stdout,
+		Stderr:          &bytes.Buffer{},
+	}, &stdout
+}
+
+func TestClientRunAcceptNew(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	s := newTestServer(t)
+	knownHosts := filepath.Join(t.TempDir(), ".ssh", "known_hosts")
+
+	c, stdout := s.client(t, knownHosts, HostKeyCheckingAcceptNew)
+	require.NoError(t, c.Run())
+	assert.Equal(t, "ran uptime\n", stdout.String())
+
+	written, err := os.ReadFile(knownHosts)
+	require.NoError(t, err)
+	assert.Equal(t, knownhosts.Line([]string{knownhosts.Normalize(s.addr)}, s.hostKey.PublicKey())+"\n", string(written))
+
+	// The host is known now, so strict checking accepts it.
+	c, stdout = s.client(t, knownHosts, HostKeyCheckingYes)
+	require.NoError(t, c.Run())
+	assert.Equal(t, "ran uptime\n", stdout.String())
+}
+
+func TestClientRunUnknownHost(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	s := newTestServer(t)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+
+	c, _ := s.client(t, knownHosts, HostKeyCheckingYes)
+	err := c.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not in "+knownHosts)
+
+	// Without a terminal, ask cannot confirm the key either.
+	c, _ = s.client(t, knownHosts, HostKeyCheckingAsk)
+	err = c.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be confirmed without a terminal")
+}
+
+func TestClientRunChangedHostKey(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	s := newTestServer(t)
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ssh.NewSignerFromKey(other)
+	require.NoError(t, err)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(s.addr)}, otherKey.PublicKey())
+	require.NoError(t, os.WriteFile(knownHosts, []byte(line+"\n"), 0600))
+
+	c, _ := s.client(t, knownHosts, HostKeyCheckingAcceptNew)
+	err = c.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has changed since it was added to "+knownHosts+" on line 1")
+	assert.Contains(t, err.Error(), "ssh-keygen -R "+knownhosts.Normalize(s.addr))
+}
+
+func TestClientRunJump(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	jump := newTestServer(t)
+	target := newTestServer(t)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+
+	c, stdout := target.client(t, knownHosts, HostKeyCheckingAcceptNew)
+	c.Jump, _ = jump.client(t, knownHosts, HostKeyCheckingAcceptNew)
+	require.NoError(t, c.Run())
+	assert.Equal(t, "ran uptime\n", stdout.String())
+
+	written, err := os.ReadFile(knownHosts)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), knownhosts.Normalize(jump.addr))
+	assert.Contains(t, string(written), knownhosts.Normalize(target.addr))
+}
+
+func TestClientRunAgent(t *testing.T) {
+	pem, err := os.ReadFile("testdata/id_rsa_without_password")
+	require.NoError(t, err)
+	key, err := ssh.ParseRawPrivateKey(pem)
+	require.NoError(t, err)
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: key}))
+
+	sock := filepath.Join(t.TempDir(), "agent.sock")
+	l, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go agent.ServeAgent(keyring, conn)
+		}
+	}()
+	t.Setenv("SSH_AUTH_SOCK", sock)
+
+	// The key file is missing, so the connection authenticates with the
+	// agent's key alone.
+	s := newTestServer(t)
+	c, stdout := s.client(t, filepath.Join(t.TempDir(), "known_hosts"), HostKeyCheckingAcceptNew)
+	c.KeyPath = filepath.Join(t.TempDir(), "missing")
+	require.NoError(t, c.Run())
+	assert.Equal(t, "ran uptime\n", stdout.String())
+}
+
+func TestClientEncryptedKeyWithoutTerminal(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	c := &Client{KeyPath: "testdata/id_rsa_with_password", Stdin: &bytes.Buffer{}}
+	_, _, err := c.signers()
+	assert.EqualError(t, err, "the SSH key testdata/id_rsa_with_password is protected by a passphrase; add it to an SSH agent to use it without a terminal")
+}
+
+func TestDefaultClient(t *testing.T) {
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+
+	lookPath = func(string) (string, error) { return "/usr/bin/ssh", nil }
+	assert.Equal(t, ClientExec, DefaultClient())
+
+	lookPath = func(file string) (string, error) { return "", &exec.Error{Name: file, Err: exec.ErrNotFound} }
+	assert.Equal(t, ClientNative, DefaultClient())
+}
+
+func TestExitStatus(t *testing.T) {
+	assert.Equal(t, 0, ExitStatus(nil))
+	assert.Equal(t, -1, ExitStatus(assert.AnError))
+	assert.Equal(t, 3, ExitStatus(exec.Command("sh", "-c", "exit 3").Run()))
+	assert.Equal(t, -1, ExitStatus(exec.Command("sh", "-c", "exit 255").Run()))
+}
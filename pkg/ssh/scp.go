@@ -0,0 +1,369 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/doctl/pkg/runner"
+)
+
+// Options for copying files with SCP. OptionSCPUpload is a bool that is true
+// to copy from OptionSCPLocalPath to OptionSCPRemotePath, and false for the
+// reverse. OptionSCPProgress is an io.Writer that progress is written to.
+const (
+	OptionSCPUpload     = "scp-upload"
+	OptionSCPLocalPath  = "scp-local-path"
+	OptionSCPRemotePath = "scp-remote-path"
+	OptionSCPProgress   = "scp-progress"
+)
+
+// Copier copies files to or from a host with the SCP protocol, over a
+// connection made by Client. The host needs the scp program, which OpenSSH
+// servers include.
+type Copier struct {
+	Client     *Client
+	Upload     bool
+	LocalPath  string
+	RemotePath string
+	// Recursive copies directories and their contents.
+	Recursive bool
+	// Progress, if set, receives a progress line for every file.
+	Progress io.Writer
+}
+
+var _ runner.Runner = &Copier{}
+
+// Run copies the files.
+func (c *Copier) Run() error {
+	client, err := c.Client.Dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr strings.Builder
+	session.Stderr = &stderr
+
+	flags := "-t"
+	if !c.Upload {
+		flags = "-f"
+	}
+	if c.Recursive {
+		flags += " -r"
+	}
+	if err := session.Start(fmt.Sprintf("scp %s %s", flags, shellQuote(c.RemotePath))); err != nil {
+		return err
+	}
+
+	s := &scpStream{r: bufio.NewReader(stdout), w: stdin, progress: c.Progress}
+	if c.Upload {
+		err = s.send(c.LocalPath, c.Recursive)
+	} else {
+		err = s.receive(c.LocalPath)
+	}
+	stdin.Close()
+	if waitErr := session.Wait(); err == nil && waitErr != nil {
+		err = waitErr
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+	}
+	return err
+}
+
+// scpStream speaks the SCP protocol with a remote scp program.
+type scpStream struct {
+	r        *bufio.Reader
+	w        io.Writer
+	progress io.Writer
+}
+
+// send sends a local file, or a directory and its contents if recursive is
+// set, to a remote scp -t.
+func (s *scpStream) send(local string, recursive bool) error {
+	if err := s.readAck(); err != nil {
+		return err
+	}
+	info, err := os.Stat(local)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() && !recursive {
+		return fmt.Errorf("%s is a directory; copy it recursively to include its contents", local)
+	}
+	return s.sendPath(local, info)
+}
+
+func (s *scpStream) sendPath(local string, info fs.FileInfo) error {
+	if !info.IsDir() {
+		return s.sendFile(local, info)
+	}
+
+	if err := s.command("D%04o 0 %s\n", info.Mode().Perm(), info.Name()); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(local)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		info, err := os.Stat(filepath.Join(local, e.Name()))
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			continue
+		}
+		if err := s.sendPath(filepath.Join(local, e.Name()), info); err != nil {
+			return err
+		}
+	}
+	return s.command("E\n")
+}
+
+func (s *scpStream) sendFile(local string, info fs.FileInfo) error {
+	f, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := s.command("C%04o %d %s\n", info.Mode().Perm(), info.Size(), info.Name()); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(s.w, s.track(f, info.Name(), info.Size()), info.Size()); err != nil {
+		return err
+	}
+	if _, err := s.w.Write([]byte{0}); err != nil {
+		return err
+	}
+	return s.readAck()
+}
+
+// command sends a protocol message and waits for it to be acknowledged.
+func (s *scpStream) command(format string, args ...any) error {
+	if _, err := fmt.Fprintf(s.w, format, args...); err != nil {
+		return err
+	}
+	return s.readAck()
+}
+
+// readAck reads the response to a message, which is a zero byte if it
+// succeeded and a byte of 1 or 2 followed by a message if it failed.
+func (s *scpStream) readAck() error {
+	b, err := s.r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("the remote scp stopped responding: %w", err)
+	}
+	if b == 0 {
+		return nil
+	}
+	msg, _ := s.r.ReadString('\n')
+	return fmt.Errorf("scp: %s", strings.TrimSpace(msg))
+}
+
+func (s *scpStream) ack() error {
+	_, err := s.w.Write([]byte{0})
+	return err
+}
+
+// receive receives files from a remote scp -f and writes them to local. If
+// local is an existing directory, the files are written inside it;
+// otherwise the first file or directory received is named local.
+func (s *scpStream) receive(local string) error {
+	dirs := []string{}
+	if info, err := os.Stat(local); err == nil && info.IsDir() {
+		dirs = append(dirs, local)
+	}
+	target := func(name string) string {
+		if len(dirs) == 0 {
+			return local
+		}
+		return filepath.Join(dirs[len(dirs)-1], name)
+	}
+
+	if err := s.ack(); err != nil {
+		return err
+	}
+	for {
+		line, err := s.r.ReadString('\n')
+		if errors.Is(err, io.EOF) && line == "" {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		msg := strings.TrimSuffix(line[1:], "\n")
+
+		switch line[0] {
+		case 0x01, 0x02:
+			return fmt.Errorf("scp: %s", strings.TrimSpace(msg))
+		case 'T':
+			// Modification times are not preserved.
+		case 'E':
+			if len(dirs) == 0 {
+				return errors.New("scp: unexpected end of directory")
+			}
+			dirs = dirs[:len(dirs)-1]
+		case 'D', 'C':
+			mode, size, name, err := parseSCPHeader(msg)
+			if err != nil {
+				return err
+			}
+			dst := target(name)
+			if line[0] == 'D' {
+				if err := os.MkdirAll(dst, mode); err != nil {
+					return err
+				}
+				dirs = append(dirs, dst)
+				break
+			}
+			if err := s.ack(); err != nil {
+				return err
+			}
+			if err := s.receiveFile(dst, mode, size, name); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("scp: unexpected message %q", strings.TrimSpace(line))
+		}
+		if err := s.ack(); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *scpStream) receiveFile(dst string, mode fs.FileMode, size int64, name string) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(f, s.track(s.r, name, size), size); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return s.readAck()
+}
+
+// parseSCPHeader parses the mode, size and name of a C or D message.
+func parseSCPHeader(msg string) (fs.FileMode, int64, string, error) {
+	parts := strings.SplitN(msg, " ", 3)
+	if len(parts) != 3 {
+		return 0, 0, "", fmt.Errorf("scp: invalid message %q", msg)
+	}
+	mode, err := strconv.ParseUint(parts[0], 8, 32)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("scp: invalid mode %q", parts[0])
+	}
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || size < 0 {
+		return 0, 0, "", fmt.Errorf("scp: invalid size %q", parts[1])
+	}
+	name := parts[2]
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return 0, 0, "", fmt.Errorf("scp: invalid file name %q", name)
+	}
+	return fs.FileMode(mode).Perm(), size, name, nil
+}
+
+// track reports the progress of reading a file of size bytes from r.
+func (s *scpStream) track(r io.Reader, name string, size int64) io.Reader {
+	if s.progress == nil {
+		return r
+	}
+	return &progressReader{r: r, w: s.progress, name: name, size: size}
+}
+
+type progressReader struct {
+	r       io.Reader
+	w       io.Writer
+	name    string
+	size    int64
+	read    int64
+	percent int
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	percent := 100
+	if p.size > 0 {
+		percent = int(p.read * 100 / p.size)
+	}
+	if percent != p.percent || p.read == 0 {
+		p.percent = percent
+		end := ""
+		if p.read >= p.size {
+			end = "\n"
+		}
+		fmt.Fprintf(p.w, "\r%s %3d%% %s%s", p.name, percent, formatBytes(p.read), end)
+	}
+	return n, err
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// shellQuote quotes a path for the remote shell. A leading ~/ is left
+// unquoted so that it still expands to the home directory.
+func shellQuote(p string) string {
+	prefix := ""
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		prefix, p = "~/", rest
+	}
+	if p == "" {
+		if prefix == "" {
+			return "."
+		}
+		return prefix
+	}
+	return prefix + "'" + strings.ReplaceAll(path.Clean(p), "'", `'\''`) + "'"
+}
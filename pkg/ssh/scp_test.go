@@ -0,0 +1,103 @@
+package ssh
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCopier(t *testing.T, s *testServer, upload bool, local, remote string) *Copier {
+	if _, err := exec.LookPath("scp"); err != nil {
+		t.Skip("scp is not installed")
+	}
+	t.Setenv("SSH_AUTH_SOCK", "")
+	c, _ := s.client(t, filepath.Join(t.TempDir(), "known_hosts"), HostKeyCheckingAcceptNew)
+	return &Copier{Client: c, Upload: upload, LocalPath: local, RemotePath: remote}
+}
+
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0640))
+	}
+}
+
+func assertTree(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(got), name)
+	}
+}
+
+func TestCopierUpload(t *testing.T) {
+	s := newTestServer(t)
+	local, remote := t.TempDir(), t.TempDir()
+	writeTree(t, local, map[string]string{"app.conf": "listen 80\n"})
+
+	c := newTestCopier(t, s, true, filepath.Join(local, "app.conf"), remote)
+	var progress bytes.Buffer
+	c.Progress = &progress
+	require.NoError(t, c.Run())
+
+	assertTree(t, remote, map[string]string{"app.conf": "listen 80\n"})
+	info, err := os.Stat(filepath.Join(remote, "app.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	assert.Equal(t, "\rapp.conf 100% 10B\n", progress.String())
+}
+
+func TestCopierUploadRecursive(t *testing.T) {
+	s := newTestServer(t)
+	local, remote := t.TempDir(), t.TempDir()
+	files := map[string]string{"site/index.html": "<html>", "site/css/main.css": "body {}"}
+	writeTree(t, local, files)
+
+	c := newTestCopier(t, s, true, filepath.Join(local, "site"), remote)
+	err := c.Run()
+	assert.EqualError(t, err, filepath.Join(local, "site")+" is a directory; copy it recursively to include its contents")
+
+	c.Recursive = true
+	require.NoError(t, c.Run())
+	assertTree(t, remote, files)
+}
+
+func TestCopierDownloadRecursive(t *testing.T) {
+	s := newTestServer(t)
+	local, remote := t.TempDir(), t.TempDir()
+	files := map[string]string{"logs/access.log": "GET /\n", "logs/old/error.log": "oops\n"}
+	writeTree(t, remote, files)
+
+	// An existing directory receives the copy inside it.
+	c := newTestCopier(t, s, false, local, filepath.Join(remote, "logs"))
+	c.Recursive = true
+	require.NoError(t, c.Run())
+	assertTree(t, local, files)
+
+	// Any other path is the name of the copy.
+	c.LocalPath = filepath.Join(local, "copy")
+	require.NoError(t, c.Run())
+	assertTree(t, c.LocalPath, map[string]string{"access.log": "GET /\n", "old/error.log": "oops\n"})
+}
+
+func TestCopierDownloadMissing(t *testing.T) {
+	s := newTestServer(t)
+	c := newTestCopier(t, s, false, t.TempDir(), filepath.Join(t.TempDir(), "missing"))
+	err := c.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "No such file or directory")
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "'/tmp/a b'", shellQuote("/tmp/a b"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+	assert.Equal(t, "~/'app'", shellQuote("~/app"))
+	assert.Equal(t, "~/", shellQuote("~/"))
+	assert.Equal(t, ".", shellQuote(""))
+}
@@ -14,18 +14,46 @@ limitations under the License.
 package ssh
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strconv"
 
 	"github.com/digitalocean/doctl/pkg/runner"
 	"github.com/fatih/color"
+	"golang.org/x/crypto/ssh"
 )
 
 // Options is the type used to specify options passed to the SSH command
 type Options map[string]any
 
+// Options that replace the standard streams of the SSH command. Their values
+// are an io.Reader for OptionStdin and an io.Writer for the others.
+const (
+	OptionStdin  = "stdin"
+	OptionStdout = "stdout"
+	OptionStderr = "stderr"
+)
+
+// ExitStatus returns the exit status of the remote command that err
+// reports, 0 if err is nil, or -1 if the command did not run or exit.
+func ExitStatus(err error) int {
+	var sshErr *ssh.ExitError
+	var execErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &sshErr):
+		return sshErr.ExitStatus()
+	case errors.As(err, &execErr) && execErr.ExitCode() != 255:
+		// ssh exits with 255 when it fails itself.
+		return execErr.ExitCode()
+	}
+	return -1
+}
+
 // Runner runs ssh commands.
 type Runner struct {
 	User            string
@@ -35,6 +63,21 @@ type Runner struct {
 	AgentForwarding bool
 	Command         string
 	RetriesMax      int
+	// HostKeyChecking, if set, is passed to ssh as its StrictHostKeyChecking
+	// option, which otherwise comes from the ssh configuration.
+	HostKeyChecking string
+
+	// Jump, if set, is the host that the connection is made through.
+	Jump *JumpHost
+	// LocalForwards are forwarded while the connection is open. If Command
+	// is empty, no shell is started and the ports are forwarded until ssh
+	// is stopped.
+	LocalForwards []Forward
+
+	// Stdin, Stdout and Stderr default to the process's standard streams.
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
 }
 
 var _ runner.Runner = &Runner{}
@@ -59,6 +102,33 @@ func (r *Runner) Run() error {
 		args = append(args, "-A")
 	}
 
+	if r.Jump != nil {
+		// ProxyCommand, unlike -J, uses the same key for the jump host.
+		proxy := "ssh"
+		if r.KeyPath != "" {
+			proxy += " -i " + shellQuote(r.KeyPath)
+		}
+		if r.Jump.Port > 0 {
+			proxy += " -p " + strconv.Itoa(r.Jump.Port)
+		}
+		jumpHost := r.Jump.Host
+		if r.Jump.User != "" {
+			jumpHost = r.Jump.User + "@" + jumpHost
+		}
+		args = append(args, "-o", "ProxyCommand="+proxy+" -W %h:%p "+jumpHost)
+	}
+
+	if r.HostKeyChecking != "" {
+		args = append(args, "-o", "StrictHostKeyChecking="+r.HostKeyChecking)
+	}
+
+	for _, f := range r.LocalForwards {
+		args = append(args, "-L", f.String())
+	}
+	if len(r.LocalForwards) > 0 && r.Command == "" {
+		args = append(args, "-N")
+	}
+
 	args = append(args, sshHost)
 	if r.Command != "" {
 		args = append(args, r.Command)
@@ -74,6 +144,15 @@ func (r *Runner) Run() error {
 	cmd.Stderr = os.Stderr
 	cmd.Stdout = os.Stdout
 	cmd.Stdin = os.Stdin
+	if r.Stdin != nil {
+		cmd.Stdin = r.Stdin
+	}
+	if r.Stdout != nil {
+		cmd.Stdout = r.Stdout
+	}
+	if r.Stderr != nil {
+		cmd.Stderr = r.Stderr
+	}
 
 	err := cmd.Run()
 	if err != nil {
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Doctl Authors All rights reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/doctl/pkg/runner"
+	"golang.org/x/crypto/ssh"
+)
+
+// Options for connecting through a jump host and forwarding ports.
+// OptionJump is a *JumpHost, and OptionLocalForwards is a []Forward.
+const (
+	OptionJump          = "jump"
+	OptionLocalForwards = "local-forwards"
+)
+
+// JumpHost is a host that a connection is made through, like the OpenSSH
+// ProxyJump option.
+type JumpHost struct {
+	User string
+	Host string
+	Port int
+}
+
+// Forward forwards connections to a local port to a port on a host that the
+// remote end of an SSH connection can reach.
+type Forward struct {
+	BindAddress string
+	Port        int
+	Host        string
+	HostPort    int
+}
+
+// ParseForward parses a forward written as in the OpenSSH -L option:
+// [bind_address:]port:host:hostport. IPv6 addresses are written in square
+// brackets. The bind address defaults to localhost.
+func ParseForward(spec string) (Forward, error) {
+	var (
+		parts   []string
+		start   int
+		bracket bool
+	)
+	for i, r := range spec {
+		switch {
+		case r == '[':
+			bracket = true
+		case r == ']':
+			bracket = false
+		case r == ':' && !bracket:
+			parts = append(parts, spec[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, spec[start:])
+
+	f := Forward{BindAddress: "localhost"}
+	if len(parts) == 4 {
+		f.BindAddress, parts = strings.Trim(parts[0], "[]"), parts[1:]
+	}
+	if len(parts) != 3 {
+		return Forward{}, fmt.Errorf("invalid forward %q; use [bind_address:]port:host:hostport", spec)
+	}
+	f.Host = strings.Trim(parts[1], "[]")
+	var err error
+	if f.Port, err = parsePort(parts[0]); err != nil {
+		return Forward{}, fmt.Errorf("invalid forward %q: %w", spec, err)
+	}
+	if f.HostPort, err = parsePort(parts[2]); err != nil {
+		return Forward{}, fmt.Errorf("invalid forward %q: %w", spec, err)
+	}
+	if f.Host == "" {
+		return Forward{}, fmt.Errorf("invalid forward %q: the host is empty", spec)
+	}
+	return f, nil
+}
+
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil || port < 0 || port > 65535 {
+		return 0, fmt.Errorf("%q is not a valid port", s)
+	}
+	return port, nil
+}
+
+// LocalAddr returns the local address that is listened on.
+func (f Forward) LocalAddr() string {
+	return net.JoinHostPort(f.BindAddress, strconv.Itoa(f.Port))
+}
+
+// RemoteAddr returns the address that connections are forwarded to.
+func (f Forward) RemoteAddr() string {
+	return net.JoinHostPort(f.Host, strconv.Itoa(f.HostPort))
+}
+
+// String returns the forward in the form of the OpenSSH -L option.
+func (f Forward) String() string {
+	return net.JoinHostPort(f.BindAddress, strconv.Itoa(f.Port)) + ":" + f.RemoteAddr()
+}
+
+// Tunnel forwards local ports through an SSH connection made by Client, like
+// ssh -N -L. It runs until the connection is closed.
+type Tunnel struct {
+	Client   *Client
+	Forwards []Forward
+
+	// listening is called once the local ports are open.
+	listening func(client *ssh.Client, addrs []net.Addr)
+}
+
+var _ runner.Runner = &Tunnel{}
+
+// Run opens the connection and forwards the ports.
+func (t *Tunnel) Run() error {
+	if len(t.Forwards) == 0 {
+		return errors.New("no ports to forward")
+	}
+	client, err := t.Client.Dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	addrs := make([]net.Addr, 0, len(t.Forwards))
+	for _, f := range t.Forwards {
+		l, err := net.Listen("tcp", f.LocalAddr())
+		if err != nil {
+			return err
+		}
+		defer l.Close()
+		addrs = append(addrs, l.Addr())
+		go forwardConnections(client, l, f.RemoteAddr(), t.Client.stderr())
+	}
+	if t.listening != nil {
+		t.listening(client, addrs)
+	}
+	return client.Wait()
+}
+
+// forwardConnections accepts connections on l and forwards them to remote
+// through the client.
+func forwardConnections(client *ssh.Client, l net.Listener, remote string, stderr io.Writer) {
+	for {
+		local, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer local.Close()
+			conn, err := client.Dial("tcp", remote)
+			if err != nil {
+				fmt.Fprintf(stderr, "Failed to forward a connection to %s: %v\n", remote, err)
+				return
+			}
+			defer conn.Close()
+			pipe(local, conn)
+		}()
+	}
+}
+
+// pipe copies between two connections until both directions are done.
+func pipe(a, b net.Conn) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	copyHalf := func(dst, src net.Conn) {
+		defer wg.Done()
+		io.Copy(dst, src)
+		if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+			cw.CloseWrite()
+		} else {
+			dst.Close()
+		}
+	}
+	go copyHalf(a, b)
+	go copyHalf(b, a)
+	wg.Wait()
+}
@@ -0,0 +1,93 @@
+package ssh
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseForward(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Forward
+		err  string
+	}{
+		{spec: "5432:db.internal:25060", want: Forward{BindAddress: "localhost", Port: 5432, Host: "db.internal", HostPort: 25060}},
+		{spec: "0.0.0.0:8080:10.0.0.5:80", want: Forward{BindAddress: "0.0.0.0", Port: 8080, Host: "10.0.0.5", HostPort: 80}},
+		{spec: "[::1]:8080:[fd00::5]:80", want: Forward{BindAddress: "::1", Port: 8080, Host: "fd00::5", HostPort: 80}},
+		{spec: "5432:db.internal", err: `invalid forward "5432:db.internal"; use [bind_address:]port:host:hostport`},
+		{spec: "x:db.internal:25060", err: `invalid forward "x:db.internal:25060": "x" is not a valid port`},
+		{spec: "5432::25060", err: `invalid forward "5432::25060": the host is empty`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			f, err := ParseForward(tt.spec)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f)
+		})
+	}
+
+	f, err := ParseForward("[::1]:8080:[fd00::5]:80")
+	require.NoError(t, err)
+	assert.Equal(t, "[::1]:8080:[fd00::5]:80", f.String())
+}
+
+func TestTunnel(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	s := newTestServer(t)
+
+	// The service that the tunnel forwards to echoes what it receives.
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer echo.Close()
+	go func() {
+		for {
+			conn, err := echo.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	echoAddr := echo.Addr().(*net.TCPAddr)
+
+	c, _ := s.client(t, filepath.Join(t.TempDir(), "known_hosts"), HostKeyCheckingAcceptNew)
+	ready := make(chan []net.Addr, 1)
+	clients := make(chan *ssh.Client, 1)
+	tunnel := &Tunnel{
+		Client:   c,
+		Forwards: []Forward{{BindAddress: "127.0.0.1", Port: 0, Host: "127.0.0.1", HostPort: echoAddr.Port}},
+		listening: func(client *ssh.Client, addrs []net.Addr) {
+			clients <- client
+			ready <- addrs
+		},
+	}
+	done := make(chan error, 1)
+	go func() { done <- tunnel.Run() }()
+
+	addrs := <-ready
+	conn, err := net.Dial("tcp", addrs[0].String())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	conn.(*net.TCPConn).CloseWrite()
+	got, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(got))
+	conn.Close()
+
+	(<-clients).Close()
+	assert.Error(t, <-done)
+}
@@ -0,0 +1,505 @@
+// Package zonefile reads and writes DNS zone files in the master file format
+// described in RFC 1035, section 5.
+package zonefile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Record is a resource record in a zone.
+type Record struct {
+	// Name is the owner of the record relative to the zone's origin, or "@"
+	// for the origin itself.
+	Name string
+	// TTL is the record's time to live in seconds, or 0 if the zone file does
+	// not give one.
+	TTL  int
+	Type string
+	// Data is the record's address, host name, or text. Host names are fully
+	// qualified, with a trailing dot. The text of a TXT record is the
+	// concatenation of its character strings.
+	Data string
+	// Priority is the preference of an MX record and the priority of an SRV
+	// record. Weight and Port are set for SRV records, and Flags and Tag for
+	// CAA records.
+	Priority int
+	Weight   int
+	Port     int
+	Flags    int
+	Tag      string
+	// Line is the line of the zone file that the record starts on.
+	Line int
+}
+
+// maxStringLength is the length limit of a DNS character string.
+const maxStringLength = 255
+
+// Fqdn returns name with a trailing dot.
+func Fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// Absolute returns the fully qualified form of name, which is relative to
+// origin unless it ends in a dot. "@" stands for origin.
+func Absolute(name, origin string) string {
+	switch {
+	case name == "@":
+		return Fqdn(origin)
+	case strings.HasSuffix(name, "."):
+		return name
+	case origin == "" || origin == ".":
+		return name + "."
+	}
+	return name + "." + Fqdn(origin)
+}
+
+// Relative returns name, which is fully qualified, relative to origin. It
+// returns "@" for origin itself, and false if name is outside origin.
+func Relative(name, origin string) (string, bool) {
+	name, origin = Fqdn(name), Fqdn(origin)
+	if strings.EqualFold(name, origin) {
+		return "@", true
+	}
+	if len(name) > len(origin) && strings.EqualFold(name[len(name)-len(origin)-1:], "."+origin) {
+		return name[:len(name)-len(origin)-1], true
+	}
+	return "", false
+}
+
+// A ParseError describes a problem with a line of a zone file.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads the records of a zone file. Names are made relative to origin,
+// which a $ORIGIN directive in the file may change. Records of every type are
+// returned, including SOA records; $INCLUDE directives are not supported.
+func Parse(r io.Reader, origin string) ([]Record, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := lex(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	zone := Fqdn(origin)
+	origin = zone
+	var (
+		records    []Record
+		owner      string
+		defaultTTL int
+	)
+	for _, e := range entries {
+		toks := e.tokens
+		fail := func(format string, args ...any) error {
+			return &ParseError{Line: e.line, Err: fmt.Errorf(format, args...)}
+		}
+
+		if first := toks[0]; !first.quoted && strings.HasPrefix(first.text, "$") {
+			switch strings.ToUpper(first.text) {
+			case "$ORIGIN":
+				if len(toks) != 2 {
+					return nil, fail("$ORIGIN needs one domain name")
+				}
+				origin = Absolute(toks[1].text, origin)
+			case "$TTL":
+				if len(toks) != 2 {
+					return nil, fail("$TTL needs one TTL")
+				}
+				ttl, ok := parseTTL(toks[1].text)
+				if !ok {
+					return nil, fail("invalid TTL %q", toks[1].text)
+				}
+				defaultTTL = ttl
+			default:
+				return nil, fail("the %s directive is not supported", first.text)
+			}
+			continue
+		}
+
+		if !e.blankOwner {
+			owner = Absolute(toks[0].text, origin)
+			toks = toks[1:]
+		} else if owner == "" {
+			return nil, fail("the first record must have an owner name")
+		}
+
+		rec := Record{TTL: defaultTTL, Line: e.line}
+		var ok bool
+		if rec.Name, ok = Relative(owner, zone); !ok {
+			return nil, fail("%s is outside the zone %s", owner, zone)
+		}
+
+		// The TTL and class may come in either order before the type.
+		for i := 0; i < 2 && len(toks) > 0; i++ {
+			if ttl, ok := parseTTL(toks[0].text); ok {
+				rec.TTL = ttl
+				toks = toks[1:]
+			} else if isClass(toks[0].text) {
+				toks = toks[1:]
+			}
+		}
+		if len(toks) == 0 {
+			return nil, fail("missing record type")
+		}
+		rec.Type = strings.ToUpper(toks[0].text)
+
+		if err := parseData(&rec, toks[1:], origin); err != nil {
+			return nil, fail("%s record: %v", rec.Type, err)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func isClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+// parseTTL parses a TTL given in seconds or with BIND's unit suffixes, such
+// as 1h30m.
+func parseTTL(s string) (int, bool) {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+
+	total, n := 0, -1
+	for _, ch := range strings.ToLower(s) {
+		if ch >= '0' && ch <= '9' {
+			if n < 0 {
+				n = 0
+			}
+			n = n*10 + int(ch-'0')
+			continue
+		}
+		unit := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}[ch]
+		if unit == 0 || n < 0 {
+			return 0, false
+		}
+		total += n * unit
+		n = -1
+	}
+	if n >= 0 {
+		return 0, false
+	}
+	return total, true
+}
+
+func parseData(rec *Record, toks []token, origin string) error {
+	want := func(n int) error {
+		if len(toks) != n {
+			return fmt.Errorf("expected %d fields, found %d", n, len(toks))
+		}
+		return nil
+	}
+	num := func(t token, field string) (int, error) {
+		n, err := strconv.Atoi(t.text)
+		if err != nil || n < 0 || n > 65535 {
+			return 0, fmt.Errorf("invalid %s %q", field, t.text)
+		}
+		return n, nil
+	}
+
+	var err error
+	switch rec.Type {
+	case "A", "AAAA":
+		if err := want(1); err != nil {
+			return err
+		}
+		ip := net.ParseIP(toks[0].text)
+		if ip == nil || (rec.Type == "A") != (ip.To4() != nil) {
+			return fmt.Errorf("invalid address %q", toks[0].text)
+		}
+		rec.Data = toks[0].text
+	case "CNAME", "NS", "PTR":
+		if err := want(1); err != nil {
+			return err
+		}
+		rec.Data = Absolute(toks[0].text, origin)
+	case "MX":
+		if err := want(2); err != nil {
+			return err
+		}
+		if rec.Priority, err = num(toks[0], "preference"); err != nil {
+			return err
+		}
+		rec.Data = Absolute(toks[1].text, origin)
+	case "SRV":
+		if err := want(4); err != nil {
+			return err
+		}
+		if rec.Priority, err = num(toks[0], "priority"); err != nil {
+			return err
+		}
+		if rec.Weight, err = num(toks[1], "weight"); err != nil {
+			return err
+		}
+		if rec.Port, err = num(toks[2], "port"); err != nil {
+			return err
+		}
+		rec.Data = Absolute(toks[3].text, origin)
+	case "CAA":
+		if err := want(3); err != nil {
+			return err
+		}
+		if rec.Flags, err = num(toks[0], "flags"); err != nil || rec.Flags > 255 {
+			return fmt.Errorf("invalid flags %q", toks[0].text)
+		}
+		rec.Tag = toks[1].text
+		rec.Data = toks[2].text
+	case "TXT", "SPF":
+		if len(toks) == 0 {
+			return errors.New("missing text")
+		}
+		var b strings.Builder
+		for _, t := range toks {
+			b.WriteString(t.text)
+		}
+		rec.Data = b.String()
+	default:
+		if len(toks) == 0 {
+			return errors.New("missing data")
+		}
+		texts := make([]string, 0, len(toks))
+		for _, t := range toks {
+			texts = append(texts, t.text)
+		}
+		rec.Data = strings.Join(texts, " ")
+	}
+	return nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// entry is a logical line of a zone file, which parentheses may spread over
+// several physical lines.
+type entry struct {
+	line int
+	// blankOwner is set when the entry starts with white space, meaning that
+	// it has the same owner as the entry before it.
+	blankOwner bool
+	tokens     []token
+}
+
+func lex(src string) ([]entry, error) {
+	var (
+		entries   []entry
+		cur       *entry
+		depth     int
+		line      = 1
+		lineStart = true
+	)
+	add := func(t token) {
+		if cur == nil {
+			cur = &entry{line: line}
+		}
+		cur.tokens = append(cur.tokens, t)
+	}
+	flush := func() {
+		if cur != nil && len(cur.tokens) > 0 {
+			entries = append(entries, *cur)
+		}
+		cur = nil
+	}
+
+	for i := 0; i < len(src); {
+		ch := src[i]
+		atStart := lineStart
+		lineStart = false
+
+		switch ch {
+		case '\n':
+			line++
+			lineStart = true
+			if depth == 0 {
+				flush()
+			}
+			i++
+		case ';':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			lineStart = atStart
+		case ' ', '\t', '\r':
+			if atStart && depth == 0 {
+				cur = &entry{line: line, blankOwner: true}
+			}
+			i++
+		case '(':
+			depth++
+			i++
+		case ')':
+			if depth == 0 {
+				return nil, &ParseError{Line: line, Err: errors.New("unbalanced parentheses")}
+			}
+			depth--
+			i++
+		case '"':
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(src) || src[i] == '\n' {
+					return nil, &ParseError{Line: line, Err: errors.New("unterminated quoted string")}
+				}
+				if src[i] == '"' {
+					i++
+					break
+				}
+				n := unescape(src[i:], &b)
+				i += n
+			}
+			add(token{text: b.String(), quoted: true})
+		default:
+			var b strings.Builder
+			for i < len(src) && !strings.ContainsRune(" \t\r\n;()\"", rune(src[i])) {
+				i += unescape(src[i:], &b)
+			}
+			add(token{text: b.String()})
+		}
+	}
+	if depth != 0 {
+		return nil, &ParseError{Line: line, Err: errors.New("unbalanced parentheses")}
+	}
+	flush()
+	return entries, nil
+}
+
+// unescape writes the first character of s to b, decoding a \X or \DDD escape,
+// and returns the number of bytes it used.
+func unescape(s string, b *strings.Builder) int {
+	if s[0] != '\\' || len(s) < 2 {
+		b.WriteByte(s[0])
+		return 1
+	}
+	if len(s) >= 4 && isDigit(s[1]) && isDigit(s[2]) && isDigit(s[3]) {
+		n, _ := strconv.Atoi(s[1:4])
+		if n <= 255 {
+			b.WriteByte(byte(n))
+			return 4
+		}
+	}
+	b.WriteByte(s[1])
+	return 2
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Write writes records as a zone file for origin. defaultTTL is written as
+// the $TTL directive when it is not 0. Records are sorted by name and type,
+// and TXT records longer than 255 bytes are split into several strings.
+func Write(w io.Writer, origin string, defaultTTL int, records []Record) error {
+	sorted := append([]Record(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Name != b.Name {
+			// The origin comes first.
+			return a.Name == "@" || (b.Name != "@" && a.Name < b.Name)
+		}
+		return a.Type < b.Type
+	})
+
+	fmt.Fprintf(w, "$ORIGIN %s\n", Fqdn(origin))
+	if defaultTTL != 0 {
+		fmt.Fprintf(w, "$TTL %d\n", defaultTTL)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 1, '\t', 0)
+	for _, r := range sorted {
+		ttl := ""
+		if r.TTL != 0 {
+			ttl = strconv.Itoa(r.TTL)
+		}
+		fmt.Fprintf(tw, "%s\t%s\tIN\t%s\t%s\n", r.Name, ttl, r.Type, formatData(r))
+	}
+	return tw.Flush()
+}
+
+func formatData(r Record) string {
+	switch r.Type {
+	case "MX":
+		return fmt.Sprintf("%d %s", r.Priority, r.Data)
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Data)
+	case "CAA":
+		return fmt.Sprintf("%d %s %s", r.Flags, r.Tag, quote(r.Data))
+	case "TXT", "SPF":
+		return QuoteText(r.Data)
+	}
+	return r.Data
+}
+
+// QuoteText returns text as one or more quoted character strings of at most
+// 255 bytes each, separated by spaces.
+func QuoteText(text string) string {
+	chunks := SplitText(text)
+	quoted := make([]string, 0, len(chunks))
+	for _, c := range chunks {
+		quoted = append(quoted, quote(c))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// SplitText splits text into character strings of at most 255 bytes.
+func SplitText(text string) []string {
+	if text == "" {
+		return []string{""}
+	}
+	var chunks []string
+	for len(text) > maxStringLength {
+		chunks = append(chunks, text[:maxStringLength])
+		text = text[maxStringLength:]
+	}
+	return append(chunks, text)
+}
+
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c > '~':
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package zonefile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testZone = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.example.net. hostmaster.example.com. (
+		2024010101 ; serial
+		7200       ; refresh
+		3600 1209600 3600 )
+	IN	NS	ns1.example.net.
+@	300	IN	A	192.0.2.1
+	IN 300	AAAA	2001:db8::1
+www		CNAME	@
+mail	1d	MX	10 mx1
+_sip._tcp	SRV	10 60 5060 sip.example.com.
+@	CAA	0 issue "letsencrypt.org"
+@	TXT	"v=spf1 include:_spf.example.net ~all"
+dkim._domainkey	TXT	( "v=DKIM1; k=rsa; "
+		"p=MIIBIjAN\"quoted\"" ) ; comment
+$ORIGIN sub.example.com.
+api	A	192.0.2.2
+`
+
+func TestParse(t *testing.T) {
+	records, err := Parse(strings.NewReader(testZone), "example.com")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{Name: "@", TTL: 3600, Type: "SOA", Data: "ns1.example.net. hostmaster.example.com. 2024010101 7200 3600 1209600 3600", Line: 3},
+		{Name: "@", TTL: 3600, Type: "NS", Data: "ns1.example.net.", Line: 7},
+		{Name: "@", TTL: 300, Type: "A", Data: "192.0.2.1", Line: 8},
+		{Name: "@", TTL: 300, Type: "AAAA", Data: "2001:db8::1", Line: 9},
+		{Name: "www", TTL: 3600, Type: "CNAME", Data: "example.com.", Line: 10},
+		{Name: "mail", TTL: 86400, Type: "MX", Data: "mx1.example.com.", Priority: 10, Line: 11},
+		{Name: "_sip._tcp", TTL: 3600, Type: "SRV", Data: "sip.example.com.", Priority: 10, Weight: 60, Port: 5060, Line: 12},
+		{Name: "@", TTL: 3600, Type: "CAA", Data: "letsencrypt.org", Flags: 0, Tag: "issue", Line: 13},
+		{Name: "@", TTL: 3600, Type: "TXT", Data: "v=spf1 include:_spf.example.net ~all", Line: 14},
+		{Name: "dkim._domainkey", TTL: 3600, Type: "TXT", Data: `v=DKIM1; k=rsa; p=MIIBIjAN"quoted"`, Line: 15},
+		{Name: "api.sub", TTL: 3600, Type: "A", Data: "192.0.2.2", Line: 18},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		err  string
+	}{
+		{name: "bad address", zone: "@ A 2001:db8::1\n", err: `line 1: A record: invalid address "2001:db8::1"`},
+		{name: "missing fields", zone: "@ MX mx1\n", err: "line 1: MX record: expected 2 fields, found 1"},
+		{name: "outside zone", zone: "www.example.org. A 192.0.2.1\n", err: "line 1: www.example.org. is outside the zone example.com."},
+		{name: "no owner", zone: "  A 192.0.2.1\n", err: "line 1: the first record must have an owner name"},
+		{name: "include", zone: "$INCLUDE other.zone\n", err: "line 1: the $INCLUDE directive is not supported"},
+		{name: "unbalanced", zone: "@ TXT ( \"a\"\n", err: "line 2: unbalanced parentheses"},
+		{name: "unterminated", zone: "@ TXT \"a\n", err: "line 1: unterminated quoted string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.zone), "example.com")
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	for s, want := range map[string]int{"300": 300, "1h": 3600, "1h30m": 5400, "1w": 604800} {
+		got, ok := parseTTL(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, want, got, s)
+	}
+	for _, s := range []string{"", "IN", "1x", "h"} {
+		_, ok := parseTTL(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	records := []Record{
+		{Name: "www", TTL: 300, Type: "CNAME", Data: "example.com."},
+		{Name: "@", Type: "TXT", Data: long},
+		{Name: "@", TTL: 3600, Type: "MX", Data: "mx1.example.com.", Priority: 10},
+		{Name: "@", Type: "CAA", Data: "letsencrypt.org", Tag: "issue"},
+		{Name: "_sip._tcp", Type: "SRV", Data: "sip.example.com.", Priority: 10, Weight: 60, Port: 5060},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, "example.com", 1800, records))
+	assert.Contains(t, buf.String(), `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`)
+
+	parsed, err := Parse(&buf, "example.com")
+	require.NoError(t, err)
+	require.Len(t, parsed, len(records))
+
+	assert.Equal(t, "CAA", parsed[0].Type)
+	assert.Equal(t, "MX", parsed[1].Type)
+	assert.Equal(t, 3600, parsed[1].TTL)
+	assert.Equal(t, long, parsed[2].Data)
+	assert.Equal(t, 1800, parsed[2].TTL)
+	assert.Equal(t, 5060, parsed[3].Port)
+	assert.Equal(t, "www", parsed[4].Name)
+}
+
+func TestRelative(t *testing.T) {
+	name, ok := Relative("WWW.Example.com.", "example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "WWW", name)
+
+	name, ok = Relative("example.com", "example.com.")
+	assert.True(t, ok)
+	assert.Equal(t, "@", name)
+
+	_, ok = Relative("badexample.com.", "example.com.")
+	assert.False(t, ok)
+}